@@ -2,24 +2,56 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/coryzibell/matrix/internal/identity"
+	"github.com/coryzibell/matrix/internal/output"
 	"github.com/coryzibell/matrix/internal/ram"
 )
 
+// Accessibility finding categories, aligned with common WCAG concerns
+const (
+	A11yColorOnly       = "color-only"       // WCAG 1.4.1 Use of Color
+	A11yTextAlternative = "text-alternative" // WCAG 1.1.1 Non-text Content
+	A11yUnclearHeading  = "unclear-heading"  // WCAG 2.4.6 Headings and Labels
+)
+
 // AccessibilityIssue represents a potential accessibility barrier
 type AccessibilityIssue struct {
 	File        string
 	LineNumber  int
 	Type        string
+	Category    string
+	Severity    Severity
 	Description string
+	Remediation string
+}
+
+// AccessibilityReport contains the full audit result
+type AccessibilityReport struct {
+	CommandsAudited int                 `json:"commands_audited"`
+	Score           int                 `json:"score"`
+	Findings        []AccessibilityJSON `json:"findings"`
+	Accessible      []string            `json:"accessible"`
+}
+
+// AccessibilityJSON is the JSON representation of an AccessibilityIssue
+type AccessibilityJSON struct {
+	File        string `json:"file"`
+	Line        int    `json:"line,omitempty"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Remediation string `json:"remediation"`
 }
 
 // runAltRoutes implements the alt-routes command
@@ -36,6 +68,8 @@ func runAltRoutes() error {
 		return auditAccessibility()
 	case "strip":
 		return stripANSI()
+	case "plain":
+		return renderPlain()
 	case "search":
 		return searchRAM()
 	case "list":
@@ -53,22 +87,32 @@ func printAltRoutesUsage() {
 	fmt.Println("alt-routes - Accessibility audit and alternative output formats")
 	fmt.Println("")
 	fmt.Println("Usage:")
-	fmt.Println("  matrix alt-routes audit")
-	fmt.Println("  matrix alt-routes strip < input.txt")
+	fmt.Println("  matrix alt-routes audit [--dir <path>] [--json]")
+	fmt.Println("  matrix alt-routes strip [--plain] < input.txt")
+	fmt.Println("  matrix alt-routes plain <command> [args...]")
 	fmt.Println("  matrix alt-routes search <term>")
 	fmt.Println("  matrix alt-routes list")
 	fmt.Println("")
 	fmt.Println("Subcommands:")
 	fmt.Println("  audit    Audit matrix commands for accessibility issues")
 	fmt.Println("  strip    Read stdin, strip ANSI codes, output plain text")
+	fmt.Println("  plain    Run any matrix command with screen-reader-friendly output")
 	fmt.Println("  search   Search RAM files for term (plain text)")
 	fmt.Println("  list     List identities with connection counts (plain text)")
 }
 
 // auditAccessibility scans matrix command files for accessibility issues
 func auditAccessibility() error {
+	fs := flag.NewFlagSet("alt-routes-audit", flag.ExitOnError)
+	dirFlag := fs.String("dir", "/home/w3surf/work/personal/code/matrix/cmd/matrix", "Directory containing command sources")
+	jsonFlag := fs.Bool("json", false, "Output as JSON")
+
+	if len(os.Args) > 3 {
+		fs.Parse(os.Args[3:])
+	}
+
 	// Find all .go command files
-	cmdDir := "/home/w3surf/work/personal/code/matrix/cmd/matrix"
+	cmdDir := *dirFlag
 	files, err := filepath.Glob(filepath.Join(cmdDir, "*.go"))
 	if err != nil {
 		return fmt.Errorf("failed to find command files: %w", err)
@@ -76,15 +120,19 @@ func auditAccessibility() error {
 
 	var issues []AccessibilityIssue
 	var accessibleFiles []string
+	audited := 0
 
 	// Patterns to detect accessibility issues
 	colorPattern := regexp.MustCompile(`(?:output\.(Green|Cyan|Yellow|Red|Dim)|"\033\[)`)
 	noColorPattern := regexp.MustCompile(`NoColor|--no-color|--plain`)
+	emojiPattern := regexp.MustCompile(`[\x{1F300}-\x{1FAFF}\x{2600}-\x{27BF}]`)
+	headingPattern := regexp.MustCompile(`fmt\.Print(?:ln|f)?\("[═━─]+\s`)
+	separatorPattern := regexp.MustCompile(`strings\.Repeat\("[═━─]"`)
 
 	for _, filePath := range files {
 		// Skip main.go and alt_routes.go itself
 		base := filepath.Base(filePath)
-		if base == "main.go" || base == "alt_routes.go" {
+		if base == "main.go" || base == "alt_routes.go" || strings.HasSuffix(base, "_test.go") {
 			continue
 		}
 
@@ -92,6 +140,7 @@ func auditAccessibility() error {
 		if err != nil {
 			continue
 		}
+		audited++
 
 		fileContent := string(content)
 		lines := strings.Split(fileContent, "\n")
@@ -114,11 +163,38 @@ func auditAccessibility() error {
 				hasNoColorSupport = true
 			}
 
+			// Headings built from decorative glyphs are read out symbol by symbol
+			if headingPattern.MatchString(line) {
+				fileIssues = append(fileIssues, AccessibilityIssue{
+					File:        base,
+					LineNumber:  lineNum,
+					Type:        "decorated-heading",
+					Category:    A11yUnclearHeading,
+					Severity:    SeverityLow,
+					Description: "Heading wrapped in decorative box-drawing characters",
+					Remediation: "Use a plain text heading (e.g. \"Dependency Graph:\") in plain mode",
+				})
+				continue
+			}
+
+			if separatorPattern.MatchString(line) {
+				fileIssues = append(fileIssues, AccessibilityIssue{
+					File:        base,
+					LineNumber:  lineNum,
+					Type:        "separator",
+					Category:    A11yUnclearHeading,
+					Severity:    SeverityLow,
+					Description: "Decorative separator line adds noise for screen readers",
+					Remediation: "Replace with a blank line or omit in plain mode",
+				})
+				continue
+			}
+
 			// Check for ASCII art or visual formatting
-			if strings.Contains(line, "├") || strings.Contains(line, "└") ||
+			hasEmoji := emojiPattern.MatchString(line)
+			if hasEmoji || strings.Contains(line, "├") || strings.Contains(line, "└") ||
 				strings.Contains(line, "─") || strings.Contains(line, "│") ||
-				strings.Contains(line, "→") || strings.Contains(line, "🌱") ||
-				strings.Contains(line, "🌿") {
+				strings.Contains(line, "→") {
 
 				// Check if there's also plain text alternative in same context
 				hasPlainAlternative := false
@@ -131,12 +207,22 @@ func auditAccessibility() error {
 				}
 
 				if !hasPlainAlternative {
-					fileIssues = append(fileIssues, AccessibilityIssue{
+					issue := AccessibilityIssue{
 						File:        base,
 						LineNumber:  lineNum,
 						Type:        "visual-formatting",
+						Category:    A11yTextAlternative,
+						Severity:    SeverityLow,
 						Description: "Uses visual formatting without plain text alternative",
-					})
+						Remediation: "Pair symbols with words (e.g. \"->\" or \"depends on\") in plain mode",
+					}
+					if hasEmoji {
+						issue.Type = "emoji"
+						issue.Severity = SeverityMedium
+						issue.Description = "Emoji conveys meaning without a text alternative"
+						issue.Remediation = "Add a text label next to the emoji or drop it in plain mode"
+					}
+					fileIssues = append(fileIssues, issue)
 				}
 			}
 		}
@@ -147,7 +233,10 @@ func auditAccessibility() error {
 				File:        base,
 				LineNumber:  0,
 				Type:        "no-color-flag",
+				Category:    A11yColorOnly,
+				Severity:    SeverityHigh,
 				Description: "Uses ANSI colors without --no-color flag support",
+				Remediation: "Honor output.NoColor and label states in text (e.g. \"[HIGH]\"), not color alone",
 			})
 		}
 
@@ -158,37 +247,67 @@ func auditAccessibility() error {
 		}
 	}
 
+	score := accessibilityScore(issues, audited)
+
+	if *jsonFlag {
+		report := AccessibilityReport{
+			CommandsAudited: audited,
+			Score:           score,
+			Findings:        []AccessibilityJSON{},
+			Accessible:      accessibleFiles,
+		}
+		for _, issue := range issues {
+			report.Findings = append(report.Findings, AccessibilityJSON{
+				File:        issue.File,
+				Line:        issue.LineNumber,
+				Category:    issue.Category,
+				Severity:    issue.Severity.String(),
+				Description: issue.Description,
+				Remediation: issue.Remediation,
+			})
+		}
+		if report.Accessible == nil {
+			report.Accessible = []string{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
 	// Print audit report
 	fmt.Println("WHEELCHAIR Accessibility Audit")
 	fmt.Println("")
-	fmt.Printf("Commands Audited: %d\n", len(files)-2) // Exclude main.go and alt_routes.go
+	fmt.Printf("Commands Audited: %d\n", audited)
+	fmt.Printf("Accessibility Score: %d/100\n", score)
 	fmt.Println("")
 
 	if len(issues) > 0 {
 		fmt.Println("ISSUES FOUND:")
 		fmt.Println("")
 
-		// Group issues by file
-		issuesByFile := make(map[string][]AccessibilityIssue)
+		// Group issues by category, most severe first within each
+		issuesByCategory := make(map[string][]AccessibilityIssue)
 		for _, issue := range issues {
-			issuesByFile[issue.File] = append(issuesByFile[issue.File], issue)
+			issuesByCategory[issue.Category] = append(issuesByCategory[issue.Category], issue)
 		}
 
-		// Sort files for consistent output
-		files := make([]string, 0, len(issuesByFile))
-		for file := range issuesByFile {
-			files = append(files, file)
-		}
-		sort.Strings(files)
+		for _, category := range []string{A11yColorOnly, A11yTextAlternative, A11yUnclearHeading} {
+			items := issuesByCategory[category]
+			if len(items) == 0 {
+				continue
+			}
+			sort.SliceStable(items, func(i, j int) bool {
+				return items[i].Severity > items[j].Severity
+			})
 
-		for _, file := range files {
-			fmt.Printf("  %s\n", file)
-			for _, issue := range issuesByFile[file] {
+			fmt.Printf("  %s (%d)\n", strings.ToUpper(category), len(items))
+			for _, issue := range items {
 				if issue.LineNumber > 0 {
-					fmt.Printf("    WARNING (line %d): %s\n", issue.LineNumber, issue.Description)
+					fmt.Printf("    [%s] %s:%d: %s\n", issue.Severity, issue.File, issue.LineNumber, issue.Description)
 				} else {
-					fmt.Printf("    WARNING: %s\n", issue.Description)
+					fmt.Printf("    [%s] %s: %s\n", issue.Severity, issue.File, issue.Description)
 				}
+				fmt.Printf("      Remediation: %s\n", issue.Remediation)
 			}
 			fmt.Println("")
 		}
@@ -215,14 +334,52 @@ func auditAccessibility() error {
 	return nil
 }
 
-// stripANSI reads from stdin, strips ANSI escape sequences, writes to stdout
+// accessibilityScore computes a 0-100 score as the average of per-command
+// scores, where each command loses points according to finding severity
+func accessibilityScore(issues []AccessibilityIssue, audited int) int {
+	if audited == 0 {
+		return 100
+	}
+
+	penalties := map[Severity]int{
+		SeverityHigh:   25,
+		SeverityMedium: 5,
+		SeverityLow:    1,
+	}
+
+	penaltyByFile := make(map[string]int)
+	for _, issue := range issues {
+		penaltyByFile[issue.File] += penalties[issue.Severity]
+	}
+
+	total := 0
+	for _, penalty := range penaltyByFile {
+		if penalty > 100 {
+			penalty = 100
+		}
+		total += 100 - penalty
+	}
+	// Commands without issues score full marks
+	total += (audited - len(penaltyByFile)) * 100
+
+	return total / audited
+}
+
+// stripANSI reads from stdin, strips ANSI escape sequences, writes to stdout.
+// With --plain, emoji and box-drawing glyphs are also converted to ASCII.
 func stripANSI() error {
+	plain := len(os.Args) > 3 && os.Args[3] == "--plain"
+
 	// ANSI escape sequence pattern
 	ansiPattern := regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
 
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
 		line := scanner.Text()
+		if plain {
+			fmt.Println(output.PlainText(line))
+			continue
+		}
 		cleaned := ansiPattern.ReplaceAllString(line, "")
 		fmt.Println(cleaned)
 	}
@@ -234,6 +391,58 @@ func stripANSI() error {
 	return nil
 }
 
+// plainChildArgs builds the arguments for the command renderPlain re-runs.
+// main strips the global flags before dispatch, so --quiet is passed back
+// on; --ram-dir reaches the child through identity.RAMDirEnv.
+func plainChildArgs(args []string) []string {
+	if output.Quiet {
+		return append([]string{"--quiet"}, args...)
+	}
+	return args
+}
+
+// renderPlain re-runs another matrix command and converts its output to plain
+// text: no ANSI, no emoji, ASCII headers and list markers
+func renderPlain() error {
+	if len(os.Args) < 4 {
+		return fmt.Errorf("usage: alt-routes plain <command> [args...]")
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate matrix executable: %w", err)
+	}
+
+	cmd := exec.Command(self, plainChildArgs(os.Args[3:])...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to capture output: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", os.Args[3], err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fmt.Println(output.PlainText(scanner.Text()))
+	}
+
+	if err := cmd.Wait(); err != nil {
+		// Preserve the wrapped command's exit status
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to run %s: %w", os.Args[3], err)
+	}
+
+	return nil
+}
+
 // searchRAM searches all RAM files for a term
 func searchRAM() error {
 	if len(os.Args) < 4 {
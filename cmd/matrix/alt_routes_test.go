@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coryzibell/matrix/internal/identity"
+	"github.com/coryzibell/matrix/internal/output"
+)
+
+func TestRenderPlainForwardsRAMDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(matrixTestExecEnv, "1")
+
+	ramDir := t.TempDir()
+	writeFixture(t, ramDir, map[string]string{
+		filepath.Join("smith", "task.md"): "# Task\nstatus: success ✅\n",
+	})
+	identity.SetRAMDir(ramDir)
+	defer identity.SetRAMDir("")
+
+	text, err := captureStdout(t, []string{"matrix", "alt-routes", "plain", "velocity"}, renderPlain)
+	if err != nil {
+		t.Fatalf("renderPlain() failed: %v", err)
+	}
+	if strings.Contains(text, "No garden found") || !strings.Contains(text, "Total Tasks: 1") {
+		t.Errorf("Expected the re-run command to read the --ram-dir fixture, got:\n%s", text)
+	}
+	if strings.Contains(text, "\x1b[") || strings.Contains(text, "⚡") {
+		t.Errorf("Expected plain output, got:\n%s", text)
+	}
+}
+
+func TestPlainChildArgs(t *testing.T) {
+	defer func() { output.Quiet = false }()
+
+	args := []string{"recon", "."}
+	if got := plainChildArgs(args); !reflect.DeepEqual(got, args) {
+		t.Errorf("plainChildArgs() = %v, want %v", got, args)
+	}
+
+	output.Quiet = true
+	want := []string{"--quiet", "recon", "."}
+	if got := plainChildArgs(args); !reflect.DeepEqual(got, want) {
+		t.Errorf("plainChildArgs() with --quiet = %v, want %v", got, want)
+	}
+}
+
+func TestAuditAccessibilityJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"loud.go": `package main
+
+func loud() {
+	fmt.Println("═══ REPORT ═══")
+	fmt.Println("🔥 hot path")
+	output.Item(output.Red + "fail" + output.Reset)
+}
+`,
+		"quiet.go": "package main\n\nfunc quiet() { fmt.Println(\"done\") }\n",
+	})
+
+	out, err := captureStdout(t, []string{"matrix", "alt-routes", "audit", "--dir", dir, "--json"}, auditAccessibility)
+	if err != nil {
+		t.Fatalf("auditAccessibility() failed: %v", err)
+	}
+
+	var report AccessibilityReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, out)
+	}
+	if report.CommandsAudited != 2 {
+		t.Errorf("Expected 2 commands audited, got %d", report.CommandsAudited)
+	}
+
+	got := make(map[string]string)
+	for _, finding := range report.Findings {
+		if finding.File != "loud.go" {
+			t.Errorf("Unexpected finding in %s: %+v", finding.File, finding)
+		}
+		got[finding.Category] = finding.Severity
+	}
+	want := map[string]string{
+		A11yColorOnly:       SeverityHigh.String(),
+		A11yTextAlternative: SeverityMedium.String(),
+		A11yUnclearHeading:  SeverityLow.String(),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Finding severities by category = %v, want %v", got, want)
+	}
+
+	// loud.go loses 25 + 5 + 1, quiet.go keeps full marks
+	if report.Score != (69+100)/2 {
+		t.Errorf("Expected score %d, got %d", (69+100)/2, report.Score)
+	}
+}
+
+func TestAccessibilityScore(t *testing.T) {
+	if got := accessibilityScore(nil, 0); got != 100 {
+		t.Errorf("Expected 100 with nothing audited, got %d", got)
+	}
+
+	var issues []AccessibilityIssue
+	for i := 0; i < 5; i++ {
+		issues = append(issues, AccessibilityIssue{File: "a.go", Severity: SeverityHigh})
+	}
+	issues = append(issues, AccessibilityIssue{File: "b.go", Severity: SeverityMedium})
+
+	// a.go bottoms out at 0, b.go scores 95, c.go is clean
+	if got := accessibilityScore(issues, 3); got != (0+95+100)/3 {
+		t.Errorf("accessibilityScore() = %d, want %d", got, (0+95+100)/3)
+	}
+}
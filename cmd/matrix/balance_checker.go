@@ -11,6 +11,7 @@ import (
 
 	"github.com/coryzibell/matrix/internal/output"
 	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // Assertion represents a structural claim extracted from architectural docs
@@ -119,10 +120,7 @@ func runBalanceChecker() error {
 
 	for _, target := range targets {
 		// Expand tilde
-		if strings.HasPrefix(target, "~") {
-			homeDir, _ := os.UserHomeDir()
-			target = strings.Replace(target, "~", homeDir, 1)
-		}
+		target = util.ExpandPath(target)
 
 		// Verify target exists
 		if _, err := os.Stat(target); os.IsNotExist(err) {
@@ -433,9 +431,5 @@ func truncateDescription(desc string, maxLen int) string {
 	desc = regexp.MustCompile(`\s+`).ReplaceAllString(desc, " ")
 	desc = strings.TrimSpace(desc)
 
-	if len(desc) <= maxLen {
-		return desc
-	}
-
-	return desc[:maxLen-3] + "..."
+	return util.Truncate(desc, maxLen)
 }
@@ -2,16 +2,26 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/coryzibell/matrix/internal/output"
 	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // Severity levels for security findings
@@ -52,29 +62,133 @@ func (s Severity) Color() string {
 // Finding represents a security issue discovered
 type Finding struct {
 	Severity       Severity
-	Category       string // credentials, permissions, injection, staleness
+	Category       string // credentials, permissions, injection, staleness, history
 	FilePath       string
 	Line           int
 	Description    string
 	MatchedContent string
 	Recommendation string
+	Commit         string // history findings: commit that introduced the match
 }
 
 // ScanConfig holds configuration for the breach-points scan
 type ScanConfig struct {
-	TargetPath      string
-	ScanCredentials bool
-	ScanPermissions bool
-	ScanInjection   bool
-	ScanStaleness   bool
-	StaleDays       int
-	OutputJSON      bool
-	FailOnLevel     Severity
+	TargetPath        string
+	ScanCredentials   bool
+	ScanPermissions   bool
+	ScanInjection     bool
+	ScanStaleness     bool
+	StaleDays         int
+	OutputJSON        bool
+	FailOnLevel       Severity
+	WebhookURL        string
+	NotifyOnLevel     Severity
+	ScanHistory       bool
+	HistoryDepth      int // max commits to walk in history mode
+	Workers           int // files processed concurrently
+	RulesFile         string
+	CustomRules       []credentialPattern // loaded from RulesFile
+	MaxPerCategory    int                 // findings shown per category in text output, 0 = no limit
+	ScanTestDirs      bool                // report credentials and staleness in test/example directories too
+	SeverityOverrides map[string]Severity // category -> severity, from --severity
+}
+
+// bpDefaultMaxPerCategory is generous enough that only noisy scans get cut
+const bpDefaultMaxPerCategory = 50
+
+// bpTestDirs are directory names whose fake secrets and old fixtures are
+// intentional. Credentials and staleness findings under them are suppressed
+// unless --scan-test-dirs is given.
+var bpTestDirs = map[string]bool{
+	"testdata":     true,
+	"test":         true,
+	"tests":        true,
+	"__tests__":    true,
+	"spec":         true,
+	"fixtures":     true,
+	"__fixtures__": true,
+	"examples":     true,
+	"example":      true,
+	"samples":      true,
+}
+
+// bpTestDirCategories are the finding categories suppressed in test dirs
+var bpTestDirCategories = map[string]bool{
+	"credentials": true,
+	"staleness":   true,
+}
+
+// breachIgnorePattern is an inline suppression comment, e.g. "# breach-ignore"
+// or "// breach-ignore: test key". It silences findings on its own line, or on
+// the next line when the comment stands alone.
+var breachIgnorePattern = regexp.MustCompile(`(?:#|//|--|/\*|<!--|;)\s*breach-ignore\b`)
+
+// credentialPattern is a regex that flags a line as containing a credential
+type credentialPattern struct {
+	regex          *regexp.Regexp
+	description    string
+	severity       Severity
+	recommendation string // empty uses defaultCredentialRecommendation
+}
+
+// defaultCredentialRecommendation is suggested for credential findings
+// whose pattern doesn't carry its own advice
+const defaultCredentialRecommendation = "Move to secure credential store (environment variables, secrets manager)"
+
+// credentialRule is one entry in a --rules file
+type credentialRule struct {
+	Pattern        string `json:"pattern"`
+	Description    string `json:"description"`
+	Severity       string `json:"severity"`
+	Recommendation string `json:"recommendation"`
+}
+
+// credentialPatterns are shared by the working tree and git history scans.
+// The "secret" group marks the value sanitizeSecret masks; patterns without
+// it have the whole match masked.
+var credentialPatterns = []credentialPattern{
+	// High severity - obvious secrets
+	{regexp.MustCompile(`(?i)(aws_access_key_id|AWS_ACCESS_KEY_ID)\s*[=:]\s*["']?(?P<secret>[A-Z0-9]{20})["']?`), "AWS Access Key ID", SeverityHigh, ""},
+	{regexp.MustCompile(`(?i)(aws_secret_access_key|AWS_SECRET_ACCESS_KEY)\s*[=:]\s*["']?(?P<secret>[A-Za-z0-9/+=]{40})["']?`), "AWS Secret Access Key", SeverityHigh, ""},
+	{regexp.MustCompile(`(?i)(github_token|GITHUB_TOKEN|GH_TOKEN)\s*[=:]\s*["']?(?P<secret>ghp_[A-Za-z0-9]{36})["']?`), "GitHub Personal Access Token", SeverityHigh, ""},
+	{regexp.MustCompile(`(?i)(github_token|GITHUB_TOKEN|GH_TOKEN)\s*[=:]\s*["']?(?P<secret>gho_[A-Za-z0-9]{36})["']?`), "GitHub OAuth Token", SeverityHigh, ""},
+	{regexp.MustCompile(`(?i)(private[_-]?key|PRIVATE[_-]?KEY)\s*[=:]\s*["']?(?P<secret>-+BEGIN\s+[A-Z\s]+PRIVATE\s+KEY-+.*)`), "Private Key", SeverityHigh, ""},
+	{regexp.MustCompile(`(?i)(?P<secret>sk_live_[A-Za-z0-9]{24,})`), "Stripe Live Secret Key", SeverityHigh, ""},
+
+	// Medium severity - potential secrets
+	{regexp.MustCompile(`(?i)(password|passwd|pwd)\s*[=:]\s*["'](?P<secret>[^"'\s]{8,})["']`), "Hardcoded password", SeverityMedium, ""},
+	{regexp.MustCompile(`(?i)(api[_-]?key|apikey)\s*[=:]\s*["'](?P<secret>[^"'\s]{16,})["']`), "API Key", SeverityMedium, ""},
+	{regexp.MustCompile(`(?i)(secret|token)\s*[=:]\s*["'](?P<secret>[A-Za-z0-9+/=]{32,})["']`), "Secret or Token", SeverityMedium, ""},
+	{regexp.MustCompile(`(?i)(database[_-]?url|db[_-]?url)\s*[=:]\s*["']?(postgres|mysql|mongodb)://(?P<secret>[^"'\s]+)["']?`), "Database URL with credentials", SeverityMedium, ""},
+
+	// JWT tokens
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), "JWT Token", SeverityMedium, ""},
+}
+
+// webhookFinding is a redacted finding sent to notification webhooks
+type webhookFinding struct {
+	Severity    string `json:"severity"`
+	Category    string `json:"category"`
+	File        string `json:"file"`
+	Line        int    `json:"line,omitempty"`
+	Description string `json:"description"`
+}
+
+// webhookPayload is the JSON body POSTed to notification webhooks.
+// Text makes the payload render directly in Slack-compatible receivers.
+type webhookPayload struct {
+	Text     string           `json:"text"`
+	Target   string           `json:"target"`
+	Counts   map[string]int   `json:"counts"`
+	Findings []webhookFinding `json:"findings"`
 }
 
 // runBreachPoints implements the breach-points command
 func runBreachPoints() error {
-	config := parseBPFlags()
+	config, err := parseBPFlags()
+	if err != nil {
+		return err
+	}
 
 	// Default scan mode: all if no specific scan is requested
 	if !config.ScanCredentials && !config.ScanPermissions && !config.ScanInjection && !config.ScanStaleness {
@@ -95,34 +209,70 @@ func runBreachPoints() error {
 		return fmt.Errorf("path does not exist: %s", absPath)
 	}
 
+	// Skip checks that don't mean anything on this platform
+	for _, note := range applyPlatformLimits(&config, runtime.GOOS) {
+		fmt.Fprintf(os.Stderr, "Note: %s\n", note)
+	}
+
+	// Load custom credential rules
+	if config.RulesFile != "" {
+		rules, err := loadCredentialRules(config.RulesFile)
+		if err != nil {
+			return err
+		}
+		config.CustomRules = rules
+	}
+
 	// Run scans
-	findings := []Finding{}
+	findings, ignored := scanTree(absPath, config)
 
+	// Hidden .env files aren't walked above; check the ones git would commit.
+	// Credentials sort first, so these go to the front.
 	if config.ScanCredentials {
-		credFindings := scanCredentials(absPath)
-		findings = append(findings, credFindings...)
+		envFindings, err := scanUnignoredEnvFiles(absPath, config.CustomRules)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Note: .env gitignore check skipped: %v\n", err)
+		}
+		findings = append(envFindings, findings...)
 	}
 
-	if config.ScanPermissions {
-		permFindings := scanPermissions(absPath)
-		findings = append(findings, permFindings...)
+	if config.ScanHistory {
+		historyFindings, err := scanGitHistory(absPath, config.HistoryDepth, config.CustomRules)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: history scan skipped: %v\n", err)
+		}
+		findings = append(findings, historyFindings...)
 	}
 
-	if config.ScanInjection {
-		injFindings := scanInjection(absPath)
-		findings = append(findings, injFindings...)
+	suppressed := 0
+	if !config.ScanTestDirs {
+		findings, suppressed = suppressTestDirFindings(findings)
 	}
 
-	if config.ScanStaleness {
-		staleFindings := scanStaleness(absPath, config.StaleDays)
-		findings = append(findings, staleFindings...)
-	}
+	// Team severity overrides apply before anything reads severities
+	applySeverityOverrides(findings, config.SeverityOverrides)
 
 	// Output results
 	if config.OutputJSON {
-		outputBPJSON(findings)
+		if err := outputBPJSON(findings); err != nil {
+			return err
+		}
+		if suppressed > 0 {
+			fmt.Fprintf(os.Stderr, "Note: %d findings in test/example directories suppressed (use --scan-test-dirs to include them)\n", suppressed)
+		}
+		if ignored > 0 {
+			fmt.Fprintf(os.Stderr, "Note: %d findings suppressed by breach-ignore comments\n", ignored)
+		}
 	} else {
-		outputText(findings, absPath)
+		shown, hidden := capFindingsPerCategory(findings, config.MaxPerCategory)
+		outputText(shown, hidden, suppressed, ignored, absPath)
+	}
+
+	// Notify webhook (failures are logged, never fatal)
+	if config.WebhookURL != "" {
+		if err := notifyWebhook(config.WebhookURL, findings, config.NotifyOnLevel, absPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: webhook notification failed: %v\n", err)
+		}
 	}
 
 	// Determine exit code
@@ -135,11 +285,15 @@ func runBreachPoints() error {
 }
 
 // parseBPFlags parses command-line flags for breach-points
-func parseBPFlags() ScanConfig {
+func parseBPFlags() (ScanConfig, error) {
 	config := ScanConfig{
-		TargetPath:  "",
-		StaleDays:   90,
-		FailOnLevel: 0,
+		TargetPath:     "",
+		StaleDays:      90,
+		FailOnLevel:    0,
+		NotifyOnLevel:  SeverityHigh,
+		HistoryDepth:   500,
+		Workers:        runtime.NumCPU(),
+		MaxPerCategory: bpDefaultMaxPerCategory,
 	}
 
 	// Default RAM directory
@@ -195,342 +349,1188 @@ func parseBPFlags() ScanConfig {
 
 		case arg == "--fail-on" && i+1 < len(args):
 			i++
-			level := strings.ToLower(args[i])
-			switch level {
-			case "low":
-				config.FailOnLevel = SeverityLow
-			case "medium":
-				config.FailOnLevel = SeverityMedium
-			case "high":
-				config.FailOnLevel = SeverityHigh
+			if level := parseSeverity(args[i]); level > 0 {
+				config.FailOnLevel = level
+			}
+
+		case arg == "--webhook" && i+1 < len(args):
+			i++
+			config.WebhookURL = args[i]
+
+		case arg == "--notify-on" && i+1 < len(args):
+			i++
+			if level := parseSeverity(args[i]); level > 0 {
+				config.NotifyOnLevel = level
+			}
+
+		case arg == "--history":
+			config.ScanHistory = true
+
+		case arg == "--history-depth" && i+1 < len(args):
+			i++
+			depth, err := strconv.Atoi(args[i])
+			if err == nil && depth > 0 {
+				config.HistoryDepth = depth
+			}
+
+		case arg == "--workers" && i+1 < len(args):
+			i++
+			workers, err := strconv.Atoi(args[i])
+			if err == nil && workers > 0 {
+				config.Workers = workers
+			}
+
+		case arg == "--rules" && i+1 < len(args):
+			i++
+			config.RulesFile = args[i]
+
+		case arg == "--max-per-category" && i+1 < len(args):
+			i++
+			max, err := strconv.Atoi(args[i])
+			if err == nil && max >= 0 {
+				config.MaxPerCategory = max
+			}
+
+		case arg == "--scan-test-dirs":
+			config.ScanTestDirs = true
+
+		case arg == "--severity" && i+1 < len(args):
+			i++
+			category, level, err := parseSeverityOverride(args[i])
+			if err != nil {
+				return config, err
+			}
+			if config.SeverityOverrides == nil {
+				config.SeverityOverrides = make(map[string]Severity)
 			}
+			config.SeverityOverrides[category] = level
 		}
 	}
 
-	return config
+	return config, nil
 }
 
-// scanCredentials searches for exposed credentials
-func scanCredentials(rootPath string) []Finding {
-	var findings []Finding
+// parseSeverityOverride parses a --severity category=level value, e.g.
+// "staleness=low" or "injection=high"
+func parseSeverityOverride(value string) (string, Severity, error) {
+	category, levelName, ok := strings.Cut(value, "=")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid --severity %q: want category=level, e.g. staleness=low", value)
+	}
+	category = strings.ToLower(strings.TrimSpace(category))
+	if _, known := bpCategoryOrder[category]; !known {
+		return "", 0, fmt.Errorf("invalid --severity %q: unknown category %q (use credentials, permissions, injection, staleness, or history)", value, category)
+	}
+	level := parseSeverity(strings.TrimSpace(levelName))
+	if level == 0 {
+		return "", 0, fmt.Errorf("invalid --severity %q: unknown level %q (use low, medium, or high)", value, levelName)
+	}
+	return category, level, nil
+}
+
+// applySeverityOverrides remaps every finding in an overridden category to
+// the team's chosen severity
+func applySeverityOverrides(findings []Finding, overrides map[string]Severity) {
+	for i := range findings {
+		if level, ok := overrides[findings[i].Category]; ok {
+			findings[i].Severity = level
+		}
+	}
+}
+
+// parseSeverity converts a level name to a Severity, returning 0 if unknown
+func parseSeverity(level string) Severity {
+	switch strings.ToLower(level) {
+	case "low":
+		return SeverityLow
+	case "medium":
+		return SeverityMedium
+	case "high":
+		return SeverityHigh
+	}
+	return 0
+}
+
+// bpCategoryOrder is the order categories are reported in
+var bpCategoryOrder = map[string]int{
+	"credentials": 0,
+	"permissions": 1,
+	"injection":   2,
+	"staleness":   3,
+	"history":     4,
+}
+
+// bpFileJob is a file queued for scanning
+type bpFileJob struct {
+	seq  int // position in walk order
+	path string
+	info os.FileInfo
+}
+
+// scanTree walks rootPath once and runs the enabled scanners on each file
+// across config.Workers goroutines. Findings are sorted by category, then
+// walk order, so output doesn't depend on scheduling.
+func scanTree(rootPath string, config ScanConfig) ([]Finding, int) {
+	workers := config.Workers
+	if workers < 1 {
+		workers = 1
+	}
 
-	// Credential patterns
-	patterns := []struct {
-		regex       *regexp.Regexp
-		description string
-		severity    Severity
-	}{
-		// High severity - obvious secrets
-		{regexp.MustCompile(`(?i)(aws_access_key_id|AWS_ACCESS_KEY_ID)\s*[=:]\s*["']?([A-Z0-9]{20})["']?`), "AWS Access Key ID", SeverityHigh},
-		{regexp.MustCompile(`(?i)(aws_secret_access_key|AWS_SECRET_ACCESS_KEY)\s*[=:]\s*["']?([A-Za-z0-9/+=]{40})["']?`), "AWS Secret Access Key", SeverityHigh},
-		{regexp.MustCompile(`(?i)(github_token|GITHUB_TOKEN|GH_TOKEN)\s*[=:]\s*["']?(ghp_[A-Za-z0-9]{36})["']?`), "GitHub Personal Access Token", SeverityHigh},
-		{regexp.MustCompile(`(?i)(github_token|GITHUB_TOKEN|GH_TOKEN)\s*[=:]\s*["']?(gho_[A-Za-z0-9]{36})["']?`), "GitHub OAuth Token", SeverityHigh},
-		{regexp.MustCompile(`(?i)(private[_-]?key|PRIVATE[_-]?KEY)\s*[=:]\s*["']?(-+BEGIN\s+[A-Z\s]+PRIVATE\s+KEY-+)`), "Private Key", SeverityHigh},
-		{regexp.MustCompile(`(?i)(sk_live_[A-Za-z0-9]{24,})`), "Stripe Live Secret Key", SeverityHigh},
-
-		// Medium severity - potential secrets
-		{regexp.MustCompile(`(?i)(password|passwd|pwd)\s*[=:]\s*["']([^"'\s]{8,})["']`), "Hardcoded password", SeverityMedium},
-		{regexp.MustCompile(`(?i)(api[_-]?key|apikey)\s*[=:]\s*["']([^"'\s]{16,})["']`), "API Key", SeverityMedium},
-		{regexp.MustCompile(`(?i)(secret|token)\s*[=:]\s*["']([A-Za-z0-9+/=]{32,})["']`), "Secret or Token", SeverityMedium},
-		{regexp.MustCompile(`(?i)(database[_-]?url|db[_-]?url)\s*[=:]\s*["']?(postgres|mysql|mongodb)://[^"'\s]+["']?`), "Database URL with credentials", SeverityMedium},
-
-		// JWT tokens
-		{regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), "JWT Token", SeverityMedium},
-	}
-
-	// Walk directory
+	jobs := make(chan bpFileJob)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	byFile := make(map[int][]Finding)
+	ignored := 0
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				fileFindings, fileIgnored := scanBPFile(rootPath, job.path, job.info, config)
+				if len(fileFindings) == 0 && fileIgnored == 0 {
+					continue
+				}
+				mu.Lock()
+				byFile[job.seq] = fileFindings
+				ignored += fileIgnored
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seq := 0
+	progress := output.NewProgress("Scanning")
 	filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() || shouldSkipFile(path, info) {
-			if info != nil && info.IsDir() && shouldSkipDir(info.Name()) {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != rootPath && shouldSkipDir(info.Name()) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		progress.Tick()
+		if !shouldSkipFile(path, info) {
+			jobs <- bpFileJob{seq: seq, path: path, info: info}
+			seq++
+		}
+		return nil
+	})
+	close(jobs)
+	wg.Wait()
+	progress.Done()
 
-		// Only scan text files
-		if !isBPTextFile(strings.ToLower(filepath.Ext(path))) {
-			return nil
+	// Reassemble in walk order, then group by category; the stable sort
+	// keeps each file's findings in line and pattern order
+	findings := []Finding{}
+	for i := 0; i < seq; i++ {
+		findings = append(findings, byFile[i]...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return bpCategoryOrder[findings[i].Category] < bpCategoryOrder[findings[j].Category]
+	})
+
+	return findings, ignored
+}
+
+// capFindingsPerCategory keeps at most max findings per category, dropping
+// the lowest severities first, and returns the kept findings in their original
+// order with the number hidden per category. max <= 0 keeps everything.
+func capFindingsPerCategory(findings []Finding, max int) ([]Finding, map[string]int) {
+	hidden := make(map[string]int)
+	if max <= 0 {
+		return findings, hidden
+	}
+
+	byCategory := make(map[string][]int)
+	for i, f := range findings {
+		byCategory[f.Category] = append(byCategory[f.Category], i)
+	}
+
+	keep := make([]bool, len(findings))
+	for category, indexes := range byCategory {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return findings[indexes[a]].Severity > findings[indexes[b]].Severity
+		})
+		for rank, i := range indexes {
+			if rank < max {
+				keep[i] = true
+			} else {
+				hidden[category]++
+			}
 		}
+	}
 
-		// Read file
-		file, err := os.Open(path)
-		if err != nil {
-			return nil
+	kept := make([]Finding, 0, len(findings))
+	for i, f := range findings {
+		if keep[i] {
+			kept = append(kept, f)
+		}
+	}
+	return kept, hidden
+}
+
+// suppressTestDirFindings drops credentials and staleness findings in test
+// and example directories, returning the rest and how many were dropped
+func suppressTestDirFindings(findings []Finding) ([]Finding, int) {
+	kept := make([]Finding, 0, len(findings))
+	suppressed := 0
+	for _, f := range findings {
+		if bpTestDirCategories[f.Category] && inBPTestDir(f.FilePath) {
+			suppressed++
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept, suppressed
+}
+
+// inBPTestDir reports whether any directory in a relative path is a
+// recognized test or example directory
+func inBPTestDir(relPath string) bool {
+	dirs := strings.Split(filepath.ToSlash(filepath.Dir(relPath)), "/")
+	for _, dir := range dirs {
+		if bpTestDirs[strings.ToLower(dir)] {
+			return true
 		}
-		defer file.Close()
+	}
+	return false
+}
+
+// scanBPFile runs every enabled scanner against one file, reading it at
+// most once
+func scanBPFile(rootPath, path string, info os.FileInfo, config ScanConfig) ([]Finding, int) {
+	var findings []Finding
+	relPath, _ := filepath.Rel(rootPath, path)
+	ext := strings.ToLower(filepath.Ext(path))
 
-		scanner := bufio.NewScanner(file)
-		lineNum := 0
+	if config.ScanPermissions {
+		findings = append(findings, checkPermissions(relPath, info)...)
+	}
 
-		for scanner.Scan() {
-			lineNum++
-			line := scanner.Text()
+	wantCredentials := config.ScanCredentials && isBPTextFile(ext)
+	wantInjection := config.ScanInjection && (ext == ".sh" || ext == ".bash")
+	threshold := time.Now().AddDate(0, 0, -config.StaleDays)
+	wantStaleness := config.ScanStaleness && !info.ModTime().After(threshold)
+	if !wantCredentials && !wantInjection && !wantStaleness {
+		return findings, 0
+	}
 
-			// Check each pattern
-			for _, pattern := range patterns {
-				if pattern.regex.MatchString(line) {
-					relPath, _ := filepath.Rel(rootPath, path)
-					findings = append(findings, Finding{
-						Severity:       pattern.severity,
-						Category:       "credentials",
-						FilePath:       relPath,
-						Line:           lineNum,
-						Description:    pattern.description + " exposed",
-						MatchedContent: sanitizeSecret(line),
-						Recommendation: "Move to secure credential store (environment variables, secrets manager)",
-					})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return findings, 0
+	}
+	content := string(data)
+	ignored := 0
+
+	if wantCredentials || wantInjection {
+		lines := strings.Split(content, "\n")
+		for i := range lines {
+			lines[i] = strings.TrimSuffix(lines[i], "\r")
+		}
+		if wantCredentials {
+			findings = append(findings, scanCredentials(relPath, ext, data, lines, config.CustomRules)...)
+		}
+		if wantInjection {
+			findings = append(findings, checkInjection(relPath, lines)...)
+		}
+		findings, ignored = suppressInlineIgnores(findings, lines)
+	}
+
+	if wantStaleness {
+		findings = append(findings, checkStaleness(relPath, info, content)...)
+	}
+
+	return findings, ignored
+}
+
+// suppressInlineIgnores drops line findings marked with a breach-ignore
+// comment and returns the kept findings with the number dropped
+func suppressInlineIgnores(findings []Finding, lines []string) ([]Finding, int) {
+	kept := findings[:0]
+	ignored := 0
+	for _, f := range findings {
+		if f.Line > 0 && lineIgnored(lines, f.Line) {
+			ignored++
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept, ignored
+}
+
+// lineIgnored reports whether a 1-based line carries a breach-ignore comment
+// or follows a line that is nothing but one
+func lineIgnored(lines []string, line int) bool {
+	if line <= len(lines) && breachIgnorePattern.MatchString(lines[line-1]) {
+		return true
+	}
+	if line < 2 || line-2 >= len(lines) {
+		return false
+	}
+	loc := breachIgnorePattern.FindStringIndex(strings.TrimSpace(lines[line-2]))
+	return loc != nil && loc[0] == 0
+}
+
+// scanCredentials runs the line and structured credential checks on one
+// file. recon's security concerns come from here too, so both commands agree
+// on what counts as a secret.
+func scanCredentials(relPath, ext string, data []byte, lines []string, custom []credentialPattern) []Finding {
+	findings := checkCredentials(relPath, lines, custom)
+
+	// Nested config secrets the line patterns can't see
+	flagged := make(map[int]bool)
+	for _, f := range findings {
+		flagged[f.Line] = true
+	}
+	return append(findings, checkStructuredSecrets(relPath, ext, data, lines, flagged)...)
+}
+
+// checkCredentials searches a file's lines for exposed credentials using the
+// built-in patterns plus any custom rules
+func checkCredentials(relPath string, lines []string, custom []credentialPattern) []Finding {
+	var findings []Finding
+	patterns := withCustomRules(custom)
+
+	for i, line := range lines {
+		// Check each pattern
+		for _, pattern := range patterns {
+			if pattern.regex.MatchString(line) {
+				recommendation := pattern.recommendation
+				if recommendation == "" {
+					recommendation = defaultCredentialRecommendation
 				}
+				findings = append(findings, Finding{
+					Severity:       pattern.severity,
+					Category:       "credentials",
+					FilePath:       relPath,
+					Line:           i + 1,
+					Description:    pattern.description + " exposed",
+					MatchedContent: sanitizeSecret(line, pattern.regex),
+					Recommendation: recommendation,
+				})
 			}
 		}
+	}
+
+	return findings
+}
 
+// structuredValue is a string value found in a JSON or YAML config file
+type structuredValue struct {
+	Path  string // dotted key path, e.g. auth.token or servers[].password
+	Key   string // last key in Path
+	Value string
+	Line  int
+}
+
+// sensitiveKeyPattern matches config keys that usually hold secrets
+var sensitiveKeyPattern = regexp.MustCompile(`(?i)(passw(or)?d|^pwd$|secret|token|api[_-]?key|apikey|private[_-]?key|access[_-]?key|credential|(^|[_.-])key$)`)
+
+// secretPlaceholders are values that name a secret without containing it
+var secretPlaceholders = []string{"changeme", "example", "placeholder", "redacted", "your_", "your-", "xxxx", "****", "todo"}
+
+// checkStructuredSecrets walks the values of a JSON or YAML file and flags
+// string values under sensitive keys that look like real secrets. This
+// catches nested secrets the line regexes can't see, e.g.
+// {"auth": {"token": "..."}}. Lines in skipLines already have a credential
+// finding and aren't reported twice.
+func checkStructuredSecrets(relPath, ext string, data []byte, lines []string, skipLines map[int]bool) []Finding {
+	var values []structuredValue
+	switch ext {
+	case ".json":
+		values = walkJSONValues(data)
+	case ".yml", ".yaml":
+		values = walkYAMLValues(lines)
+	default:
 		return nil
-	})
+	}
+
+	var findings []Finding
+	for _, v := range values {
+		if skipLines[v.Line] || !sensitiveKeyPattern.MatchString(v.Key) || !looksLikeSecret(v.Value) {
+			continue
+		}
+
+		matched := ""
+		if v.Line >= 1 && v.Line <= len(lines) {
+			matched = strings.Replace(lines[v.Line-1], v.Value, redactedSecret, 1)
+		}
+		findings = append(findings, Finding{
+			Severity:       SeverityMedium,
+			Category:       "credentials",
+			FilePath:       relPath,
+			Line:           v.Line,
+			Description:    fmt.Sprintf("Secret in config key %s exposed", v.Path),
+			MatchedContent: util.Truncate(strings.TrimSpace(matched), 120),
+			Recommendation: defaultCredentialRecommendation,
+		})
+	}
 
 	return findings
 }
 
-// scanPermissions checks for overly permissive files containing sensitive data
-func scanPermissions(rootPath string) []Finding {
-	var findings []Finding
+// looksLikeSecret reports whether a value could be a real credential rather
+// than a reference, placeholder, or ordinary setting
+func looksLikeSecret(value string) bool {
+	if len(value) < 8 || strings.ContainsAny(value, " \t") {
+		return false
+	}
 
-	// Sensitive file patterns
-	sensitivePatterns := []string{
-		"password", "secret", "token", "key", "credential", "auth",
-		"private", "confidential", ".env", "config",
+	// URLs only matter when they embed credentials
+	if strings.Contains(value, "://") && !strings.Contains(value, "@") {
+		return false
 	}
 
-	filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
-			if info != nil && info.IsDir() && shouldSkipDir(info.Name()) {
-				return filepath.SkipDir
+	// References to secrets kept elsewhere
+	for _, prefix := range []string{"${", "{{", "<", "$", "%", "env:", "vault:", "/", "./", "~/"} {
+		if strings.HasPrefix(value, prefix) {
+			return false
+		}
+	}
+
+	lower := strings.ToLower(value)
+	for _, placeholder := range secretPlaceholders {
+		if strings.Contains(lower, placeholder) {
+			return false
+		}
+	}
+	if strings.Trim(value, value[:1]) == "" {
+		return false // one repeated character
+	}
+
+	// Short values need a digit or symbol to look like a secret rather than
+	// an identifier such as created_at; long opaque ones qualify as is
+	hasDigit := strings.ContainsAny(value, "0123456789")
+	hasSymbol := strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.')
+	}) >= 0
+	return len(value) >= 16 || hasDigit || hasSymbol
+}
+
+// walkJSONValues streams a JSON document and returns its string values with
+// their key paths and line numbers. Invalid JSON (e.g. with comments) yields
+// whatever was read before the error.
+func walkJSONValues(data []byte) []structuredValue {
+	type frame struct {
+		path      string
+		isObject  bool
+		expectKey bool
+		key       string
+	}
+
+	var values []structuredValue
+	var stack []*frame
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	// childPath is the path of the value about to be read in top
+	childPath := func(top *frame) (string, string) {
+		if top == nil {
+			return "", ""
+		}
+		if !top.isObject {
+			return top.path + "[]", ""
+		}
+		if top.path == "" {
+			return top.key, top.key
+		}
+		return top.path + "." + top.key, top.key
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		var top *frame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				path, _ := childPath(top)
+				stack = append(stack, &frame{path: path, isObject: delim == '{', expectKey: delim == '{'})
+			case '}', ']':
+				stack = stack[:len(stack)-1]
+				if len(stack) > 0 && stack[len(stack)-1].isObject {
+					stack[len(stack)-1].expectKey = true
+				}
 			}
-			return nil
+			continue
 		}
 
-		if shouldSkipFile(path, info) {
-			return nil
+		if top != nil && top.isObject && top.expectKey {
+			top.key, _ = token.(string)
+			top.expectKey = false
+			continue
 		}
 
-		// Check if filename suggests sensitive content
-		filename := strings.ToLower(filepath.Base(path))
-		isSensitive := false
-		for _, pattern := range sensitivePatterns {
-			if strings.Contains(filename, pattern) {
-				isSensitive = true
-				break
+		if s, ok := token.(string); ok {
+			path, key := childPath(top)
+			if key == "" && top != nil && !top.isObject {
+				key = lastPathKey(top.path)
 			}
+			offset := int(decoder.InputOffset())
+			values = append(values, structuredValue{
+				Path:  path,
+				Key:   key,
+				Value: s,
+				Line:  bytes.Count(data[:offset], []byte("\n")) + 1,
+			})
 		}
+		if top != nil && top.isObject {
+			top.expectKey = true
+		}
+	}
 
-		if !isSensitive {
-			return nil
+	return values
+}
+
+// lastPathKey returns the final key of a dotted path, ignoring list markers
+func lastPathKey(path string) string {
+	path = strings.TrimRight(path, "[]")
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+// walkYAMLValues reads "key: value" pairs from YAML lines, tracking nesting
+// by indentation. It covers the block mappings and lists config files use,
+// not the full YAML spec: flow collections and block scalars are skipped.
+func walkYAMLValues(lines []string) []structuredValue {
+	type level struct {
+		indent int
+		path   string
+	}
+
+	var values []structuredValue
+	var stack []level
+	blockIndent := -1 // inside a | or > block scalar deeper than this
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" || trimmed == "..." {
+			continue
 		}
 
-		// Check permissions
-		mode := info.Mode()
-		perm := mode.Perm()
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
 
-		// Check if world-readable (others have read permission)
-		if perm&0004 != 0 {
-			relPath, _ := filepath.Rel(rootPath, path)
-			findings = append(findings, Finding{
-				Severity:       SeverityMedium,
-				Category:       "permissions",
-				FilePath:       relPath,
-				Line:           0,
-				Description:    fmt.Sprintf("Overly permissive file (%s)", mode.String()),
-				MatchedContent: fmt.Sprintf("File permissions: %o", perm),
-				Recommendation: "chmod 600 (owner read/write only)",
-			})
+		// A "- key: value" list item opens a level for the item's keys
+		if strings.HasPrefix(trimmed, "- ") {
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			listPath := "[]"
+			if len(stack) > 0 {
+				listPath = stack[len(stack)-1].path + "[]"
+			}
+			stack = append(stack, level{indent: indent, path: listPath})
+			indent += 2
+			trimmed = strings.TrimSpace(trimmed[2:])
 		}
 
-		// Check if group-readable on sensitive files
-		if perm&0040 != 0 {
-			relPath, _ := filepath.Rel(rootPath, path)
-			findings = append(findings, Finding{
-				Severity:       SeverityLow,
-				Category:       "permissions",
-				FilePath:       relPath,
-				Line:           0,
-				Description:    fmt.Sprintf("Group-readable sensitive file (%s)", mode.String()),
-				MatchedContent: fmt.Sprintf("File permissions: %o", perm),
-				Recommendation: "chmod 600 (owner read/write only)",
-			})
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found || strings.ContainsAny(key, " \t\"'{[") && !isQuotedYAMLKey(key) {
+			continue
 		}
+		key = strings.Trim(key, `"'`)
+		value = strings.TrimSpace(value)
 
-		return nil
-	})
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		parent := ""
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1].path
+		}
+		path := key
+		if parent != "" {
+			path = parent + "." + key
+		}
 
-	return findings
+		if value == "" || strings.HasPrefix(value, "#") {
+			stack = append(stack, level{indent: indent, path: path})
+			continue
+		}
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			blockIndent = indent
+			continue
+		}
+
+		values = append(values, structuredValue{
+			Path:  path,
+			Key:   key,
+			Value: unquoteYAMLValue(value),
+			Line:  i + 1,
+		})
+	}
+
+	return values
+}
+
+// isQuotedYAMLKey reports whether key is a single quoted string
+func isQuotedYAMLKey(key string) bool {
+	return len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0]
+}
+
+// unquoteYAMLValue strips quotes, or a trailing comment from a plain value
+func unquoteYAMLValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// withCustomRules returns the built-in credential patterns followed by custom
+func withCustomRules(custom []credentialPattern) []credentialPattern {
+	if len(custom) == 0 {
+		return credentialPatterns
+	}
+	patterns := make([]credentialPattern, 0, len(credentialPatterns)+len(custom))
+	patterns = append(patterns, credentialPatterns...)
+	return append(patterns, custom...)
+}
+
+// loadCredentialRules reads a JSON array of credential rules. Every rule is
+// validated up front and all bad ones are reported together, so a typo in one
+// regex doesn't hide the rest.
+func loadCredentialRules(path string) ([]credentialPattern, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var rules []credentialRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file %s: %w", path, err)
+	}
+
+	var patterns []credentialPattern
+	var problems []string
+	for i, rule := range rules {
+		label := fmt.Sprintf("rule %d", i+1)
+		if rule.Description != "" {
+			label = fmt.Sprintf("rule %d (%s)", i+1, rule.Description)
+		}
+
+		if rule.Pattern == "" {
+			problems = append(problems, label+": missing pattern")
+			continue
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+
+		severity := SeverityMedium
+		if rule.Severity != "" {
+			severity = parseSeverity(rule.Severity)
+			if severity == 0 {
+				problems = append(problems, fmt.Sprintf("%s: unknown severity %q (use low, medium, or high)", label, rule.Severity))
+				continue
+			}
+		}
+
+		description := rule.Description
+		if description == "" {
+			description = "Custom credential pattern"
+		}
+
+		patterns = append(patterns, credentialPattern{
+			regex:          re,
+			description:    description,
+			severity:       severity,
+			recommendation: rule.Recommendation,
+		})
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid rules in %s:\n  %s", path, strings.Join(problems, "\n  "))
+	}
+
+	return patterns, nil
 }
 
-// scanInjection checks shell scripts for injection vulnerabilities
-func scanInjection(rootPath string) []Finding {
+// envFilePattern matches .env and .env.* file names
+var envFilePattern = regexp.MustCompile(`^\.env(\..+)?$`)
+
+// envAssignment matches a KEY=value line in a .env file
+var envAssignment = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_.-]*)\s*=\s*(.*)$`)
+
+// findEnvFileSecrets finds .env and .env.* files under rootPath that hold
+// secret-looking values, one HIGH finding per file at its first secret.
+// Whether git would commit them is left to scanUnignoredEnvFiles.
+func findEnvFileSecrets(rootPath string, custom []credentialPattern) []Finding {
 	var findings []Finding
 
-	// Injection patterns
-	patterns := []struct {
-		regex       *regexp.Regexp
-		description string
-		severity    Severity
-		recommendation string
-	}{
-		{
-			regexp.MustCompile(`\beval\s+`),
-			"Use of eval",
-			SeverityHigh,
-			"Avoid eval; use safer alternatives",
-		},
-		{
-			regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*\s`),
-			"Potentially unquoted variable",
-			SeverityMedium,
-			"Quote variables: \"$VAR\" to prevent word splitting",
-		},
-		{
-			regexp.MustCompile(`\$\{[^}]+\}\s`),
-			"Potentially unquoted parameter expansion",
-			SeverityMedium,
-			"Quote expansions: \"${VAR}\" to prevent injection",
-		},
-		{
-			regexp.MustCompile(`\$\([^)]+\)\s`),
-			"Potentially unquoted command substitution",
-			SeverityMedium,
-			"Quote command substitution: \"$(cmd)\" to prevent injection",
-		},
-		{
-			regexp.MustCompile(`rm\s+-rf\s+\$`),
-			"Dangerous rm -rf with variable",
-			SeverityHigh,
-			"Use absolute paths and validate variables before destructive operations",
-		},
-	}
-
-	// Walk directory
 	filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
-			if info != nil && info.IsDir() && shouldSkipDir(info.Name()) {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != rootPath && shouldSkipDir(info.Name()) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
-
-		if shouldSkipFile(path, info) {
+		if !envFilePattern.MatchString(info.Name()) || info.Size() > 10*1024*1024 {
 			return nil
 		}
 
-		// Only scan shell scripts
-		ext := strings.ToLower(filepath.Ext(path))
-		if ext != ".sh" && ext != ".bash" {
+		data, err := os.ReadFile(path)
+		if err != nil {
 			return nil
 		}
+		relPath, _ := filepath.Rel(rootPath, path)
+		if finding, ok := envFileSecret(relPath, strings.Split(string(data), "\n"), custom); ok {
+			findings = append(findings, finding)
+		}
+		return nil
+	})
 
-		// Read file
-		file, err := os.Open(path)
-		if err != nil {
-			return nil
+	return findings
+}
+
+// envFileSecret reports the first secret-looking assignment in a .env file,
+// counting the rest in the description
+func envFileSecret(relPath string, lines []string, custom []credentialPattern) (Finding, bool) {
+	patterns := withCustomRules(custom)
+	var keys []string
+	var first Finding
+
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		m := envAssignment.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		key, value := m[1], strings.TrimSpace(m[2])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if idx := strings.Index(value, " #"); idx >= 0 {
+			value = strings.TrimSpace(value[:idx])
+		}
+
+		matched := ""
+		for _, pattern := range patterns {
+			if pattern.regex.MatchString(line) {
+				matched = sanitizeSecret(line, pattern.regex)
+				break
+			}
 		}
-		defer file.Close()
+		if matched == "" && sensitiveKeyPattern.MatchString(key) && looksLikeSecret(value) {
+			matched = strings.Replace(strings.TrimSpace(line), value, redactedSecret, 1)
+		}
+		if matched == "" {
+			continue
+		}
+
+		if len(keys) == 0 {
+			first = Finding{
+				Severity:       SeverityHigh,
+				Category:       "credentials",
+				FilePath:       relPath,
+				Line:           i + 1,
+				MatchedContent: util.Truncate(matched, 120),
+				Recommendation: "Add .env* to .gitignore before the secrets get committed",
+			}
+		}
+		keys = append(keys, key)
+	}
 
-		scanner := bufio.NewScanner(file)
-		lineNum := 0
+	if len(keys) == 0 {
+		return Finding{}, false
+	}
+	first.Description = fmt.Sprintf(".env file with secrets (%s)", strings.Join(keys, ", "))
+	return first, true
+}
 
-		for scanner.Scan() {
-			lineNum++
-			line := scanner.Text()
+// scanUnignoredEnvFiles reports .env files with secrets that git would
+// commit: files not matched by .gitignore, or already tracked. Targets
+// outside a git work tree have nothing to check.
+func scanUnignoredEnvFiles(rootPath string, custom []credentialPattern) ([]Finding, error) {
+	candidates := findEnvFileSecrets(rootPath, custom)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
 
-			// Skip comments and empty lines
-			trimmed := strings.TrimSpace(line)
-			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
-				continue
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git not found in PATH")
+	}
+	check := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	check.Dir = rootPath
+	if err := check.Run(); err != nil {
+		return nil, nil
+	}
+
+	var findings []Finding
+	for _, f := range candidates {
+		// Tracked files are never "ignored", so check them first
+		tracked := exec.Command("git", "ls-files", "--error-unmatch", "--", f.FilePath)
+		tracked.Dir = rootPath
+		if tracked.Run() == nil {
+			f.Description += " is committed to git"
+			f.Recommendation = "Untrack it (git rm --cached), add .env* to .gitignore, and rotate the committed secrets"
+			findings = append(findings, f)
+			continue
+		}
+
+		// check-ignore exits 0 when ignored, 1 when not
+		ignored := exec.Command("git", "check-ignore", "-q", "--", f.FilePath)
+		ignored.Dir = rootPath
+		err := ignored.Run()
+		if err == nil {
+			continue
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return findings, fmt.Errorf("git check-ignore failed for %s: %w", f.FilePath, err)
+		}
+		f.Description += " not excluded by .gitignore"
+		findings = append(findings, f)
+	}
+
+	return findings, nil
+}
+
+// historyCommitMarker prefixes commit header lines in git log output so they
+// can't be confused with diff content
+const historyCommitMarker = "\x01"
+
+// scanGitHistory runs the credential patterns against lines added in the last
+// maxCommits commits touching rootPath. Each leak is reported once, attributed
+// to the oldest commit in range that added it, and flagged when it has since
+// been removed from the working tree.
+func scanGitHistory(rootPath string, maxCommits int, custom []credentialPattern) ([]Finding, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git not found in PATH")
+	}
+
+	check := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	check.Dir = rootPath
+	if err := check.Run(); err != nil {
+		return nil, fmt.Errorf("%s is not inside a git repository", rootPath)
+	}
+
+	// --relative keeps paths relative to rootPath and limits the diff to it
+	cmd := exec.Command("git", "log", "-p", "--no-color", "--no-ext-diff", "--unified=0",
+		"--relative", "-n", strconv.Itoa(maxCommits),
+		"--format="+historyCommitMarker+"%h%x09%an%x09%ad", "--date=short")
+	cmd.Dir = rootPath
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run git log: %w", err)
+	}
+
+	findings := parseGitHistory(stdout, rootPath, custom)
+
+	if err := cmd.Wait(); err != nil {
+		return findings, fmt.Errorf("git log failed: %w", err)
+	}
+
+	return findings, nil
+}
+
+// parseGitHistory scans `git log -p` output (newest commit first) for
+// credentials in added lines, using the built-in patterns plus any custom rules
+func parseGitHistory(r io.Reader, rootPath string, custom []credentialPattern) []Finding {
+	type leakKey struct {
+		file, line, description string
+	}
+
+	var order []leakKey
+	leaks := make(map[leakKey]Finding)
+	currentFiles := make(map[string]string) // working tree content cache
+
+	var commit, file string
+	lineNum := 0
+	patterns := withCustomRules(custom)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, historyCommitMarker):
+			parts := strings.SplitN(strings.TrimPrefix(line, historyCommitMarker), "\t", 3)
+			commit = parts[0]
+			if len(parts) == 3 {
+				commit = fmt.Sprintf("%s (%s, %s)", parts[0], parts[1], parts[2])
+			}
+			file = ""
+
+		case strings.HasPrefix(line, "+++ "):
+			file = ""
+			if path := strings.TrimPrefix(line, "+++ "); strings.HasPrefix(path, "b/") {
+				path = strings.TrimPrefix(path, "b/")
+				if isBPTextFile(strings.ToLower(filepath.Ext(path))) {
+					file = path
+				}
 			}
 
-			// Check each pattern
+		case strings.HasPrefix(line, "@@ "):
+			lineNum = parseHunkStart(line)
+
+		case strings.HasPrefix(line, "+") && file != "":
+			added := line[1:]
 			for _, pattern := range patterns {
-				if pattern.regex.MatchString(line) {
-					relPath, _ := filepath.Rel(rootPath, path)
-					findings = append(findings, Finding{
-						Severity:       pattern.severity,
-						Category:       "injection",
-						FilePath:       relPath,
-						Line:           lineNum,
-						Description:    pattern.description,
-						MatchedContent: strings.TrimSpace(line),
-						Recommendation: pattern.recommendation,
-					})
+				if !pattern.regex.MatchString(added) {
+					continue
+				}
+
+				key := leakKey{file, strings.TrimSpace(added), pattern.description}
+				if _, seen := leaks[key]; !seen {
+					order = append(order, key)
 				}
+
+				// Later entries are older commits, so overwriting keeps the introducer
+				leaks[key] = Finding{
+					Severity:       pattern.severity,
+					Category:       "history",
+					FilePath:       file,
+					Line:           lineNum,
+					MatchedContent: sanitizeSecret(added, pattern.regex),
+					Commit:         commit,
+				}
+			}
+			lineNum++
+		}
+	}
+
+	var findings []Finding
+	for _, key := range order {
+		finding := leaks[key]
+
+		content, cached := currentFiles[key.file]
+		if !cached {
+			if data, err := os.ReadFile(filepath.Join(rootPath, key.file)); err == nil {
+				content = string(data)
 			}
+			currentFiles[key.file] = content
 		}
 
-		return nil
-	})
+		if strings.Contains(content, key.line) {
+			finding.Description = key.description + " committed to git history"
+			finding.Recommendation = "Rotate the credential and move it to a secure store; it is still in the working tree"
+		} else {
+			finding.Description = key.description + " in git history (removed from working tree)"
+			finding.Recommendation = "Rotate the credential - deleting it does not purge history (use git filter-repo or BFG to rewrite)"
+		}
+
+		findings = append(findings, finding)
+	}
 
 	return findings
 }
 
-// scanStaleness finds old files that may contain sensitive data
-func scanStaleness(rootPath string, staleDays int) []Finding {
-	var findings []Finding
+// parseHunkStart extracts the new-file starting line from a diff hunk header
+// like "@@ -10,2 +12,3 @@"
+func parseHunkStart(header string) int {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0
+	}
+	start := strings.SplitN(strings.TrimPrefix(fields[2], "+"), ",", 2)[0]
+	n, err := strconv.Atoi(start)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// Sensitive file name patterns for the permissions check
+var sensitiveFilePatterns = []string{
+	"password", "secret", "token", "key", "credential", "auth",
+	"private", "confidential", ".env", "config",
+}
+
+// Sensitive content patterns for the staleness check
+var staleSensitivePatterns = []string{
+	"password", "secret", "token", "key", "credential",
+	"debug", "trace", "log",
+}
 
-	threshold := time.Now().AddDate(0, 0, -staleDays)
+// injectionPatterns flag risky constructs in shell scripts
+var injectionPatterns = []struct {
+	regex          *regexp.Regexp
+	description    string
+	severity       Severity
+	recommendation string
+}{
+	{
+		regexp.MustCompile(`\beval\s+`),
+		"Use of eval",
+		SeverityHigh,
+		"Avoid eval; use safer alternatives",
+	},
+	{
+		regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*\s`),
+		"Potentially unquoted variable",
+		SeverityMedium,
+		"Quote variables: \"$VAR\" to prevent word splitting",
+	},
+	{
+		regexp.MustCompile(`\$\{[^}]+\}\s`),
+		"Potentially unquoted parameter expansion",
+		SeverityMedium,
+		"Quote expansions: \"${VAR}\" to prevent injection",
+	},
+	{
+		regexp.MustCompile(`\$\([^)]+\)\s`),
+		"Potentially unquoted command substitution",
+		SeverityMedium,
+		"Quote command substitution: \"$(cmd)\" to prevent injection",
+	},
+	{
+		regexp.MustCompile(`rm\s+-rf\s+\$`),
+		"Dangerous rm -rf with variable",
+		SeverityHigh,
+		"Use absolute paths and validate variables before destructive operations",
+	},
+}
 
-	// Sensitive patterns
-	sensitivePatterns := []string{
-		"password", "secret", "token", "key", "credential",
-		"debug", "trace", "log",
+// applyPlatformLimits disables scans that would mislead on goos and returns
+// a note for each. Windows has no Unix permission bits - Go synthesizes the
+// mode from the read-only attribute - so every file would look world-readable.
+func applyPlatformLimits(config *ScanConfig, goos string) []string {
+	var notes []string
+	if goos == "windows" && config.ScanPermissions {
+		config.ScanPermissions = false
+		notes = append(notes, "permissions scan skipped on Windows: file modes are synthetic and don't reflect ACLs (review access with icacls)")
 	}
+	return notes
+}
 
-	filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
-			if info != nil && info.IsDir() && shouldSkipDir(info.Name()) {
-				return filepath.SkipDir
-			}
-			return nil
-		}
+// checkPermissions flags overly permissive files containing sensitive data
+func checkPermissions(relPath string, info os.FileInfo) []Finding {
+	var findings []Finding
 
-		if shouldSkipFile(path, info) {
-			return nil
+	// Check if filename suggests sensitive content
+	filename := strings.ToLower(info.Name())
+	isSensitive := false
+	for _, pattern := range sensitiveFilePatterns {
+		if strings.Contains(filename, pattern) {
+			isSensitive = true
+			break
 		}
+	}
 
-		// Check if file is old
-		if info.ModTime().After(threshold) {
-			return nil
-		}
+	if !isSensitive {
+		return findings
+	}
 
-		// Check if file might contain sensitive data
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return nil
+	// Check permissions
+	mode := info.Mode()
+	perm := mode.Perm()
+
+	// Check if world-readable (others have read permission)
+	if perm&0004 != 0 {
+		findings = append(findings, Finding{
+			Severity:       SeverityMedium,
+			Category:       "permissions",
+			FilePath:       relPath,
+			Line:           0,
+			Description:    fmt.Sprintf("Overly permissive file (%s)", mode.String()),
+			MatchedContent: fmt.Sprintf("File permissions: %o", perm),
+			Recommendation: "chmod 600 (owner read/write only)",
+		})
+	}
+
+	// Check if group-readable on sensitive files
+	if perm&0040 != 0 {
+		findings = append(findings, Finding{
+			Severity:       SeverityLow,
+			Category:       "permissions",
+			FilePath:       relPath,
+			Line:           0,
+			Description:    fmt.Sprintf("Group-readable sensitive file (%s)", mode.String()),
+			MatchedContent: fmt.Sprintf("File permissions: %o", perm),
+			Recommendation: "chmod 600 (owner read/write only)",
+		})
+	}
+
+	return findings
+}
+
+// checkInjection checks a shell script's lines for injection vulnerabilities
+func checkInjection(relPath string, lines []string) []Finding {
+	var findings []Finding
+
+	for i, line := range lines {
+		// Skip comments and empty lines
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
 		}
 
-		contentStr := strings.ToLower(string(content))
-		hasSensitive := false
-		for _, pattern := range sensitivePatterns {
-			if strings.Contains(contentStr, pattern) {
-				hasSensitive = true
-				break
+		// Check each pattern
+		for _, pattern := range injectionPatterns {
+			if pattern.regex.MatchString(line) {
+				findings = append(findings, Finding{
+					Severity:       pattern.severity,
+					Category:       "injection",
+					FilePath:       relPath,
+					Line:           i + 1,
+					Description:    pattern.description,
+					MatchedContent: strings.TrimSpace(line),
+					Recommendation: pattern.recommendation,
+				})
 			}
 		}
+	}
 
-		if hasSensitive {
-			relPath, _ := filepath.Rel(rootPath, path)
-			daysSinceModified := int(time.Since(info.ModTime()).Hours() / 24)
+	return findings
+}
 
-			findings = append(findings, Finding{
-				Severity:       SeverityLow,
-				Category:       "staleness",
-				FilePath:       relPath,
-				Line:           0,
-				Description:    fmt.Sprintf("Stale file with sensitive content (%d days old)", daysSinceModified),
-				MatchedContent: fmt.Sprintf("Last modified: %s", info.ModTime().Format("2006-01-02")),
-				Recommendation: "Review and archive/delete if no longer needed",
-			})
+// checkStaleness flags an old file that may contain sensitive data
+func checkStaleness(relPath string, info os.FileInfo, content string) []Finding {
+	contentStr := strings.ToLower(content)
+	hasSensitive := false
+	for _, pattern := range staleSensitivePatterns {
+		if strings.Contains(contentStr, pattern) {
+			hasSensitive = true
+			break
 		}
+	}
 
+	if !hasSensitive {
 		return nil
-	})
+	}
 
-	return findings
+	daysSinceModified := int(time.Since(info.ModTime()).Hours() / 24)
+	return []Finding{{
+		Severity:       SeverityLow,
+		Category:       "staleness",
+		FilePath:       relPath,
+		Line:           0,
+		Description:    fmt.Sprintf("Stale file with sensitive content (%d days old)", daysSinceModified),
+		MatchedContent: fmt.Sprintf("Last modified: %s", info.ModTime().Format("2006-01-02")),
+		Recommendation: "Review and archive/delete if no longer needed",
+	}}
 }
 
 // shouldSkipDir returns true if directory should be skipped
@@ -574,20 +1574,42 @@ func isBPTextFile(ext string) bool {
 	return textExts[ext]
 }
 
-// sanitizeSecret redacts part of the secret for display
-func sanitizeSecret(line string) string {
-	// Simple redaction: show first and last few chars
-	if len(line) > 50 {
-		return line[:25] + "..." + line[len(line)-10:]
+// redactedSecret replaces credential values in displayed matches
+const redactedSecret = "****"
+
+// sanitizeSecret masks every credential matched by pattern in line, keeping
+// the surrounding context so the output is safe to share
+func sanitizeSecret(line string, pattern *regexp.Regexp) string {
+	secretGroup := pattern.SubexpIndex("secret")
+
+	var sb strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(line, -1) {
+		start, end := match[0], match[1]
+		if secretGroup > 0 && match[2*secretGroup] >= 0 {
+			start, end = match[2*secretGroup], match[2*secretGroup+1]
+		}
+		sb.WriteString(line[last:start])
+		sb.WriteString(redactedSecret)
+		last = end
 	}
-	return line
+	sb.WriteString(line[last:])
+
+	// Keep minified or generated lines readable
+	return util.Truncate(strings.TrimSpace(sb.String()), 120)
 }
 
 // outputText outputs findings in human-readable format
-func outputText(findings []Finding, targetPath string) {
+func outputText(findings []Finding, hidden map[string]int, suppressed, ignored int, targetPath string) {
 	if len(findings) == 0 {
 		output.Success("🔒 No breach points detected")
 		fmt.Printf("Target: %s\n", targetPath)
+		if suppressed > 0 {
+			fmt.Printf("%d findings in test/example directories suppressed (use --scan-test-dirs to include them)\n", suppressed)
+		}
+		if ignored > 0 {
+			fmt.Printf("%d findings suppressed by breach-ignore comments\n", ignored)
+		}
 		return
 	}
 
@@ -617,6 +1639,10 @@ func outputText(findings []Finding, targetPath string) {
 				fmt.Printf("  File: %s\n", finding.FilePath)
 			}
 
+			if finding.Commit != "" {
+				fmt.Printf("  Commit: %s\n", finding.Commit)
+			}
+
 			if finding.MatchedContent != "" {
 				fmt.Printf("  Match: %s\n", finding.MatchedContent)
 			}
@@ -626,48 +1652,123 @@ func outputText(findings []Finding, targetPath string) {
 		}
 	}
 
+	// Categories cut by --max-per-category
+	categories := make([]string, 0, len(hidden))
+	for category := range hidden {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		oi, oj := bpCategoryOrder[categories[i]], bpCategoryOrder[categories[j]]
+		if oi != oj {
+			return oi < oj
+		}
+		return categories[i] < categories[j]
+	})
+	hiddenTotal := 0
+	for _, category := range categories {
+		hiddenTotal += hidden[category]
+		fmt.Printf("%s... and %d more %s findings (raise --max-per-category, or 0 for all)%s\n",
+			output.Dim, hidden[category], category, output.Reset)
+	}
+	if len(categories) > 0 {
+		fmt.Println()
+	}
+
 	// Summary
 	fmt.Printf("Summary: %d findings (%d high, %d medium, %d low)\n",
 		len(findings),
 		len(bySeverity[SeverityHigh]),
 		len(bySeverity[SeverityMedium]),
 		len(bySeverity[SeverityLow]))
+	if hiddenTotal > 0 {
+		fmt.Printf("         %d more not shown\n", hiddenTotal)
+	}
+	if suppressed > 0 {
+		fmt.Printf("         %d in test/example directories suppressed (use --scan-test-dirs to include them)\n", suppressed)
+	}
+	if ignored > 0 {
+		fmt.Printf("         %d suppressed by breach-ignore comments\n", ignored)
+	}
+}
+
+// bpJSONFinding is the JSON shape of a breach-points finding
+type bpJSONFinding struct {
+	Severity       string `json:"severity"`
+	Category       string `json:"category"`
+	File           string `json:"file"`
+	Line           int    `json:"line,omitempty"`
+	Commit         string `json:"commit,omitempty"`
+	Description    string `json:"description"`
+	MatchedContent string `json:"matched_content"`
+	Recommendation string `json:"recommendation"`
 }
 
 // outputBPJSON outputs findings in JSON format
-func outputBPJSON(findings []Finding) {
-	fmt.Println("[")
-	for i, f := range findings {
-		comma := ","
-		if i == len(findings)-1 {
-			comma = ""
-		}
+func outputBPJSON(findings []Finding) error {
+	jsonFindings := []bpJSONFinding{}
+	for _, f := range findings {
+		jsonFindings = append(jsonFindings, bpJSONFinding{
+			Severity:       f.Severity.String(),
+			Category:       f.Category,
+			File:           f.FilePath,
+			Line:           f.Line,
+			Commit:         f.Commit,
+			Description:    f.Description,
+			MatchedContent: f.MatchedContent,
+			Recommendation: f.Recommendation,
+		})
+	}
+	return output.EmitJSON(jsonFindings)
+}
 
-		fmt.Printf("  {\n")
-		fmt.Printf("    \"severity\": \"%s\",\n", f.Severity.String())
-		fmt.Printf("    \"category\": \"%s\",\n", f.Category)
-		fmt.Printf("    \"file\": \"%s\",\n", escapeJSON(f.FilePath))
+// notifyWebhook POSTs a redacted summary to url when any finding meets notifyOn.
+// Matched content is never sent, only the location and description.
+func notifyWebhook(url string, findings []Finding, notifyOn Severity, targetPath string) error {
+	payload := webhookPayload{
+		Target:   targetPath,
+		Counts:   map[string]int{"high": 0, "medium": 0, "low": 0},
+		Findings: []webhookFinding{},
+	}
 
-		if f.Line > 0 {
-			fmt.Printf("    \"line\": %d,\n", f.Line)
+	for _, f := range findings {
+		payload.Counts[strings.ToLower(f.Severity.String())]++
+		if f.Severity >= notifyOn {
+			payload.Findings = append(payload.Findings, webhookFinding{
+				Severity:    f.Severity.String(),
+				Category:    f.Category,
+				File:        f.FilePath,
+				Line:        f.Line,
+				Description: f.Description,
+			})
 		}
+	}
 
-		fmt.Printf("    \"description\": \"%s\",\n", escapeJSON(f.Description))
-		fmt.Printf("    \"matched_content\": \"%s\",\n", escapeJSON(f.MatchedContent))
-		fmt.Printf("    \"recommendation\": \"%s\"\n", escapeJSON(f.Recommendation))
-		fmt.Printf("  }%s\n", comma)
+	// Nothing at or above the threshold - stay quiet
+	if len(payload.Findings) == 0 {
+		return nil
+	}
+
+	payload.Text = fmt.Sprintf("🚨 breach-points: %d finding(s) at %s or above in %s (%d high, %d medium, %d low)",
+		len(payload.Findings), notifyOn.String(), targetPath,
+		payload.Counts["high"], payload.Counts["medium"], payload.Counts["low"])
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
 	}
-	fmt.Println("]")
-}
 
-// escapeJSON escapes strings for JSON output
-func escapeJSON(s string) string {
-	s = strings.ReplaceAll(s, "\\", "\\\\")
-	s = strings.ReplaceAll(s, "\"", "\\\"")
-	s = strings.ReplaceAll(s, "\n", "\\n")
-	s = strings.ReplaceAll(s, "\r", "\\r")
-	s = strings.ReplaceAll(s, "\t", "\\t")
-	return s
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+
+	return nil
 }
 
 // determineExitCode returns appropriate exit code based on findings
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coryzibell/matrix/internal/identity"
+	"github.com/coryzibell/matrix/internal/output"
+)
+
+// commandInfo describes a top-level command for dispatch, help, and
+// `matrix commands`. Aliases are short names resolved to Name before
+// dispatch; Name stays canonical. Subcommands and Flags are the ones the
+// command accepts, for tooling; they aren't used to parse arguments.
+type commandInfo struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Subcommands []string
+	Flags       []string
+	run         func() error
+}
+
+// commands is the command registry, in help order. It's filled in by init
+// because runCommands reads it.
+var commands []commandInfo
+
+func init() {
+	commands = []commandInfo{
+		{Name: "garden-paths", Description: "Discover connections in the matrix garden", run: runGardenPaths},
+		{Name: "garden-seeds", Description: "Create well-structured RAM files from templates", run: runGardenSeeds,
+			Flags: []string{"--identity", "--list-templates", "--type"}},
+		{Name: "tension-map", Description: "Surface conflicts and tensions across RAM", run: runTensionMap},
+		{Name: "velocity", Description: "Track task completion velocity by identity", run: runVelocity,
+			Flags: []string{"--days", "--format", "--identity", "--json", "--top"}},
+		{Name: "recon", Description: "Scan codebases and generate intelligence reports", run: runRecon,
+			Flags: []string{"--compare", "--depth", "--exclude", "--focus", "--format", "--no-cache", "--output", "--quick", "--refresh", "--save"}},
+		{Name: "incident-trace", Description: "Extract structured post-mortem data from debugging sessions", run: runIncidentTrace,
+			Flags: []string{"--all", "--component", "--group-by", "--json", "--metrics", "--neo", "--open-only", "--output", "--pattern", "--verify-lines", "--window"}},
+		{Name: "crossroads", Description: "Capture decision points and paths not taken", run: runCrossroads,
+			Subcommands: []string{"record", "search", "list", "patterns", "export"},
+			Flags:       []string{"--because", "--chosen", "--context", "--format", "--out", "--paths"}},
+		{Name: "balance-checker", Description: "Detect drift between design docs and implementation", run: runBalanceChecker,
+			Flags: []string{"--all", "--threshold"}},
+		{Name: "breach-points", Description: "Audit for security vulnerabilities and exposures", run: runBreachPoints,
+			Aliases: []string{"bp"},
+			Flags: []string{"--all", "--days", "--fail-on", "--format", "--history", "--history-depth", "--max-per-category",
+				"--notify-on", "--path", "--rules", "--scan", "--scan-test-dirs", "--severity", "--webhook", "--workers"}},
+		{Name: "vault-keys", Description: "Map authentication, authorization, and security boundaries", run: runVaultKeys,
+			Flags: []string{"--focus", "--json"}},
+		{Name: "flight-check", Description: "Track deployment state across identity work", run: runFlightCheck,
+			Flags: []string{"--by-owner", "--grounded", "--history", "--interval", "--json", "--owner", "--ready", "--summary", "--watch"}},
+		{Name: "knowledge-gaps", Description: "Find unanswered questions and missing documentation", run: runKnowledgeGaps,
+			Flags: []string{"--answer-window", "--complexity", "--detailed", "--fail-on-questions", "--fail-on-todos", "--identity", "--json",
+				"--max-gaps", "--patterns", "--questions", "--strict", "--todos"}},
+		{Name: "contract-ledger", Description: "Track data flows and dependencies between identities", run: runContractLedger,
+			Flags: []string{"--artifacts", "--cycles", "--fail-on-cycle", "--format", "--graph", "--json", "--volume"}},
+		{Name: "schema-catalog", Description: "Track database schemas across projects", run: runSchemaCatalog,
+			Subcommands: []string{"scan", "diff", "history", "find", "list", "export", "stats", "lint"},
+			Flags:       []string{"--fail-on-destructive", "--format", "--from", "--json", "--max-columns", "--project", "--stale-days", "--table", "--to", "--top"}},
+		{Name: "phase-shift", Description: "Track cross-language compatibility and migration patterns", run: runPhaseShift,
+			Subcommands: []string{"record", "break", "pattern", "check", "patterns", "breaks", "list", "migrated", "pending", "progress"},
+			Flags:       []string{"--json", "--type"}},
+		{Name: "platform-map", Description: "Scan for cross-platform compatibility markers", run: runPlatformMap,
+			Flags: []string{"--baseline", "--fail-on-issues", "--issues-only", "--json", "--max-issues", "--write-baseline"}},
+		{Name: "verdict", Description: "Track test results and performance metrics", run: runVerdict,
+			Subcommands: []string{"record", "bench", "check", "report", "baseline", "list", "flaky", "compare"},
+			Flags: []string{"--a", "--b", "--baseline-file", "--component", "--duration", "--format", "--identity", "--json", "--metric", "--min-runs",
+				"--new", "--recent", "--result", "--tag", "--tags", "--test", "--tests", "--threshold", "--value"}},
+		{Name: "question", Description: "Surface hidden assumptions behind documented work", run: runQuestion,
+			Flags: []string{"--context", "--identity"}},
+		{Name: "debt-ledger", Description: "Track technical debt markers and generate remediation tasks", run: runDebtLedger,
+			Flags: []string{"--create-tasks", "--severity"}},
+		{Name: "friction-points", Description: "Track UX review queue and feedback", run: runFrictionPoints,
+			Aliases:     []string{"fp"},
+			Subcommands: []string{"queue", "list", "review", "tag", "patterns", "approve", "status", "import"},
+			Flags:       []string{"--cooccurrence", "--due", "--feedback", "--min", "--note", "--overdue", "--owner", "--priority", "--status", "--type"}},
+		{Name: "spec-verify", Description: "Verify implementations against formal specifications", run: runSpecVerify,
+			Subcommands: []string{"list", "verify", "report", "trend"},
+			Flags:       []string{"--context", "--format", "--include", "--json", "--record"}},
+		{Name: "alt-routes", Description: "Accessibility audit and alternative output formats", run: runAltRoutes,
+			Subcommands: []string{"audit", "strip", "plain", "search", "list"},
+			Flags:       []string{"--dir", "--json", "--plain"}},
+		{Name: "data-harvest", Description: "Scan RAM for data patterns to build better fixtures", run: runDataHarvest,
+			Subcommands: []string{"scan", "patterns", "schemas", "report"},
+			Flags:       []string{"--format", "--merge"}},
+		{Name: "dependency-map", Description: "Map installed toolchains and package dependencies", run: runDependencyMap,
+			Aliases:     []string{"deps"},
+			Subcommands: []string{"scan", "toolchains", "report", "conflicts"},
+			Flags:       []string{"--flag-eol", "--json"}},
+		{Name: "diff-paths", Description: "Compare two implementations and extract architectural tradeoffs", run: runDiffPaths,
+			Flags: []string{"--dir", "--json", "--table"}},
+		{Name: "doctor", Description: "Check the RAM environment and command directories", run: runDoctor},
+		{Name: "commands", Description: "List commands, subcommands, and flags (--json for tooling)", run: runCommands,
+			Flags: []string{"--json"}},
+	}
+}
+
+// globalFlags are accepted by every command
+var globalFlags = []string{"--quiet", "--ram-dir"}
+
+// findCommand looks a command up by name or alias
+func findCommand(name string) (commandInfo, bool) {
+	for _, cmd := range commands {
+		if cmd.Name == name || contains(cmd.Aliases, name) {
+			return cmd, true
+		}
+	}
+	return commandInfo{}, false
+}
+
+// printMatrixUsage prints the top-level help
+func printMatrixUsage() {
+	fmt.Println("matrix " + version)
+	fmt.Println("")
+	fmt.Println("Intelligence tools for the Claude Code identity system.")
+	fmt.Println("Analyzes and surfaces patterns across ~/.claude/ram/")
+	fmt.Println("")
+	fmt.Println("Commands:")
+	for _, cmd := range commands {
+		description := cmd.Description
+		if len(cmd.Aliases) > 0 {
+			description += fmt.Sprintf(" (alias: %s)", strings.Join(cmd.Aliases, ", "))
+		}
+		fmt.Printf("  %-15s %s\n", cmd.Name, description)
+	}
+	fmt.Println("")
+	fmt.Println("Global options:")
+	fmt.Println("  --ram-dir <dir> Use <dir> instead of ~/.claude/ram (or set " + identity.RAMDirEnv + ")")
+	fmt.Println("  --quiet         Hide the progress counter shown during long scans")
+}
+
+// commandJSON is the JSON shape of one command in `matrix commands --json`
+type commandJSON struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases"`
+	Description string   `json:"description"`
+	Subcommands []string `json:"subcommands"`
+	Flags       []string `json:"flags"`
+}
+
+// commandCatalog is the JSON document emitted by `matrix commands --json`
+type commandCatalog struct {
+	Version     string        `json:"version"`
+	GlobalFlags []string      `json:"global_flags"`
+	Commands    []commandJSON `json:"commands"`
+}
+
+// buildCommandCatalog describes the registry for tooling. Empty lists are
+// emitted as [] so consumers don't need null checks.
+func buildCommandCatalog() commandCatalog {
+	catalog := commandCatalog{Version: version, GlobalFlags: globalFlags}
+	for _, cmd := range commands {
+		entry := commandJSON{
+			Name:        cmd.Name,
+			Aliases:     cmd.Aliases,
+			Description: cmd.Description,
+			Subcommands: cmd.Subcommands,
+			Flags:       cmd.Flags,
+		}
+		if entry.Aliases == nil {
+			entry.Aliases = []string{}
+		}
+		if entry.Subcommands == nil {
+			entry.Subcommands = []string{}
+		}
+		if entry.Flags == nil {
+			entry.Flags = []string{}
+		}
+		catalog.Commands = append(catalog.Commands, entry)
+	}
+	return catalog
+}
+
+// runCommands implements the commands command
+func runCommands() error {
+	fs := flag.NewFlagSet("commands", flag.ExitOnError)
+	jsonFlag := fs.Bool("json", false, "Output as JSON")
+	if len(os.Args) > 2 {
+		fs.Parse(os.Args[2:])
+	}
+
+	catalog := buildCommandCatalog()
+	if *jsonFlag {
+		return output.EmitJSON(catalog)
+	}
+
+	output.Success(fmt.Sprintf("matrix %s - %d commands", catalog.Version, len(catalog.Commands)))
+	fmt.Println("")
+	for _, cmd := range catalog.Commands {
+		fmt.Printf("%s%s%s  %s\n", output.Yellow, cmd.Name, output.Reset, cmd.Description)
+		if len(cmd.Aliases) > 0 {
+			fmt.Printf("  aliases: %s\n", strings.Join(cmd.Aliases, ", "))
+		}
+		if len(cmd.Subcommands) > 0 {
+			fmt.Printf("  subcommands: %s\n", strings.Join(cmd.Subcommands, ", "))
+		}
+		if len(cmd.Flags) > 0 {
+			fmt.Printf("  flags: %s\n", strings.Join(cmd.Flags, " "))
+		}
+	}
+	fmt.Println("")
+	fmt.Printf("Global flags: %s\n", strings.Join(catalog.GlobalFlags, " "))
+
+	return nil
+}
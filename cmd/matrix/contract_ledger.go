@@ -52,6 +52,7 @@ type ContractLedgerReport struct {
 	Dependencies  []DependencyEdge
 	HotArtifacts  []ArtifactStats
 	Transactions  []TransactionStats
+	Cycles        [][]string // Dependency cycles, each path ending where it started
 	TotalFiles    int
 	TotalRefs     int
 }
@@ -63,13 +64,26 @@ func runContractLedger() error {
 	graphFlag := fs.Bool("graph", false, "Show only dependency graph")
 	artifactsFlag := fs.Bool("artifacts", false, "Show only hot artifacts")
 	volumeFlag := fs.Bool("volume", false, "Show only transaction volume")
+	cyclesFlag := fs.Bool("cycles", false, "Show only dependency cycles")
+	failOnCycleFlag := fs.Bool("fail-on-cycle", false, "Exit non-zero when dependency cycles are found")
 	jsonFlag := fs.Bool("json", false, "Output as JSON")
+	formatFlag := fs.String("format", "text", "Output format: text, json, mermaid")
 
 	// Parse remaining args (after "contract-ledger")
 	if len(os.Args) > 2 {
 		fs.Parse(os.Args[2:])
 	}
 
+	switch *formatFlag {
+	case "text":
+	case "json":
+		*jsonFlag = true
+	case "mermaid":
+	default:
+		return fmt.Errorf("unknown format: %s (valid: text, json, mermaid)", *formatFlag)
+	}
+	mermaidFlag := *formatFlag == "mermaid"
+
 	// Get RAM directory
 	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
@@ -78,6 +92,10 @@ func runContractLedger() error {
 
 	// Check if garden exists
 	if _, err := os.Stat(ramDir); os.IsNotExist(err) {
+		if mermaidFlag {
+			outputContractMermaid(ContractLedgerReport{})
+			return nil
+		}
 		if *jsonFlag {
 			emptyReport := ContractLedgerReport{}
 			outputContractJSON(emptyReport)
@@ -94,6 +112,10 @@ func runContractLedger() error {
 	}
 
 	if len(files) == 0 {
+		if mermaidFlag {
+			outputContractMermaid(ContractLedgerReport{})
+			return nil
+		}
 		if *jsonFlag {
 			emptyReport := ContractLedgerReport{}
 			outputContractJSON(emptyReport)
@@ -120,10 +142,19 @@ func runContractLedger() error {
 	// Output
 	if *jsonFlag {
 		outputContractJSON(report)
+	} else if mermaidFlag {
+		outputContractMermaid(report)
+	} else if *cyclesFlag {
+		displayContractCycles(report.Cycles)
 	} else {
 		displayContractReport(report, *graphFlag, *artifactsFlag, *volumeFlag)
 	}
 
+	// Block CI on circular dependencies
+	if *failOnCycleFlag && len(report.Cycles) > 0 {
+		os.Exit(1)
+	}
+
 	return nil
 }
 
@@ -312,6 +343,7 @@ func buildContractReport(refs []FileReference, files []ram.File, cacheFiles []ra
 		Dependencies: deps,
 		HotArtifacts: artifacts,
 		Transactions: trans,
+		Cycles:       detectDependencyCycles(deps),
 		TotalFiles:   len(files) + len(cacheFiles),
 		TotalRefs:    len(refs),
 	}
@@ -403,9 +435,114 @@ func displayContractReport(report ContractLedgerReport, graphOnly, artifactsOnly
 		fmt.Println("")
 	}
 
+	// Dependency Cycles
+	if showGraph && len(report.Cycles) > 0 {
+		fmt.Println("═══ DEPENDENCY CYCLES ═══")
+		fmt.Println("")
+		for _, cycle := range report.Cycles {
+			fmt.Printf("  %s\n", output.Red+strings.Join(cycle, " → ")+output.Reset)
+		}
+		fmt.Println("")
+	}
+
 	output.Success("📜 Ledger complete")
 }
 
+// displayContractCycles outputs only the dependency cycles
+func displayContractCycles(cycles [][]string) {
+	output.Success("📜 Contract Ledger - Dependency Cycles")
+	fmt.Println("")
+
+	if len(cycles) == 0 {
+		fmt.Println("No dependency cycles found.")
+		return
+	}
+
+	for i, cycle := range cycles {
+		fmt.Printf("  %d. %s\n", i+1, output.Red+strings.Join(cycle, " → ")+output.Reset)
+	}
+	fmt.Println("")
+	fmt.Printf("Found %d cycle(s)\n", len(cycles))
+}
+
+// detectDependencyCycles finds cycles in the identity dependency graph using DFS.
+// Each cycle is returned as a path that starts and ends at the same identity.
+func detectDependencyCycles(deps []DependencyEdge) [][]string {
+	// Build adjacency list with sorted neighbors for stable output
+	adjacency := make(map[string][]string)
+	for _, dep := range deps {
+		adjacency[dep.From] = append(adjacency[dep.From], dep.To)
+	}
+	var nodes []string
+	for node, targets := range adjacency {
+		sort.Strings(targets)
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	var cycles [][]string
+	seen := make(map[string]bool)
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = visiting
+		stack = append(stack, node)
+
+		for _, next := range adjacency[node] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				// Back edge: slice the stack from the first occurrence of next
+				start := 0
+				for i, n := range stack {
+					if n == next {
+						start = i
+						break
+					}
+				}
+				cycle := append([]string{}, stack[start:]...)
+				key := canonicalCycleKey(cycle)
+				if !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, append(cycle, next))
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[node] = done
+	}
+
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+
+	return cycles
+}
+
+// canonicalCycleKey rotates a cycle to start at its smallest node so the
+// same cycle discovered from different entry points is only reported once
+func canonicalCycleKey(cycle []string) string {
+	minIdx := 0
+	for i, n := range cycle {
+		if n < cycle[minIdx] {
+			minIdx = i
+		}
+	}
+	rotated := append(append([]string{}, cycle[minIdx:]...), cycle[:minIdx]...)
+	return strings.Join(rotated, "→")
+}
+
 // outputContractJSON outputs the report as JSON
 func outputContractJSON(report ContractLedgerReport) {
 	encoder := json.NewEncoder(os.Stdout)
@@ -413,6 +550,68 @@ func outputContractJSON(report ContractLedgerReport) {
 	encoder.Encode(report)
 }
 
+// outputContractMermaid outputs the dependency graph as a Mermaid flowchart
+func outputContractMermaid(report ContractLedgerReport) {
+	fmt.Println("graph LR")
+
+	// Declare a node for every identity that produces or consumes data
+	nodes := make(map[string]bool)
+	for _, trans := range report.Transactions {
+		nodes[trans.Identity] = true
+	}
+	for _, dep := range report.Dependencies {
+		nodes[dep.From] = true
+		nodes[dep.To] = true
+	}
+
+	var names []string
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ids := mermaidNodeIDs(names)
+	for _, name := range names {
+		fmt.Printf("    %s[\"%s\"]\n", ids[name], strings.ReplaceAll(name, "\"", "'"))
+	}
+
+	// Edges labelled with the contract file and reference count
+	for _, dep := range report.Dependencies {
+		label := fmt.Sprintf("%s (%d refs)", dep.Via, dep.Count)
+		fmt.Printf("    %s -->|\"%s\"| %s\n",
+			ids[dep.From],
+			strings.ReplaceAll(label, "\"", "'"),
+			ids[dep.To])
+	}
+}
+
+// mermaidNodeIDs assigns each name a Mermaid-safe node identifier. IDs are
+// prefixed so names like "end" can't clash with Mermaid keywords, and names
+// that sanitize to the same ID ("a-b", "a_b") get a numeric suffix.
+func mermaidNodeIDs(names []string) map[string]string {
+	ids := make(map[string]string, len(names))
+	used := make(map[string]bool, len(names))
+	for _, name := range names {
+		var b strings.Builder
+		b.WriteString("n_")
+		for _, r := range name {
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune('_')
+			}
+		}
+
+		id := b.String()
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s_%d", b.String(), n)
+		}
+		used[id] = true
+		ids[name] = id
+	}
+	return ids
+}
+
 // scanCacheDir scans the cache directory for files
 func scanCacheDir(cacheDir string) ([]ram.File, error) {
 	var files []ram.File
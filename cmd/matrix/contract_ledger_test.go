@@ -0,0 +1,70 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMermaidNodeIDs(t *testing.T) {
+	ids := mermaidNodeIDs([]string{"a-b", "a_b", "a_b_2", "end"})
+
+	want := map[string]string{
+		"a-b":   "n_a_b",
+		"a_b":   "n_a_b_2",
+		"a_b_2": "n_a_b_2_2",
+		"end":   "n_end",
+	}
+	for name, id := range want {
+		if ids[name] != id {
+			t.Errorf("mermaidNodeIDs()[%q] = %q, want %q", name, ids[name], id)
+		}
+	}
+}
+
+func TestDetectDependencyCycles(t *testing.T) {
+	deps := []DependencyEdge{
+		{From: "smith", To: "neo"},
+		{From: "neo", To: "trinity"},
+		{From: "trinity", To: "smith"},
+		{From: "trinity", To: "oracle"},
+		{From: "tank", To: "mouse"},
+		{From: "mouse", To: "tank"},
+	}
+
+	cycles := detectDependencyCycles(deps)
+	want := [][]string{
+		{"mouse", "tank", "mouse"},
+		{"neo", "trinity", "smith", "neo"},
+	}
+	if !reflect.DeepEqual(cycles, want) {
+		t.Errorf("detectDependencyCycles() = %v, want %v", cycles, want)
+	}
+
+	if cycles := detectDependencyCycles(deps[:2]); len(cycles) != 0 {
+		t.Errorf("Expected no cycles in an acyclic graph, got %v", cycles)
+	}
+}
+
+func TestContractLedgerFailOnCycle(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ramDir := t.TempDir()
+	writeFixture(t, ramDir, map[string]string{
+		filepath.Join("smith", "notes.md"): "See ~/.claude/ram/neo/plan.md\n",
+		filepath.Join("neo", "plan.md"):    "Built on ~/.claude/ram/smith/notes.md\n",
+	})
+
+	out, code := runMatrix(t, "--ram-dir", ramDir, "contract-ledger", "--cycles", "--fail-on-cycle")
+	if code != 1 {
+		t.Errorf("Expected exit 1 with a cycle, got %d:\n%s", code, out)
+	}
+	if !strings.Contains(out, "neo → smith → neo") {
+		t.Errorf("Expected the cycle path in output, got:\n%s", out)
+	}
+
+	// Without --fail-on-cycle the cycle is reported but the run succeeds
+	if out, code := runMatrix(t, "--ram-dir", ramDir, "contract-ledger", "--cycles"); code != 0 {
+		t.Errorf("Expected exit 0 without --fail-on-cycle, got %d:\n%s", code, out)
+	}
+}
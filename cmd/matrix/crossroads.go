@@ -1,7 +1,10 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/coryzibell/matrix/internal/identity"
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // Crossroads represents a decision point record
@@ -42,6 +46,8 @@ func runCrossroads() error {
 		return listCrossroads()
 	case "patterns":
 		return showPatterns()
+	case "export":
+		return exportCrossroads()
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown crossroads subcommand: %s\n", subcommand)
 		printCrossroadsUsage()
@@ -56,15 +62,18 @@ func printCrossroadsUsage() {
 	fmt.Println("")
 	fmt.Println("Usage:")
 	fmt.Println("  matrix crossroads record --context=\"...\" --paths=\"1. X, 2. Y\" --chosen=\"1\" --because=\"...\"")
+	fmt.Println("  matrix crossroads record              (prompts for each field in a terminal)")
 	fmt.Println("  matrix crossroads search <keyword>")
 	fmt.Println("  matrix crossroads list")
 	fmt.Println("  matrix crossroads patterns")
+	fmt.Println("  matrix crossroads export --format=adr --out=docs/adr")
 	fmt.Println("")
 	fmt.Println("Subcommands:")
 	fmt.Println("  record    Record a new decision point")
 	fmt.Println("  search    Search past crossroads by keyword")
 	fmt.Println("  list      Show all recorded crossroads")
 	fmt.Println("  patterns  Show recurring themes across decisions")
+	fmt.Println("  export    Write crossroads as numbered ADR markdown files")
 }
 
 func recordCrossroads() error {
@@ -85,15 +94,26 @@ func recordCrossroads() error {
 		}
 	}
 
-	// Validate required fields
-	if context == "" || pathsStr == "" {
-		return fmt.Errorf("--context and --paths are required")
-	}
+	var paths []string
+	if len(os.Args) == 3 && output.IsTerminal(os.Stdin) {
+		// No flags in a terminal: ask for each field
+		var err error
+		context, paths, chosen, because, err = promptCrossroads(bufio.NewReader(os.Stdin), os.Stdout)
+		if err != nil {
+			return err
+		}
+		fmt.Println("")
+	} else {
+		// Validate required fields
+		if context == "" || pathsStr == "" {
+			return fmt.Errorf("--context and --paths are required")
+		}
 
-	// Parse paths (split on numbered list pattern)
-	paths := parsePaths(pathsStr)
-	if len(paths) == 0 {
-		return fmt.Errorf("could not parse paths - use format: '1. Option A, 2. Option B'")
+		// Parse paths (split on numbered list pattern)
+		paths = parsePaths(pathsStr)
+		if len(paths) == 0 {
+			return fmt.Errorf("could not parse paths - use format: '1. Option A, 2. Option B'")
+		}
 	}
 
 	// Determine which identity is recording (default to oracle)
@@ -114,7 +134,7 @@ func recordCrossroads() error {
 
 	// Generate filename
 	dateStr := time.Now().Format("2006-01-02")
-	slug := slugify(context)
+	slug := util.Slugify(context)
 	filename := fmt.Sprintf("%s-%s.md", slug, dateStr)
 	filePath := filepath.Join(crossroadsDir, filename)
 
@@ -159,6 +179,63 @@ func recordCrossroads() error {
 	return nil
 }
 
+// promptCrossroads asks for a decision's context, paths, chosen path, and
+// reasoning. Paths are read one per line until a blank line; the chosen
+// path and reasoning may be left blank.
+func promptCrossroads(r *bufio.Reader, w io.Writer) (context string, paths []string, chosen, because string, err error) {
+	context, err = promptLine(r, w, "Context: ")
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", nil, "", "", err
+	}
+	if context == "" {
+		return "", nil, "", "", fmt.Errorf("context is required")
+	}
+
+	fmt.Fprintln(w, "Paths considered (one per line, blank line to finish):")
+	for {
+		path, err := promptLine(r, w, fmt.Sprintf("  %d. ", len(paths)+1))
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", nil, "", "", err
+		}
+		if path == "" {
+			break
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return "", nil, "", "", fmt.Errorf("at least one path is required")
+	}
+
+	for {
+		chosen, err = promptLine(r, w, fmt.Sprintf("Chosen path [1-%d, blank to skip]: ", len(paths)))
+		if chosen == "" || err != nil {
+			chosen = ""
+			break
+		}
+		var idx int
+		if _, scanErr := fmt.Sscanf(chosen, "%d", &idx); scanErr == nil && idx >= 1 && idx <= len(paths) {
+			chosen = fmt.Sprintf("%d", idx)
+			break
+		}
+		fmt.Fprintf(w, "Enter a number from 1 to %d\n", len(paths))
+	}
+
+	because, _ = promptLine(r, w, "Reasoning (blank to skip): ")
+	return context, paths, chosen, because, nil
+}
+
+// promptLine writes prompt and reads one trimmed line. It returns io.EOF
+// only when input ends before any text is read.
+func promptLine(r *bufio.Reader, w io.Writer, prompt string) (string, error) {
+	fmt.Fprint(w, prompt)
+	line, err := r.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if errors.Is(err, io.EOF) && line != "" {
+		err = nil
+	}
+	return line, err
+}
+
 func searchCrossroads() error {
 	if len(os.Args) < 4 {
 		return fmt.Errorf("search requires a keyword argument")
@@ -480,23 +557,160 @@ func showPatterns() error {
 
 // Helper functions
 
-func slugify(text string) string {
-	// Convert to lowercase
-	slug := strings.ToLower(text)
+func exportCrossroads() error {
+	// Parse flags
+	format := "adr"
+	var outDir string
+
+	for i := 3; i < len(os.Args); i++ {
+		arg := os.Args[i]
+
+		if strings.HasPrefix(arg, "--format=") {
+			format = strings.TrimPrefix(arg, "--format=")
+		} else if arg == "--format" && i+1 < len(os.Args) {
+			i++
+			format = os.Args[i]
+		} else if strings.HasPrefix(arg, "--out=") {
+			outDir = strings.TrimPrefix(arg, "--out=")
+		} else if arg == "--out" && i+1 < len(os.Args) {
+			i++
+			outDir = os.Args[i]
+		}
+	}
+
+	// Validate required fields
+	if format != "adr" {
+		return fmt.Errorf("unsupported export format: %s (supported: adr)", format)
+	}
+	if outDir == "" {
+		return fmt.Errorf("--out is required")
+	}
+
+	// Get crossroads directory
+	oraclePath, err := identity.RAMPath("oracle")
+	if err != nil {
+		return fmt.Errorf("failed to get oracle RAM path: %w", err)
+	}
+
+	crossroadsDir := filepath.Join(oraclePath, "crossroads")
+
+	files, err := os.ReadDir(crossroadsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("No crossroads recorded yet.")
+			return nil
+		}
+		return fmt.Errorf("failed to read crossroads directory: %w", err)
+	}
+
+	var allCrossroads []Crossroads
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".md") {
+			continue
+		}
+
+		filePath := filepath.Join(crossroadsDir, file.Name())
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			continue
+		}
+
+		allCrossroads = append(allCrossroads, parseCrossroadsFile(filePath, string(content)))
+	}
+
+	if len(allCrossroads) == 0 {
+		fmt.Println("No crossroads recorded yet.")
+		return nil
+	}
+
+	// Number ADRs chronologically
+	sort.SliceStable(allCrossroads, func(i, j int) bool {
+		if allCrossroads[i].Date != allCrossroads[j].Date {
+			return allCrossroads[i].Date < allCrossroads[j].Date
+		}
+		return allCrossroads[i].FilePath < allCrossroads[j].FilePath
+	})
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	output.Success(fmt.Sprintf("📜 Exporting %d crossroads as ADRs", len(allCrossroads)))
+	fmt.Println("")
+
+	for i, cr := range allCrossroads {
+		number := i + 1
+		filename := fmt.Sprintf("%04d-%s.md", number, util.Slugify(cr.Context))
+		filePath := filepath.Join(outDir, filename)
 
-	// Replace spaces and special chars with hyphens
-	re := regexp.MustCompile(`[^a-z0-9]+`)
-	slug = re.ReplaceAllString(slug, "-")
+		if err := os.WriteFile(filePath, []byte(buildADRMarkdown(number, cr)), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filename, err)
+		}
+
+		fmt.Printf("  %s\n", output.Yellow+filePath+output.Reset)
+	}
+
+	return nil
+}
+
+// buildADRMarkdown renders a crossroads record in the conventional
+// Architecture Decision Record layout (Status, Context, Decision, Consequences)
+func buildADRMarkdown(number int, cr Crossroads) string {
+	var sb strings.Builder
 
-	// Trim hyphens from ends
-	slug = strings.Trim(slug, "-")
+	sb.WriteString(fmt.Sprintf("# %d. %s\n\n", number, cr.Context))
+	if cr.Date != "" {
+		sb.WriteString(fmt.Sprintf("Date: %s\n\n", cr.Date))
+	}
 
-	// Limit length
-	if len(slug) > 60 {
-		slug = slug[:60]
+	sb.WriteString("## Status\n\n")
+	if cr.Chosen != "" {
+		sb.WriteString("Accepted\n\n")
+	} else {
+		sb.WriteString("Proposed\n\n")
+	}
+
+	sb.WriteString("## Context\n\n")
+	sb.WriteString(cr.Context + "\n\n")
+	if len(cr.Paths) > 0 {
+		sb.WriteString("Options considered:\n\n")
+		for _, path := range cr.Paths {
+			sb.WriteString(fmt.Sprintf("- %s\n", path))
+		}
+		sb.WriteString("\n")
 	}
 
-	return slug
+	sb.WriteString("## Decision\n\n")
+	if cr.Chosen != "" {
+		sb.WriteString(fmt.Sprintf("We chose **%s**.\n\n", cr.Chosen))
+	} else {
+		sb.WriteString("No path was chosen when this crossroads was recorded.\n\n")
+	}
+
+	sb.WriteString("## Consequences\n\n")
+	if cr.Reasoning != "" {
+		sb.WriteString(cr.Reasoning + "\n\n")
+	}
+
+	var notTaken []string
+	for _, path := range cr.Paths {
+		if path != cr.Chosen {
+			notTaken = append(notTaken, path)
+		}
+	}
+	if cr.Chosen != "" && len(notTaken) > 0 {
+		sb.WriteString("Paths not taken:\n\n")
+		for _, path := range notTaken {
+			sb.WriteString(fmt.Sprintf("- %s\n", path))
+		}
+		sb.WriteString("\n")
+	}
+
+	if cr.RecordedBy != "" {
+		sb.WriteString(fmt.Sprintf("---\n*Recorded by %s via matrix crossroads*\n", cr.RecordedBy))
+	}
+
+	return sb.String()
 }
 
 func parsePaths(pathsStr string) []string {
@@ -589,7 +803,7 @@ func parseCrossroadsFile(filePath, content string) Crossroads {
 		}
 
 		// Extract chosen path
-		if strings.HasPrefix(line, "**#") && strings.Contains(line, ":**") {
+		if strings.HasPrefix(line, "**#") && strings.Contains(line, ":") {
 			// Format: **#1: Path name**
 			parts := strings.SplitN(line, ":", 2)
 			if len(parts) > 1 {
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coryzibell/matrix/internal/identity"
+)
+
+func TestPromptCrossroads(t *testing.T) {
+	input := "Pick a queue\nKafka\n  NATS  \n\n7\nnope\n2\nLighter to run\n"
+
+	context, paths, chosen, because, err := promptCrossroads(bufio.NewReader(strings.NewReader(input)), io.Discard)
+	if err != nil {
+		t.Fatalf("promptCrossroads() failed: %v", err)
+	}
+
+	if context != "Pick a queue" {
+		t.Errorf("Expected context %q, got %q", "Pick a queue", context)
+	}
+	if !reflect.DeepEqual(paths, []string{"Kafka", "NATS"}) {
+		t.Errorf("Expected paths [Kafka NATS], got %v", paths)
+	}
+	if chosen != "2" {
+		t.Errorf("Expected out-of-range and non-numeric choices re-prompted, got %q", chosen)
+	}
+	if because != "Lighter to run" {
+		t.Errorf("Expected reasoning, got %q", because)
+	}
+}
+
+func TestPromptCrossroadsOptionalFieldsAtEOF(t *testing.T) {
+	// Input ends after the paths: chosen and reasoning stay empty
+	context, paths, chosen, because, err := promptCrossroads(bufio.NewReader(strings.NewReader("Rename service\nkeep\nrename")), io.Discard)
+	if err != nil {
+		t.Fatalf("promptCrossroads() failed: %v", err)
+	}
+	if context != "Rename service" || len(paths) != 2 || chosen != "" || because != "" {
+		t.Errorf("Unexpected result: %q %v %q %q", context, paths, chosen, because)
+	}
+}
+
+func TestPromptCrossroadsRequiresContextAndPaths(t *testing.T) {
+	for _, input := range []string{"", "\n", "Context only\n\n"} {
+		if _, _, _, _, err := promptCrossroads(bufio.NewReader(strings.NewReader(input)), io.Discard); err == nil {
+			t.Errorf("Expected error for input %q", input)
+		}
+	}
+}
+
+func TestBuildADRMarkdown(t *testing.T) {
+	accepted := buildADRMarkdown(3, Crossroads{
+		Context:    "Pick a queue",
+		Date:       "2024-02-01",
+		RecordedBy: "oracle",
+		Paths:      []string{"Kafka", "NATS"},
+		Chosen:     "NATS",
+		Reasoning:  "Lighter to run",
+	})
+	for _, want := range []string{
+		"# 3. Pick a queue\n", "Date: 2024-02-01", "## Status\n\nAccepted", "- Kafka\n- NATS",
+		"We chose **NATS**.", "## Consequences\n\nLighter to run", "Paths not taken:\n\n- Kafka\n", "Recorded by oracle",
+	} {
+		if !strings.Contains(accepted, want) {
+			t.Errorf("Expected %q in:\n%s", want, accepted)
+		}
+	}
+
+	proposed := buildADRMarkdown(1, Crossroads{Context: "Pick a cache", Paths: []string{"Redis", "Memcached"}})
+	if !strings.Contains(proposed, "## Status\n\nProposed") || !strings.Contains(proposed, "No path was chosen") {
+		t.Errorf("Expected an undecided crossroads to be proposed:\n%s", proposed)
+	}
+	if strings.Contains(proposed, "Paths not taken") {
+		t.Errorf("Expected no paths not taken without a choice:\n%s", proposed)
+	}
+}
+
+func TestCrossroadsExportADR(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ramDir := t.TempDir()
+	t.Setenv(identity.RAMDirEnv, ramDir)
+	writeFixture(t, filepath.Join(ramDir, "oracle", "crossroads"), map[string]string{
+		"queue.md":  buildCrossroadsMarkdown("Pick a queue", "2024-02-01", "oracle", []string{"Kafka", "NATS"}, "2", "Lighter to run"),
+		"cache.md":  buildCrossroadsMarkdown("Pick a cache", "2024-01-15", "neo", []string{"Redis", "Memcached"}, "", ""),
+		"notes.txt": "not a crossroads",
+	})
+	outDir := filepath.Join(t.TempDir(), "docs", "adr")
+
+	out, err := captureStdout(t, []string{"matrix", "crossroads", "export", "--format=adr", "--out", outDir}, exportCrossroads)
+	if err != nil {
+		t.Fatalf("crossroads export failed: %v", err)
+	}
+	if !strings.Contains(out, "Exporting 2 crossroads as ADRs") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	// Numbered by date, not by file name
+	if want := []string{"0001-pick-a-cache.md", "0002-pick-a-queue.md"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("ADR files = %v, want %v", names, want)
+	}
+
+	queue, err := os.ReadFile(filepath.Join(outDir, "0002-pick-a-queue.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(queue), "# 2. Pick a queue") || !strings.Contains(string(queue), "We chose **NATS**.") {
+		t.Errorf("Unexpected ADR:\n%s", queue)
+	}
+
+	for _, args := range [][]string{{"--format=madr", "--out", outDir}, {"--format", "adr"}} {
+		argv := append([]string{"matrix", "crossroads", "export"}, args...)
+		if _, err := captureStdout(t, argv, exportCrossroads); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -11,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
 )
 
 // HarvestResult contains discovered data patterns
@@ -37,12 +40,53 @@ type SchemaPattern struct {
 	Name      string
 	Fields    []FieldPattern
 	Locations []string
+	values    map[string]*fieldValues // field -> values observed during a scan
 }
 
 // FieldPattern represents a common field
 type FieldPattern struct {
-	Name string
-	Type string
+	Name       string
+	Type       string
+	EnumValues []string `json:",omitempty"` // closed value set, if the field looks like an enum
+}
+
+// fieldValues tallies the values observed for one schema field
+type fieldValues struct {
+	counts    map[string]int
+	total     int
+	nonString bool
+}
+
+// Thresholds for treating observed string values as an enum: at least
+// enumMinObservations values, between 2 and enumMaxValues distinct short
+// values, each seen twice on average
+const (
+	enumMinObservations = 4
+	enumMaxValues       = 10
+	enumMaxValueLength  = 32
+)
+
+// Declared SQL value sets: MySQL ENUM columns and CHECK (col IN (...))
+var (
+	sqlEnumColumnPattern  = regexp.MustCompile(`(?i)(\w+)\s+ENUM\s*\(([^)]*)\)`)
+	sqlCheckInPattern     = regexp.MustCompile(`(?i)CHECK\s*\(\s*(\w+)\s+IN\s*\(([^)]*)\)`)
+	sqlQuotedValuePattern = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
+// Field name case styles
+const (
+	caseSnake  = "snake_case"
+	caseCamel  = "camelCase"
+	casePascal = "PascalCase"
+	caseKebab  = "kebab-case"
+)
+
+// MixedConventionSchema is a schema whose fields use more than one case style
+type MixedConventionSchema struct {
+	Name      string
+	Styles    map[string][]string // case style -> field names
+	Dominant  string
+	Locations []string
 }
 
 // APIPattern represents discovered API conventions
@@ -83,31 +127,34 @@ func printDataHarvestUsage() {
 	fmt.Println("")
 	fmt.Println("USAGE:")
 	fmt.Println("  matrix data-harvest scan [path]     Scan for data patterns (default: ~/.claude/ram/)")
+	fmt.Println("         [--merge]                    Add to existing harvest data instead of replacing it")
 	fmt.Println("  matrix data-harvest patterns        Show discovered naming/type patterns")
-	fmt.Println("  matrix data-harvest schemas         List discovered schema structures")
+	fmt.Println("  matrix data-harvest schemas         List discovered schemas and enum value sets")
+	fmt.Println("         [--format text|json|yaml|csv] Export schemas for other tools (csv: one row per field)")
 	fmt.Println("  matrix data-harvest report          Full harvest report")
 	fmt.Println("")
 	fmt.Println("EXAMPLES:")
 	fmt.Println("  matrix data-harvest scan")
 	fmt.Println("  matrix data-harvest scan ~/projects/myapp")
+	fmt.Println("  matrix data-harvest scan --merge ~/projects/otherapp")
 	fmt.Println("  matrix data-harvest patterns")
+	fmt.Println("  matrix data-harvest schemas --format csv > schemas.csv")
 	fmt.Println("  matrix data-harvest report")
 }
 
 // runHarvestScan scans a directory for data patterns
 func runHarvestScan() error {
 	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	merge := fs.Bool("merge", false, "Combine with existing harvest data instead of replacing it")
 	if len(os.Args) > 3 {
 		fs.Parse(os.Args[3:])
 	}
 
-	// Default to ~/.claude/ram/
-	homeDir, err := os.UserHomeDir()
+	// Default to the RAM directory (~/.claude/ram/)
+	targetPath, err := ram.DefaultRAMDir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	targetPath := filepath.Join(homeDir, ".claude", "ram")
 	if fs.NArg() > 0 {
 		targetPath = fs.Arg(0)
 	}
@@ -135,6 +182,22 @@ func runHarvestScan() error {
 	// Display results
 	displayHarvestResults(result)
 
+	// Fold into previous harvests
+	if *merge {
+		existing, err := loadHarvestResults()
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load existing harvest data: %w", err)
+		}
+		if existing != nil {
+			if harvestIncludesPath(existing, absPath) {
+				fmt.Printf("%sNote: %s was already harvested; its counts are now included twice%s\n", output.Yellow, absPath, output.Reset)
+			}
+			result = mergeHarvestResults(existing, result)
+			fmt.Println("")
+			fmt.Printf("Merged with previous harvest: %d files across %s\n", result.TotalFilesScanned, result.ScanPath)
+		}
+	}
+
 	// Save results to Mouse's working directory
 	if err := saveHarvestResults(result); err != nil {
 		fmt.Printf("Warning: failed to save harvest results: %v\n", err)
@@ -161,13 +224,46 @@ func runHarvestPatterns() error {
 	return nil
 }
 
+// harvestSchemaJSON is the json/yaml export shape of a discovered schema
+type harvestSchemaJSON struct {
+	Name      string             `json:"name"`
+	Fields    []harvestFieldJSON `json:"fields"`
+	Locations []string           `json:"locations"`
+}
+
+type harvestFieldJSON struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	EnumValues []string `json:"enum_values,omitempty"`
+}
+
+// harvestSchemaCSVHeader names the columns of the csv export
+var harvestSchemaCSVHeader = []string{"schema", "field", "type", "enum_values", "locations"}
+
 // runHarvestSchemas lists discovered schemas
 func runHarvestSchemas() error {
+	fs := flag.NewFlagSet("schemas", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: text, json, yaml, or csv")
+	if len(os.Args) > 3 {
+		fs.Parse(os.Args[3:])
+	}
+
+	switch *format {
+	case "text", "json", "yaml", "csv":
+	default:
+		return fmt.Errorf("unknown format: %s (use text, json, yaml, or csv)", *format)
+	}
+
 	result, err := loadHarvestResults()
 	if err != nil {
 		return fmt.Errorf("no harvest data found. Run 'matrix data-harvest scan' first: %w", err)
 	}
 
+	if *format != "text" {
+		output.NoColor = true
+		return writeHarvestSchemas(os.Stdout, result.CommonSchemas, *format)
+	}
+
 	output.Success("📋 Discovered Schemas")
 	fmt.Println("")
 
@@ -175,7 +271,7 @@ func runHarvestSchemas() error {
 		fmt.Printf("%s%s%s (found in %d locations)\n", output.Yellow, schema.Name, output.Reset, len(schema.Locations))
 		fmt.Println("  Fields:")
 		for _, field := range schema.Fields {
-			fmt.Printf("    - %s: %s\n", field.Name, field.Type)
+			fmt.Printf("    - %s: %s%s\n", field.Name, field.Type, fieldEnumSuffix(field))
 		}
 		fmt.Println("")
 	}
@@ -187,6 +283,98 @@ func runHarvestSchemas() error {
 	return nil
 }
 
+// writeHarvestSchemas exports schemas as json, yaml, or csv
+func writeHarvestSchemas(w io.Writer, schemas []SchemaPattern, format string) error {
+	exported := make([]harvestSchemaJSON, 0, len(schemas))
+	for _, schema := range schemas {
+		entry := harvestSchemaJSON{
+			Name:      schema.Name,
+			Fields:    make([]harvestFieldJSON, 0, len(schema.Fields)),
+			Locations: schema.Locations,
+		}
+		if entry.Locations == nil {
+			entry.Locations = []string{}
+		}
+		for _, field := range schema.Fields {
+			entry.Fields = append(entry.Fields, harvestFieldJSON{Name: field.Name, Type: field.Type, EnumValues: field.EnumValues})
+		}
+		exported = append(exported, entry)
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(exported)
+	case "yaml":
+		return writeHarvestSchemasYAML(w, exported)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(harvestSchemaCSVHeader)
+		for _, schema := range exported {
+			locations := strings.Join(schema.Locations, ";")
+			for _, field := range schema.Fields {
+				cw.Write([]string{schema.Name, field.Name, field.Type, strings.Join(field.EnumValues, "|"), locations})
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+// writeHarvestSchemasYAML writes the schema export as a YAML list
+func writeHarvestSchemasYAML(w io.Writer, schemas []harvestSchemaJSON) error {
+	if len(schemas) == 0 {
+		_, err := fmt.Fprintln(w, "[]")
+		return err
+	}
+
+	var b strings.Builder
+	for _, schema := range schemas {
+		fmt.Fprintf(&b, "- name: %s\n", yamlScalar(schema.Name))
+		if len(schema.Fields) == 0 {
+			b.WriteString("  fields: []\n")
+		} else {
+			b.WriteString("  fields:\n")
+		}
+		for _, field := range schema.Fields {
+			fmt.Fprintf(&b, "    - name: %s\n", yamlScalar(field.Name))
+			fmt.Fprintf(&b, "      type: %s\n", yamlScalar(field.Type))
+			if len(field.EnumValues) > 0 {
+				b.WriteString("      enum_values:\n")
+				for _, value := range field.EnumValues {
+					fmt.Fprintf(&b, "        - %s\n", yamlScalar(value))
+				}
+			}
+		}
+		if len(schema.Locations) == 0 {
+			b.WriteString("  locations: []\n")
+		} else {
+			b.WriteString("  locations:\n")
+		}
+		for _, location := range schema.Locations {
+			fmt.Fprintf(&b, "    - %s\n", yamlScalar(location))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// yamlPlainUnsafe matches strings a YAML parser would not read back as the
+// same plain string: reserved words, numbers, and indicator characters
+var yamlPlainUnsafe = regexp.MustCompile(`(?i)^(?:|~|null|true|false|yes|no|on|off|[-+]?[0-9.][0-9._eE+-]*|.*[:#]\s.*|.*[:#]$|[-?:,\[\]{}#&*!|>'"%@\x60 ].*|.*\s)$`)
+
+// yamlScalar renders a string as a YAML scalar, double-quoting it (with
+// JSON escapes, which YAML accepts) when a plain scalar would be misread
+func yamlScalar(value string) string {
+	if yamlPlainUnsafe.MatchString(value) || strings.ContainsAny(value, "\n\t\"\\") {
+		quoted, _ := json.Marshal(value)
+		return string(quoted)
+	}
+	return value
+}
+
 // runHarvestReport generates full harvest report
 func runHarvestReport() error {
 	result, err := loadHarvestResults()
@@ -248,6 +436,7 @@ func harvestDataPatterns(path string) (*HarvestResult, error) {
 
 	// Convert schema map to slice
 	for _, schema := range schemaMap {
+		detectEnumFields(schema)
 		result.CommonSchemas = append(result.CommonSchemas, *schema)
 	}
 
@@ -289,12 +478,21 @@ func analyzeJSON(content, filePath string, result *HarvestResult, schemaMap map[
 	fields := extractFieldsFromJSON(data)
 	analyzeFields(fields, result)
 
-	// Try to infer schema from structure
+	// Try to infer schema from structure. For arrays the first object names
+	// the schema and every object contributes values for enum detection.
 	if obj, ok := data.(map[string]interface{}); ok {
-		inferSchemaFromObject(obj, filePath, schemaMap)
+		if schema := inferSchemaFromObject(obj, filePath, schemaMap); schema != nil {
+			observeFieldValues(schema, obj)
+		}
 	} else if arr, ok := data.([]interface{}); ok && len(arr) > 0 {
 		if obj, ok := arr[0].(map[string]interface{}); ok {
-			inferSchemaFromObject(obj, filePath, schemaMap)
+			if schema := inferSchemaFromObject(obj, filePath, schemaMap); schema != nil {
+				for _, item := range arr {
+					if obj, ok := item.(map[string]interface{}); ok {
+						observeFieldValues(schema, obj)
+					}
+				}
+			}
 		}
 	}
 
@@ -322,8 +520,9 @@ func analyzeSQL(content, filePath string, result *HarvestResult, schemaMap map[s
 		"`" + `?(\w+)` + "`" + `?\s*\((.*?)\)`)
 
 	matches := createTablePattern.FindAllStringSubmatch(content, -1)
+	locs := createTablePattern.FindAllStringIndex(content, -1)
 
-	for _, match := range matches {
+	for i, match := range matches {
 		if len(match) < 3 {
 			continue
 		}
@@ -357,7 +556,108 @@ func analyzeSQL(content, filePath string, result *HarvestResult, schemaMap map[s
 
 			analyzeFieldName(fieldName, result)
 		}
+
+		// The column list above stops at the first ")", so declared value
+		// sets are read from the whole statement
+		stmtEnd := len(content)
+		if i+1 < len(locs) {
+			stmtEnd = locs[i+1][0]
+		}
+		if semi := strings.Index(content[locs[i][0]:stmtEnd], ";"); semi >= 0 {
+			stmtEnd = locs[i][0] + semi
+		}
+		for column, values := range sqlDeclaredEnums(content[locs[i][0]:stmtEnd]) {
+			setFieldEnum(schema, column, values)
+		}
+	}
+}
+
+// sqlDeclaredEnums returns the value sets a CREATE TABLE statement declares
+// through ENUM column types or CHECK (col IN (...)) constraints, keyed by
+// column, in declaration order
+func sqlDeclaredEnums(statement string) map[string][]string {
+	enums := make(map[string][]string)
+	for _, pattern := range []*regexp.Regexp{sqlEnumColumnPattern, sqlCheckInPattern} {
+		for _, m := range pattern.FindAllStringSubmatch(statement, -1) {
+			var values []string
+			for _, v := range sqlQuotedValuePattern.FindAllStringSubmatch(m[2], -1) {
+				values = append(values, strings.ReplaceAll(v[1], "''", "'"))
+			}
+			if len(values) > 0 {
+				enums[m[1]] = values
+			}
+		}
+	}
+	return enums
+}
+
+// setFieldEnum records a declared value set on a schema field, adding the
+// field if the column parser missed it
+func setFieldEnum(schema *SchemaPattern, column string, values []string) {
+	for i := range schema.Fields {
+		if strings.EqualFold(schema.Fields[i].Name, column) {
+			schema.Fields[i].EnumValues = values
+			return
+		}
+	}
+	schema.Fields = append(schema.Fields, FieldPattern{Name: column, Type: "enum", EnumValues: values})
+}
+
+// observeFieldValues tallies an object's values against its schema's fields
+func observeFieldValues(schema *SchemaPattern, obj map[string]interface{}) {
+	if schema.values == nil {
+		schema.values = make(map[string]*fieldValues)
+	}
+	for key, value := range obj {
+		fv := schema.values[key]
+		if fv == nil {
+			fv = &fieldValues{counts: make(map[string]int)}
+			schema.values[key] = fv
+		}
+		fv.total++
+		if str, ok := value.(string); ok {
+			fv.counts[str]++
+		} else {
+			fv.nonString = true
+		}
+	}
+}
+
+// detectEnumFields sets EnumValues on string fields whose observed values
+// form a small closed set. Declared SQL value sets are left as they are.
+func detectEnumFields(schema *SchemaPattern) {
+	for i := range schema.Fields {
+		field := &schema.Fields[i]
+		fv := schema.values[field.Name]
+		if field.EnumValues != nil || field.Type != "string" || fv == nil || fv.nonString {
+			continue
+		}
+		distinct := len(fv.counts)
+		if fv.total < enumMinObservations || distinct < 2 || distinct > enumMaxValues || distinct*2 > fv.total {
+			continue
+		}
+
+		values := make([]string, 0, distinct)
+		for value := range fv.counts {
+			if value == "" || len(value) > enumMaxValueLength {
+				values = nil
+				break
+			}
+			values = append(values, value)
+		}
+		if values != nil {
+			sort.Strings(values)
+			field.EnumValues = values
+		}
+	}
+}
+
+// fieldEnumSuffix renders a field's enum values for schema listings
+func fieldEnumSuffix(field FieldPattern) string {
+	if len(field.EnumValues) == 0 {
+		return ""
 	}
+	return fmt.Sprintf(" %s[%s]%s", output.Dim, strings.Join(field.EnumValues, ", "), output.Reset)
 }
 
 // extractFieldsFromJSON recursively extracts field names from JSON data
@@ -389,16 +689,11 @@ func analyzeFields(fields []string, result *HarvestResult) {
 // analyzeFieldName analyzes a single field name for patterns
 func analyzeFieldName(field string, result *HarvestResult) {
 	// Count snake_case vs camelCase
-	if strings.Contains(field, "_") {
+	switch fieldCaseStyle(field) {
+	case caseSnake:
 		result.NamingPatterns.SnakeCaseCount++
-	} else if len(field) > 0 && field[0] >= 'a' && field[0] <= 'z' {
-		// Check if it has uppercase letters (camelCase)
-		for _, c := range field[1:] {
-			if c >= 'A' && c <= 'Z' {
-				result.NamingPatterns.CamelCaseCount++
-				break
-			}
-		}
+	case caseCamel:
+		result.NamingPatterns.CamelCaseCount++
 	}
 
 	// Analyze timestamp patterns
@@ -421,8 +716,86 @@ func analyzeFieldName(field string, result *HarvestResult) {
 	}
 }
 
-// inferSchemaFromObject infers schema from JSON object
-func inferSchemaFromObject(obj map[string]interface{}, filePath string, schemaMap map[string]*SchemaPattern) {
+// fieldCaseStyle classifies a field name's case style. Single lowercase or
+// uppercase words ("id", "URL") fit any convention and return "".
+func fieldCaseStyle(field string) string {
+	if strings.Contains(field, "_") {
+		return caseSnake
+	}
+	if strings.Contains(field, "-") {
+		return caseKebab
+	}
+	if field == "" {
+		return ""
+	}
+
+	hasUpper, hasLower := false, false
+	for _, c := range field[1:] {
+		if c >= 'A' && c <= 'Z' {
+			hasUpper = true
+		} else if c >= 'a' && c <= 'z' {
+			hasLower = true
+		}
+	}
+
+	switch {
+	case field[0] >= 'a' && field[0] <= 'z' && hasUpper:
+		return caseCamel
+	case field[0] >= 'A' && field[0] <= 'Z' && hasLower:
+		return casePascal
+	}
+	return ""
+}
+
+// findMixedConventionSchemas returns schemas whose fields don't share one case
+// style, with the fields grouped by style. The dominant style is the one most
+// fields use; every other style lists the offending fields.
+func findMixedConventionSchemas(schemas []SchemaPattern) []MixedConventionSchema {
+	var mixed []MixedConventionSchema
+
+	for _, schema := range schemas {
+		styles := make(map[string][]string)
+		seen := make(map[string]bool)
+		for _, field := range schema.Fields {
+			if seen[field.Name] {
+				continue
+			}
+			seen[field.Name] = true
+			if style := fieldCaseStyle(field.Name); style != "" {
+				styles[style] = append(styles[style], field.Name)
+			}
+		}
+		if len(styles) < 2 {
+			continue
+		}
+
+		dominant := ""
+		for style, fields := range styles {
+			sort.Strings(fields)
+			if dominant == "" || len(fields) > len(styles[dominant]) ||
+				(len(fields) == len(styles[dominant]) && style < dominant) {
+				dominant = style
+			}
+		}
+
+		mixed = append(mixed, MixedConventionSchema{
+			Name:      schema.Name,
+			Styles:    styles,
+			Dominant:  dominant,
+			Locations: schema.Locations,
+		})
+	}
+
+	sort.SliceStable(mixed, func(i, j int) bool {
+		return mixed[i].Name < mixed[j].Name
+	})
+
+	return mixed
+}
+
+// inferSchemaFromObject infers schema from JSON object, returning nil when
+// the object doesn't look like a known schema
+func inferSchemaFromObject(obj map[string]interface{}, filePath string, schemaMap map[string]*SchemaPattern) *SchemaPattern {
 	// Try to infer schema name from common patterns
 	schemaName := "Unknown"
 
@@ -442,27 +815,31 @@ func inferSchemaFromObject(obj map[string]interface{}, filePath string, schemaMa
 		schemaName = "Products"
 	}
 
-	if schemaName != "Unknown" {
-		schema := getOrCreateSchema(schemaName, filePath, schemaMap)
+	if schemaName == "Unknown" {
+		return nil
+	}
+
+	schema := getOrCreateSchema(schemaName, filePath, schemaMap)
 
-		for key, value := range obj {
-			fieldType := inferTypeFromValue(value)
-			// Only add if not already present
-			found := false
-			for _, f := range schema.Fields {
-				if f.Name == key {
-					found = true
-					break
-				}
-			}
-			if !found {
-				schema.Fields = append(schema.Fields, FieldPattern{
-					Name: key,
-					Type: fieldType,
-				})
+	for key, value := range obj {
+		fieldType := inferTypeFromValue(value)
+		// Only add if not already present
+		found := false
+		for _, f := range schema.Fields {
+			if f.Name == key {
+				found = true
+				break
 			}
 		}
+		if !found {
+			schema.Fields = append(schema.Fields, FieldPattern{
+				Name: key,
+				Type: fieldType,
+			})
+		}
 	}
+
+	return schema
 }
 
 // inferTypeFromValue infers type from JSON value
@@ -637,7 +1014,7 @@ func displayHarvestResults(result *HarvestResult) {
 				fieldLimit = len(schema.Fields)
 			}
 			for j := 0; j < fieldLimit; j++ {
-				fmt.Printf("    - %s: %s\n", schema.Fields[j].Name, schema.Fields[j].Type)
+				fmt.Printf("    - %s: %s%s\n", schema.Fields[j].Name, schema.Fields[j].Type, fieldEnumSuffix(schema.Fields[j]))
 			}
 			if len(schema.Fields) > fieldLimit {
 				fmt.Printf("    ... and %d more fields\n", len(schema.Fields)-fieldLimit)
@@ -646,6 +1023,34 @@ func displayHarvestResults(result *HarvestResult) {
 		}
 	}
 
+	// Schemas mixing naming conventions
+	if mixed := findMixedConventionSchemas(result.CommonSchemas); len(mixed) > 0 {
+		output.Header("MIXED-CONVENTION SCHEMAS:")
+		fmt.Println("")
+		for _, schema := range mixed {
+			styles := make([]string, 0, len(schema.Styles))
+			for style := range schema.Styles {
+				if style != schema.Dominant {
+					styles = append(styles, style)
+				}
+			}
+			sort.Strings(styles)
+
+			counts := []string{fmt.Sprintf("%s %d", schema.Dominant, len(schema.Styles[schema.Dominant]))}
+			for _, style := range styles {
+				counts = append(counts, fmt.Sprintf("%s %d", style, len(schema.Styles[style])))
+			}
+			fmt.Printf("  %s%s%s (%s)\n", output.Yellow, schema.Name, output.Reset, strings.Join(counts, ", "))
+			for _, style := range styles {
+				fmt.Printf("    %s: %s\n", style, strings.Join(schema.Styles[style], ", "))
+			}
+			if len(schema.Locations) > 0 {
+				fmt.Printf("    %sin %s%s\n", output.Dim, strings.Join(schema.Locations, ", "), output.Reset)
+			}
+			fmt.Println("")
+		}
+	}
+
 	// API patterns
 	if len(result.APIPatterns) > 0 {
 		output.Header("API PATTERNS:")
@@ -711,14 +1116,127 @@ func displayHarvestReport(result *HarvestResult) {
 	output.Success("Ready to build training programs that taste like the real thing.")
 }
 
+// harvestScanPathSeparator joins the paths of merged harvests in ScanPath
+const harvestScanPathSeparator = ", "
+
+// harvestIncludesPath reports whether path was part of a harvest
+func harvestIncludesPath(result *HarvestResult, path string) bool {
+	return contains(strings.Split(result.ScanPath, harvestScanPathSeparator), path)
+}
+
+// mergeHarvestResults combines a new harvest into an existing one. Counts are
+// summed, and schemas with the same name and fields are merged into one entry
+// whose Locations cover both harvests.
+func mergeHarvestResults(existing, current *HarvestResult) *HarvestResult {
+	merged := &HarvestResult{
+		FileTypes: make(map[string]int),
+		NamingPatterns: NamingConventions{
+			SnakeCaseCount:  existing.NamingPatterns.SnakeCaseCount + current.NamingPatterns.SnakeCaseCount,
+			CamelCaseCount:  existing.NamingPatterns.CamelCaseCount + current.NamingPatterns.CamelCaseCount,
+			TimestampFields: make(map[string]int),
+			IDFormats:       make(map[string]int),
+			BooleanPrefixes: make(map[string]int),
+		},
+		CommonSchemas:     []SchemaPattern{},
+		APIPatterns:       []APIPattern{},
+		ScanPath:          existing.ScanPath,
+		TotalFilesScanned: existing.TotalFilesScanned + current.TotalFilesScanned,
+	}
+
+	if !harvestIncludesPath(existing, current.ScanPath) {
+		if merged.ScanPath == "" {
+			merged.ScanPath = current.ScanPath
+		} else {
+			merged.ScanPath += harvestScanPathSeparator + current.ScanPath
+		}
+	}
+
+	for _, r := range []*HarvestResult{existing, current} {
+		addCounts(merged.FileTypes, r.FileTypes)
+		addCounts(merged.NamingPatterns.TimestampFields, r.NamingPatterns.TimestampFields)
+		addCounts(merged.NamingPatterns.IDFormats, r.NamingPatterns.IDFormats)
+		addCounts(merged.NamingPatterns.BooleanPrefixes, r.NamingPatterns.BooleanPrefixes)
+	}
+
+	// Merge schemas by signature, keeping first-seen order
+	schemaIndex := make(map[string]int)
+	for _, r := range []*HarvestResult{existing, current} {
+		for _, schema := range r.CommonSchemas {
+			sig := schemaSignature(schema)
+			if i, ok := schemaIndex[sig]; ok {
+				merged.CommonSchemas[i].Locations = unique(append(merged.CommonSchemas[i].Locations, schema.Locations...))
+				mergeEnumValues(merged.CommonSchemas[i].Fields, schema.Fields)
+				continue
+			}
+			schemaIndex[sig] = len(merged.CommonSchemas)
+			schema.Locations = append([]string{}, schema.Locations...)
+			schema.Fields = append([]FieldPattern{}, schema.Fields...)
+			merged.CommonSchemas = append(merged.CommonSchemas, schema)
+		}
+	}
+	sort.SliceStable(merged.CommonSchemas, func(i, j int) bool {
+		return len(merged.CommonSchemas[i].Locations) > len(merged.CommonSchemas[j].Locations)
+	})
+
+	// Merge API patterns by pattern name
+	apiIndex := make(map[string]int)
+	for _, r := range []*HarvestResult{existing, current} {
+		for _, pattern := range r.APIPatterns {
+			if i, ok := apiIndex[pattern.Pattern]; ok {
+				merged.APIPatterns[i].Examples = unique(append(merged.APIPatterns[i].Examples, pattern.Examples...))
+				continue
+			}
+			apiIndex[pattern.Pattern] = len(merged.APIPatterns)
+			pattern.Examples = append([]string{}, pattern.Examples...)
+			merged.APIPatterns = append(merged.APIPatterns, pattern)
+		}
+	}
+
+	return merged
+}
+
+// mergeEnumValues adds the enum values of src fields to the same-named dst
+// fields. A field that is an enum in only one harvest stays an enum.
+func mergeEnumValues(dst, src []FieldPattern) {
+	for _, sf := range src {
+		for i := range dst {
+			if dst[i].Name != sf.Name || len(sf.EnumValues) == 0 {
+				continue
+			}
+			values := unique(append(append([]string{}, dst[i].EnumValues...), sf.EnumValues...))
+			if len(dst[i].EnumValues) > 0 && len(values) > len(dst[i].EnumValues) {
+				sort.Strings(values)
+			}
+			dst[i].EnumValues = values
+		}
+	}
+}
+
+// addCounts adds every count in src to dst
+func addCounts(dst, src map[string]int) {
+	for k, v := range src {
+		dst[k] += v
+	}
+}
+
+// schemaSignature identifies a schema by its name and sorted field list
+func schemaSignature(schema SchemaPattern) string {
+	fields := make([]string, 0, len(schema.Fields))
+	for _, f := range schema.Fields {
+		fields = append(fields, f.Name+":"+f.Type)
+	}
+	sort.Strings(fields)
+	return schema.Name + "(" + strings.Join(fields, ",") + ")"
+}
+
 // saveHarvestResults saves harvest data to Mouse's directory
 func saveHarvestResults(result *HarvestResult) error {
-	homeDir, err := os.UserHomeDir()
+	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
 		return err
 	}
 
-	harvestDir := filepath.Join(homeDir, ".claude", "ram", "mouse", "harvest")
+	harvestDir := filepath.Join(ramDir, "mouse", "harvest")
 	if err := os.MkdirAll(harvestDir, 0755); err != nil {
 		return err
 	}
@@ -735,12 +1253,12 @@ func saveHarvestResults(result *HarvestResult) error {
 
 // loadHarvestResults loads harvest data from Mouse's directory
 func loadHarvestResults() (*HarvestResult, error) {
-	homeDir, err := os.UserHomeDir()
+	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
 		return nil, err
 	}
 
-	resultFile := filepath.Join(homeDir, ".claude", "ram", "mouse", "harvest", "latest-harvest.json")
+	resultFile := filepath.Join(ramDir, "mouse", "harvest", "latest-harvest.json")
 	data, err := os.ReadFile(resultFile)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeHarvestResults(t *testing.T) {
+	existing := &HarvestResult{
+		FileTypes: map[string]int{".json": 2, ".md": 5},
+		NamingPatterns: NamingConventions{
+			SnakeCaseCount:  3,
+			TimestampFields: map[string]int{"created_at": 1},
+			IDFormats:       map[string]int{"user_id": 2},
+			BooleanPrefixes: map[string]int{},
+		},
+		CommonSchemas: []SchemaPattern{
+			{Name: "users", Fields: []FieldPattern{{Name: "id", Type: "INTEGER"}, {Name: "email", Type: "TEXT"}}, Locations: []string{"/a/schema.sql"}},
+		},
+		APIPatterns:       []APIPattern{{Pattern: "Auth: Bearer tokens", Examples: []string{}}},
+		ScanPath:          "/a",
+		TotalFilesScanned: 2,
+	}
+	current := &HarvestResult{
+		FileTypes: map[string]int{".json": 1, ".sql": 1},
+		NamingPatterns: NamingConventions{
+			SnakeCaseCount:  2,
+			CamelCaseCount:  1,
+			TimestampFields: map[string]int{"created_at": 2},
+			IDFormats:       map[string]int{},
+			BooleanPrefixes: map[string]int{"is": 1},
+		},
+		CommonSchemas: []SchemaPattern{
+			// Same fields in a different order: same signature
+			{Name: "users", Fields: []FieldPattern{{Name: "email", Type: "TEXT"}, {Name: "id", Type: "INTEGER"}}, Locations: []string{"/b/schema.sql"}},
+			// Same name, different shape: kept separate
+			{Name: "users", Fields: []FieldPattern{{Name: "id", Type: "UUID"}}, Locations: []string{"/b/legacy.sql"}},
+		},
+		ScanPath:          "/b",
+		TotalFilesScanned: 2,
+	}
+
+	merged := mergeHarvestResults(existing, current)
+
+	if want := map[string]int{".json": 3, ".md": 5, ".sql": 1}; !reflect.DeepEqual(merged.FileTypes, want) {
+		t.Errorf("FileTypes = %v, want %v", merged.FileTypes, want)
+	}
+	if merged.NamingPatterns.SnakeCaseCount != 5 || merged.NamingPatterns.CamelCaseCount != 1 {
+		t.Errorf("Unexpected case counts: %+v", merged.NamingPatterns)
+	}
+	if merged.NamingPatterns.TimestampFields["created_at"] != 3 || merged.NamingPatterns.BooleanPrefixes["is"] != 1 {
+		t.Errorf("Unexpected naming stats: %+v", merged.NamingPatterns)
+	}
+	if merged.TotalFilesScanned != 4 || merged.ScanPath != "/a, /b" {
+		t.Errorf("Unexpected totals: files=%d path=%q", merged.TotalFilesScanned, merged.ScanPath)
+	}
+
+	if len(merged.CommonSchemas) != 2 {
+		t.Fatalf("Expected 2 schemas, got %d: %+v", len(merged.CommonSchemas), merged.CommonSchemas)
+	}
+	if want := []string{"/a/schema.sql", "/b/schema.sql"}; !reflect.DeepEqual(merged.CommonSchemas[0].Locations, want) {
+		t.Errorf("Merged schema locations = %v, want %v", merged.CommonSchemas[0].Locations, want)
+	}
+	if len(merged.APIPatterns) != 1 {
+		t.Errorf("Expected 1 API pattern, got %+v", merged.APIPatterns)
+	}
+
+	// Inputs are left untouched
+	if len(existing.CommonSchemas[0].Locations) != 1 || existing.FileTypes[".json"] != 2 {
+		t.Errorf("mergeHarvestResults modified its input: %+v", existing)
+	}
+}
+
+func TestFieldCaseStyle(t *testing.T) {
+	tests := map[string]string{
+		"user_id":   caseSnake,
+		"createdAt": caseCamel,
+		"UserName":  casePascal,
+		"api-key":   caseKebab,
+		"id":        "",
+		"URL":       "",
+		"":          "",
+	}
+	for field, want := range tests {
+		if got := fieldCaseStyle(field); got != want {
+			t.Errorf("fieldCaseStyle(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
+
+func TestFindMixedConventionSchemas(t *testing.T) {
+	schemas := []SchemaPattern{
+		{Name: "users", Fields: []FieldPattern{
+			{Name: "id", Type: "INTEGER"}, {Name: "first_name", Type: "TEXT"}, {Name: "last_name", Type: "TEXT"},
+			{Name: "createdAt", Type: "TIMESTAMP"}, {Name: "created_at", Type: "TIMESTAMP"}, {Name: "createdAt", Type: "TIMESTAMP"},
+		}, Locations: []string{"/a/schema.sql"}},
+		{Name: "orders", Fields: []FieldPattern{{Name: "id", Type: "INTEGER"}, {Name: "order_total", Type: "DECIMAL"}, {Name: "placed_at", Type: "TIMESTAMP"}}},
+		{Name: "Products", Fields: []FieldPattern{{Name: "sku", Type: "string"}, {Name: "unitPrice", Type: "number"}, {Name: "in_stock", Type: "boolean"}}},
+	}
+
+	mixed := findMixedConventionSchemas(schemas)
+
+	if len(mixed) != 2 || mixed[0].Name != "Products" || mixed[1].Name != "users" {
+		t.Fatalf("Expected Products and users, got %+v", mixed)
+	}
+
+	users := mixed[1]
+	if users.Dominant != caseSnake {
+		t.Errorf("Expected users to be mostly %s, got %s", caseSnake, users.Dominant)
+	}
+	want := map[string][]string{
+		caseSnake: {"created_at", "first_name", "last_name"},
+		caseCamel: {"createdAt"},
+	}
+	if !reflect.DeepEqual(users.Styles, want) {
+		t.Errorf("users styles = %v, want %v", users.Styles, want)
+	}
+
+	// Ties go to the alphabetically first style
+	if mixed[0].Dominant != caseCamel {
+		t.Errorf("Expected Products tie to resolve to %s, got %s", caseCamel, mixed[0].Dominant)
+	}
+}
+
+func TestHarvestEnumFields(t *testing.T) {
+	dir := t.TempDir()
+	users := `[
+  {"email": "a@x.io", "role": "admin", "status": "active", "nickname": "ace"},
+  {"email": "b@x.io", "role": "member", "status": "active", "nickname": "bee"},
+  {"email": "c@x.io", "role": "member", "status": "banned", "nickname": "cat"},
+  {"email": "d@x.io", "role": "member", "status": "active", "nickname": "dog"}
+]`
+	schema := `
+CREATE TABLE orders (
+  id INTEGER PRIMARY KEY,
+  state ENUM('new', 'paid', 'shipped') NOT NULL,
+  channel TEXT CHECK (channel IN ('web', 'app')),
+  note TEXT
+);
+`
+	if err := os.WriteFile(filepath.Join(dir, "users.json"), []byte(users), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "schema.sql"), []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := harvestDataPatterns(dir)
+	if err != nil {
+		t.Fatalf("harvestDataPatterns() failed: %v", err)
+	}
+
+	enums := make(map[string][]string)
+	for _, schema := range result.CommonSchemas {
+		for _, field := range schema.Fields {
+			if len(field.EnumValues) > 0 {
+				enums[schema.Name+"."+field.Name] = field.EnumValues
+			}
+		}
+	}
+
+	want := map[string][]string{
+		"Users.role":     {"admin", "member"},
+		"Users.status":   {"active", "banned"},
+		"orders.state":   {"new", "paid", "shipped"},
+		"orders.channel": {"web", "app"},
+	}
+	if !reflect.DeepEqual(enums, want) {
+		t.Errorf("enum fields = %v, want %v", enums, want)
+	}
+}
+
+func TestWriteHarvestSchemas(t *testing.T) {
+	schemas := []SchemaPattern{{
+		Name: "orders",
+		Fields: []FieldPattern{
+			{Name: "id", Type: "integer"},
+			{Name: "state", Type: "string", EnumValues: []string{"new", "yes", "a: b"}},
+		},
+		Locations: []string{"db/schema.sql", "fixtures/orders.json"},
+	}}
+
+	var csvOut bytes.Buffer
+	if err := writeHarvestSchemas(&csvOut, schemas, "csv"); err != nil {
+		t.Fatalf("writeHarvestSchemas(csv) failed: %v", err)
+	}
+	wantCSV := "schema,field,type,enum_values,locations\n" +
+		"orders,id,integer,,db/schema.sql;fixtures/orders.json\n" +
+		"orders,state,string,new|yes|a: b,db/schema.sql;fixtures/orders.json\n"
+	if csvOut.String() != wantCSV {
+		t.Errorf("Unexpected csv:\n%s", csvOut.String())
+	}
+
+	var yamlOut bytes.Buffer
+	if err := writeHarvestSchemas(&yamlOut, schemas, "yaml"); err != nil {
+		t.Fatalf("writeHarvestSchemas(yaml) failed: %v", err)
+	}
+	for _, want := range []string{
+		"- name: orders\n  fields:\n    - name: id\n      type: integer\n",
+		"      enum_values:\n        - new\n        - \"yes\"\n        - \"a: b\"\n",
+		"  locations:\n    - db/schema.sql\n",
+	} {
+		if !strings.Contains(yamlOut.String(), want) {
+			t.Errorf("Expected yaml to contain %q:\n%s", want, yamlOut.String())
+		}
+	}
+
+	var jsonOut bytes.Buffer
+	if err := writeHarvestSchemas(&jsonOut, schemas, "json"); err != nil {
+		t.Fatalf("writeHarvestSchemas(json) failed: %v", err)
+	}
+	var decoded []harvestSchemaJSON
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid json: %v", err)
+	}
+	if len(decoded) != 1 || len(decoded[0].Fields) != 2 || decoded[0].Fields[1].EnumValues[2] != "a: b" {
+		t.Errorf("Unexpected json round trip: %+v", decoded)
+	}
+}
+
+func TestYAMLScalar(t *testing.T) {
+	for value, want := range map[string]string{
+		"users":       "users",
+		"created_at":  "created_at",
+		"":            `""`,
+		"true":        `"true"`,
+		"42":          `"42"`,
+		"- item":      `"- item"`,
+		"key: value":  `"key: value"`,
+		"line\nbreak": `"line\nbreak"`,
+		"C:\\path":    `"C:\\path"`,
+	} {
+		if got := yamlScalar(value); got != want {
+			t.Errorf("yamlScalar(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
 )
 
 // DebtMarker represents a technical debt marker found in code
@@ -379,12 +380,12 @@ func severityToString(severity DebtSeverity) string {
 
 // createTaskFiles generates remediation task files in Ramakandra's RAM directory
 func createTaskFiles(report *DebtReport) error {
-	homeDir, err := os.UserHomeDir()
+	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	taskDir := filepath.Join(homeDir, ".claude", "ram", "ramakandra", "debt-tasks")
+	taskDir := filepath.Join(ramDir, "ramakandra", "debt-tasks")
 
 	// Create directory if it doesn't exist
 	if err := os.MkdirAll(taskDir, 0755); err != nil {
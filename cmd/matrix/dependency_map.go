@@ -9,10 +9,12 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // ToolchainInfo represents an installed toolchain
@@ -23,6 +25,8 @@ type ToolchainInfo struct {
 	Path        string `json:"path"`        // where the binary is
 	Available   bool   `json:"available"`   // was it detected
 	CheckedAt   string `json:"checked_at"`
+	MinVersion  string `json:"min_version,omitempty"` // from --flag-eol
+	EOL         bool   `json:"eol,omitempty"`         // below MinVersion
 }
 
 // PackageManifest represents a package manifest file
@@ -42,6 +46,20 @@ type EcosystemSummary struct {
 	ManifestCount int    `json:"manifest_count"`
 }
 
+// VersionPin is one manifest's version spec for a dependency
+type VersionPin struct {
+	Version string `json:"version"`
+	Source  string `json:"source"`
+}
+
+// VersionConflict is a dependency pinned to different versions across
+// manifests of the same ecosystem
+type VersionConflict struct {
+	Ecosystem string       `json:"ecosystem"`
+	Name      string       `json:"name"`
+	Pins      []VersionPin `json:"pins"`
+}
+
 // DependencyMapOutput contains the complete scan results
 type DependencyMapOutput struct {
 	ScannedAt   time.Time          `json:"scanned_at"`
@@ -49,6 +67,7 @@ type DependencyMapOutput struct {
 	Toolchains  []ToolchainInfo    `json:"toolchains"`
 	Manifests   []PackageManifest  `json:"manifests"`
 	Ecosystems  []EcosystemSummary `json:"ecosystems"`
+	Conflicts   []VersionConflict  `json:"conflicts"`
 }
 
 // runDependencyMap implements the dependency-map command
@@ -61,17 +80,19 @@ func runDependencyMap() error {
 		subCmd = os.Args[2]
 	}
 
-	switch subCmd {
-	case "scan":
+	switch {
+	case subCmd == "scan":
 		return runDependencyScan(fs)
-	case "toolchains":
-		return runToolchainsCheck()
-	case "report":
-		return runDependencyReport()
-	case "":
-		return runDependencyReport()
+	case subCmd == "toolchains":
+		return runToolchainsCheck(fs, os.Args[3:])
+	case subCmd == "report":
+		return runDependencyReport(fs, os.Args[3:])
+	case subCmd == "conflicts":
+		return runDependencyConflicts(fs)
+	case subCmd == "" || strings.HasPrefix(subCmd, "-"):
+		return runDependencyReport(fs, os.Args[2:])
 	default:
-		return fmt.Errorf("unknown subcommand: %s (valid: scan, toolchains, report)", subCmd)
+		return fmt.Errorf("unknown subcommand: %s (valid: scan, toolchains, report, conflicts)", subCmd)
 	}
 }
 
@@ -144,12 +165,68 @@ func runDependencyScan(fs *flag.FlagSet) error {
 	return nil
 }
 
-// runToolchainsCheck checks for installed toolchains
-func runToolchainsCheck() error {
-	output.Success("🔧 Toolchain Detection")
+// runDependencyConflicts reports dependencies pinned to different versions
+// across manifests of the same ecosystem
+func runDependencyConflicts(fs *flag.FlagSet) error {
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+
+	// Parse flags
+	if len(os.Args) > 3 {
+		fs.Parse(os.Args[3:])
+	}
+
+	// Get target path
+	targetPath := "."
+	if fs.NArg() > 0 {
+		targetPath = fs.Arg(0)
+	}
+
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	if _, err := os.Stat(absPath); err != nil {
+		return fmt.Errorf("path does not exist: %s", absPath)
+	}
+
+	conflicts := detectVersionConflicts(scanForManifests(absPath), absPath)
+
+	if *jsonOutput {
+		if conflicts == nil {
+			conflicts = []VersionConflict{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(conflicts)
+	}
+
+	output.Success("🔧 Dependency Version Conflicts")
+	fmt.Println("")
+	fmt.Printf("Scanning: %s\n", absPath)
 	fmt.Println("")
 
+	if len(conflicts) == 0 {
+		fmt.Println("No version conflicts found.")
+		return nil
+	}
+
+	displayVersionConflicts(conflicts)
+	return nil
+}
+
+// runToolchainsCheck checks for installed toolchains
+func runToolchainsCheck(fs *flag.FlagSet, args []string) error {
+	eolFile := fs.String("flag-eol", "", "File of minimum supported versions per tool")
+	fs.Parse(args)
+
 	toolchains := detectToolchains()
+	if err := applyEOLFile(toolchains, *eolFile); err != nil {
+		return err
+	}
+
+	output.Success("🔧 Toolchain Detection")
+	fmt.Println("")
 
 	if len(toolchains) == 0 {
 		fmt.Println("No toolchains detected.")
@@ -165,7 +242,11 @@ func runToolchainsCheck() error {
 			if tc.Manager != "" {
 				managerInfo = fmt.Sprintf(" (%s)", tc.Manager)
 			}
-			fmt.Printf("  ✓ %s %s%s\n", tc.Name, output.Green+tc.Version+output.Reset, managerInfo)
+			if tc.EOL {
+				fmt.Printf("  ⚠ %s %s%s%s\n", tc.Name, output.Yellow+tc.Version+output.Reset, managerInfo, eolNote(tc))
+			} else {
+				fmt.Printf("  ✓ %s %s%s\n", tc.Name, output.Green+tc.Version+output.Reset, managerInfo)
+			}
 			if tc.Path != "" {
 				fmt.Printf("    %s\n", output.Dim+tc.Path+output.Reset)
 			}
@@ -175,23 +256,37 @@ func runToolchainsCheck() error {
 	}
 	fmt.Println("")
 
+	displayEOLWarning(toolchains)
+
 	return nil
 }
 
 // runDependencyReport generates full dependency report
-func runDependencyReport() error {
-	output.Success("🔧 Dependency Map")
-	fmt.Println("")
+func runDependencyReport(fs *flag.FlagSet, args []string) error {
+	eolFile := fs.String("flag-eol", "", "File of minimum supported versions per tool")
+	jsonOutput := fs.Bool("json", false, "Output the full report as JSON")
+	fs.Parse(args)
 
 	// Detect toolchains
 	toolchains := detectToolchains()
+	if err := applyEOLFile(toolchains, *eolFile); err != nil {
+		return err
+	}
 
 	// Scan current directory for manifests
 	cwd, _ := os.Getwd()
-	manifests := scanForManifests(cwd)
+	report := buildDependencyMapOutput(cwd, toolchains)
 
-	// Calculate ecosystem summaries
-	ecosystems := summarizeEcosystems(manifests)
+	if *jsonOutput {
+		return output.EmitJSON(report)
+	}
+
+	manifests := report.Manifests
+	ecosystems := report.Ecosystems
+	conflicts := report.Conflicts
+
+	output.Success("🔧 Dependency Map")
+	fmt.Println("")
 
 	// Display results
 	if len(toolchains) > 0 {
@@ -204,10 +299,11 @@ func runDependencyReport() error {
 				if tc.Manager != "" {
 					managerInfo = fmt.Sprintf(" (%s)", output.Dim+tc.Manager+output.Reset+")")
 				}
-				fmt.Printf("  %s %s%s\n", tc.Name, tc.Version, managerInfo)
+				fmt.Printf("  %s %s%s%s\n", tc.Name, tc.Version, managerInfo, eolNote(tc))
 			}
 		}
 		fmt.Println("")
+		displayEOLWarning(toolchains)
 	}
 
 	if len(manifests) > 0 {
@@ -252,6 +348,10 @@ func runDependencyReport() error {
 		fmt.Println("")
 	}
 
+	if len(conflicts) > 0 {
+		displayVersionConflicts(conflicts)
+	}
+
 	if len(toolchains) == 0 && len(manifests) == 0 {
 		fmt.Println("No toolchains or package manifests detected.")
 		fmt.Println("")
@@ -260,6 +360,36 @@ func runDependencyReport() error {
 	return nil
 }
 
+// buildDependencyMapOutput scans scanPath for manifests and assembles the
+// full report. Empty sections are empty lists rather than null in JSON.
+func buildDependencyMapOutput(scanPath string, toolchains []ToolchainInfo) DependencyMapOutput {
+	manifests := scanForManifests(scanPath)
+
+	report := DependencyMapOutput{
+		ScannedAt:  time.Now(),
+		ScanPath:   scanPath,
+		Toolchains: toolchains,
+		Manifests:  manifests,
+		Ecosystems: summarizeEcosystems(manifests),
+		Conflicts:  detectVersionConflicts(manifests, scanPath),
+	}
+
+	if report.Toolchains == nil {
+		report.Toolchains = []ToolchainInfo{}
+	}
+	if report.Manifests == nil {
+		report.Manifests = []PackageManifest{}
+	}
+	if report.Ecosystems == nil {
+		report.Ecosystems = []EcosystemSummary{}
+	}
+	if report.Conflicts == nil {
+		report.Conflicts = []VersionConflict{}
+	}
+
+	return report
+}
+
 // detectToolchains probes for installed toolchains
 func detectToolchains() []ToolchainInfo {
 	checks := []struct {
@@ -356,9 +486,124 @@ func detectToolchains() []ToolchainInfo {
 		toolchains = append(toolchains, tc)
 	}
 
+	sort.Slice(toolchains, func(i, j int) bool {
+		return toolchains[i].Name < toolchains[j].Name
+	})
+
 	return toolchains
 }
 
+// loadMinVersions reads a file of minimum supported versions, one tool per
+// line as "node 18", "node: 18.0" or "node=18.0.0". Blank lines and lines
+// starting with # are ignored.
+func loadMinVersions(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read EOL file: %w", err)
+	}
+
+	mins := make(map[string]string)
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ':' || r == '=' || r == ' ' || r == '\t'
+		})
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected \"<tool> <min-version>\", got %q", path, i+1, line)
+		}
+		mins[strings.ToLower(fields[0])] = strings.TrimPrefix(fields[1], "v")
+	}
+	return mins, nil
+}
+
+// applyEOLFile marks toolchains below the minimum versions listed in path.
+// An empty path leaves toolchains untouched.
+func applyEOLFile(toolchains []ToolchainInfo, path string) error {
+	if path == "" {
+		return nil
+	}
+	mins, err := loadMinVersions(util.ExpandPath(path))
+	if err != nil {
+		return err
+	}
+	markEOL(toolchains, mins)
+	return nil
+}
+
+// markEOL flags available toolchains whose version is below their minimum
+func markEOL(toolchains []ToolchainInfo, mins map[string]string) {
+	for i := range toolchains {
+		tc := &toolchains[i]
+		minVersion, ok := mins[tc.Name]
+		if !ok || !tc.Available {
+			continue
+		}
+		tc.MinVersion = minVersion
+		tc.EOL = compareVersions(tc.Version, minVersion) < 0
+	}
+}
+
+// compareVersions compares dotted numeric versions, treating missing parts
+// as zero, so "18" == "18.0.0" and "9.1" < "10". Non-numeric suffixes on a
+// part (e.g. "3rc1") are ignored.
+func compareVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		av, bv := versionPart(aParts, i), versionPart(bParts, i)
+		if av != bv {
+			if av < bv {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionPart returns the leading number of parts[i], or 0 if absent
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	digits := parts[i]
+	for j, r := range digits {
+		if r < '0' || r > '9' {
+			digits = digits[:j]
+			break
+		}
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// eolNote describes why a toolchain is flagged, or "" if it isn't
+func eolNote(tc ToolchainInfo) string {
+	if !tc.EOL {
+		return ""
+	}
+	return fmt.Sprintf(" %s[end-of-life: minimum %s]%s", output.Yellow, tc.MinVersion, output.Reset)
+}
+
+// displayEOLWarning summarizes toolchains below their minimum version
+func displayEOLWarning(toolchains []ToolchainInfo) {
+	var names []string
+	for _, tc := range toolchains {
+		if tc.EOL {
+			names = append(names, tc.Name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf("%s⚠ %d toolchains below minimum supported version: %s%s\n",
+		output.Yellow, len(names), strings.Join(names, ", "), output.Reset)
+	fmt.Println("")
+}
+
 // detectManager tries to determine which manager installed a tool
 func detectManager(path string, possibleManagers []string) string {
 	if path == "" {
@@ -601,3 +846,87 @@ func summarizeEcosystems(manifests []PackageManifest) []EcosystemSummary {
 
 	return ecosystems
 }
+
+// dependencyEcosystem groups manifest types that share a package namespace
+func dependencyEcosystem(manifestType string) string {
+	switch manifestType {
+	case "pip", "pipenv", "poetry":
+		return "python"
+	default:
+		return manifestType
+	}
+}
+
+// detectVersionConflicts groups dependencies by ecosystem and name across all
+// manifests and returns those whose version specs disagree. Sources are made
+// relative to rootPath.
+func detectVersionConflicts(manifests []PackageManifest, rootPath string) []VersionConflict {
+	type depKey struct{ ecosystem, name string }
+	pins := make(map[depKey][]VersionPin)
+
+	for _, m := range manifests {
+		source, err := filepath.Rel(rootPath, m.Path)
+		if err != nil {
+			source = m.Path
+		}
+
+		ecosystem := dependencyEcosystem(m.Type)
+		for _, deps := range [][]Dependency{m.Dependencies, m.DevDeps} {
+			for _, dep := range deps {
+				version := strings.TrimSpace(dep.Version)
+				if version == "" {
+					continue
+				}
+				key := depKey{ecosystem, dep.Name}
+				pins[key] = append(pins[key], VersionPin{Version: version, Source: source})
+			}
+		}
+	}
+
+	var conflicts []VersionConflict
+	for key, depPins := range pins {
+		versions := make(map[string]bool)
+		for _, pin := range depPins {
+			versions[pin.Version] = true
+		}
+		if len(versions) < 2 {
+			continue
+		}
+
+		sort.Slice(depPins, func(i, j int) bool {
+			if depPins[i].Version != depPins[j].Version {
+				return depPins[i].Version < depPins[j].Version
+			}
+			return depPins[i].Source < depPins[j].Source
+		})
+
+		conflicts = append(conflicts, VersionConflict{
+			Ecosystem: key.ecosystem,
+			Name:      key.name,
+			Pins:      depPins,
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Ecosystem != conflicts[j].Ecosystem {
+			return conflicts[i].Ecosystem < conflicts[j].Ecosystem
+		}
+		return conflicts[i].Name < conflicts[j].Name
+	})
+
+	return conflicts
+}
+
+// displayVersionConflicts prints each conflicting dependency with its pins
+func displayVersionConflicts(conflicts []VersionConflict) {
+	output.Header(fmt.Sprintf("Version Conflicts: %d", len(conflicts)))
+	fmt.Println("")
+
+	for _, c := range conflicts {
+		fmt.Printf("  %s (%s)\n", output.Yellow+c.Name+output.Reset, c.Ecosystem)
+		for _, pin := range c.Pins {
+			fmt.Printf("    %-20s %s\n", pin.Version, output.Dim+pin.Source+output.Reset)
+		}
+		fmt.Println("")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectVersionConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"api/go.mod":           "module api\n\nrequire (\n\tgithub.com/x/y v1.2.0\n\tgithub.com/same/dep v0.1.0\n)\n",
+		"worker/go.mod":        "module worker\n\nrequire github.com/x/y v1.3.0\n",
+		"tools/go.mod":         "module tools\n\nrequire github.com/same/dep v0.1.0\n",
+		"web/requirements.txt": "requests==2.31.0\n",
+		"ml/requirements.txt":  "requests==2.28.0\n",
+	})
+
+	conflicts := detectVersionConflicts(scanForManifests(tmpDir), tmpDir)
+
+	if len(conflicts) != 2 {
+		t.Fatalf("Expected 2 conflicts, got %d: %+v", len(conflicts), conflicts)
+	}
+
+	goConflict := conflicts[0]
+	if goConflict.Ecosystem != "go" || goConflict.Name != "github.com/x/y" {
+		t.Errorf("Expected go conflict on github.com/x/y, got %s %s", goConflict.Ecosystem, goConflict.Name)
+	}
+	if len(goConflict.Pins) != 2 ||
+		goConflict.Pins[0].Source != filepath.Join("api", "go.mod") ||
+		goConflict.Pins[1].Version != "v1.3.0" {
+		t.Errorf("Unexpected pins: %+v", goConflict.Pins)
+	}
+
+	if conflicts[1].Ecosystem != "python" || conflicts[1].Name != "requests" {
+		t.Errorf("Expected python conflict on requests, got %s %s", conflicts[1].Ecosystem, conflicts[1].Name)
+	}
+}
+
+func TestMarkEOL(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"eol.txt": "# minimum supported versions\nnode: 18\npython 3.9\ngo=1.21.0\nrust 1.70\n",
+	})
+
+	mins, err := loadMinVersions(filepath.Join(tmpDir, "eol.txt"))
+	if err != nil {
+		t.Fatalf("loadMinVersions() failed: %v", err)
+	}
+
+	toolchains := []ToolchainInfo{
+		{Name: "node", Version: "16.20.2", Available: true},
+		{Name: "python", Version: "3.10.4", Available: true},
+		{Name: "go", Version: "1.21.0", Available: true},
+		{Name: "rust", Available: false},
+		{Name: "npm", Version: "6.0.0", Available: true},
+	}
+	markEOL(toolchains, mins)
+
+	wantEOL := map[string]bool{"node": true}
+	for _, tc := range toolchains {
+		if tc.EOL != wantEOL[tc.Name] {
+			t.Errorf("%s %s: expected EOL=%v (min %q)", tc.Name, tc.Version, wantEOL[tc.Name], tc.MinVersion)
+		}
+	}
+	if toolchains[0].MinVersion != "18" {
+		t.Errorf("Expected node minimum 18, got %q", toolchains[0].MinVersion)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"18", "18.0.0", 0},
+		{"9.1", "10", -1},
+		{"1.21.5", "1.21", 1},
+		{"3.12rc1", "3.12", 0},
+		{"2.0.0", "10.0.0", -1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBuildDependencyMapOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"api/go.mod":    "module api\n\nrequire github.com/x/y v1.2.0\n",
+		"worker/go.mod": "module worker\n\nrequire github.com/x/y v1.3.0\n",
+	})
+
+	report := buildDependencyMapOutput(tmpDir, nil)
+
+	if report.ScanPath != tmpDir || report.ScannedAt.IsZero() {
+		t.Errorf("Expected scan metadata, got path=%q at=%v", report.ScanPath, report.ScannedAt)
+	}
+	if report.Toolchains == nil {
+		t.Error("Expected empty toolchains list rather than nil")
+	}
+	if len(report.Manifests) != 2 {
+		t.Errorf("Expected 2 manifests, got %d", len(report.Manifests))
+	}
+	if len(report.Ecosystems) != 1 || report.Ecosystems[0].ManifestCount != 2 {
+		t.Errorf("Expected one ecosystem across 2 manifests, got %+v", report.Ecosystems)
+	}
+	if len(report.Conflicts) != 1 {
+		t.Errorf("Expected 1 conflict, got %+v", report.Conflicts)
+	}
+
+	empty := buildDependencyMapOutput(t.TempDir(), nil)
+	if empty.Manifests == nil || empty.Ecosystems == nil || empty.Conflicts == nil {
+		t.Errorf("Expected empty lists for an empty tree, got %+v", empty)
+	}
+}
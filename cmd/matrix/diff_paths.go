@@ -22,6 +22,7 @@ type FileAnalysis struct {
 	NestingDepth int
 	IsAsync      bool
 	HasState     bool
+	TestSignals  int
 }
 
 // PathComparison contains the full diff analysis
@@ -29,6 +30,15 @@ type PathComparison struct {
 	PathA      FileAnalysis
 	PathB      FileAnalysis
 	Tradeoffs  TradeoffSummary
+	Matrix     []TradeoffRow
+}
+
+// TradeoffRow is one dimension of the tradeoff matrix
+type TradeoffRow struct {
+	Dimension string
+	A         string
+	B         string
+	Winner    string // "A", "B", "tie", or "-" when neither is better
 }
 
 // TradeoffSummary provides decision guidance
@@ -44,6 +54,7 @@ func runDiffPaths() error {
 	// Parse flags
 	dirMode := false
 	jsonOutput := false
+	tableOutput := false
 	var pathA, pathB string
 
 	for i := 0; i < len(args); i++ {
@@ -52,6 +63,8 @@ func runDiffPaths() error {
 			dirMode = true
 		case "--json":
 			jsonOutput = true
+		case "--table":
+			tableOutput = true
 		default:
 			if pathA == "" {
 				pathA = args[i]
@@ -62,7 +75,7 @@ func runDiffPaths() error {
 	}
 
 	if pathA == "" || pathB == "" {
-		return fmt.Errorf("usage: diff-paths [--dir] [--json] <path-a> <path-b>")
+		return fmt.Errorf("usage: diff-paths [--dir] [--json|--table] <path-a> <path-b>")
 	}
 
 	// Make paths absolute
@@ -97,6 +110,7 @@ func runDiffPaths() error {
 		PathA:     analysisA,
 		PathB:     analysisB,
 		Tradeoffs: tradeoffs,
+		Matrix:    buildTradeoffMatrix(analysisA, analysisB),
 	}
 
 	if jsonOutput {
@@ -105,6 +119,11 @@ func runDiffPaths() error {
 		return encoder.Encode(comparison)
 	}
 
+	if tableOutput {
+		printTradeoffMatrix(comparison)
+		return nil
+	}
+
 	// Human-readable output
 	printComparison(comparison)
 	return nil
@@ -163,6 +182,7 @@ func analyzeFile(path string) (FileAnalysis, error) {
 	importPattern := regexp.MustCompile(`^\s*(import|from|use|require|#include)`)
 	asyncPattern := regexp.MustCompile(`\b(async|await|Promise|Future|Task)\b`)
 	statePattern := regexp.MustCompile(`\b(self\.|this\.|@|var|let|const|mut)\b`)
+	testPattern := regexp.MustCompile(`(^\s*func\s+Test\w*\(|^\s*def\s+test_|\b(describe|it|test)\(|#\[test\]|@Test\b|\bassert)`)
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -192,6 +212,11 @@ func analyzeFile(path string) (FileAnalysis, error) {
 			analysis.HasState = true
 		}
 
+		// Count test coverage signals (test functions, assertions)
+		if testPattern.MatchString(line) {
+			analysis.TestSignals++
+		}
+
 		// Track nesting depth (simple brace counting)
 		currentNesting += strings.Count(line, "{") - strings.Count(line, "}")
 		if currentNesting > maxNesting {
@@ -285,6 +310,77 @@ func generateTradeoffs(a, b FileAnalysis) TradeoffSummary {
 	return summary
 }
 
+// buildTradeoffMatrix compares both analyses dimension by dimension
+func buildTradeoffMatrix(a, b FileAnalysis) []TradeoffRow {
+	unitsA := a.Classes + a.Functions + a.Methods
+	unitsB := b.Classes + b.Functions + b.Methods
+
+	return []TradeoffRow{
+		{"Language", a.Language, b.Language, "-"},
+		{"Lines of code", fmt.Sprint(a.Lines), fmt.Sprint(b.Lines), lowerWins(a.Lines, b.Lines)},
+		{"Dependencies (imports)", fmt.Sprint(a.Imports), fmt.Sprint(b.Imports), lowerWins(a.Imports, b.Imports)},
+		{"Nesting depth", fmt.Sprint(a.NestingDepth), fmt.Sprint(b.NestingDepth), lowerWins(a.NestingDepth, b.NestingDepth)},
+		{"Code units", fmt.Sprint(unitsA), fmt.Sprint(unitsB), "-"},
+		{"Classes", fmt.Sprint(a.Classes), fmt.Sprint(b.Classes), "-"},
+		{"Test signals", fmt.Sprint(a.TestSignals), fmt.Sprint(b.TestSignals), higherWins(a.TestSignals, b.TestSignals)},
+		{"Async", fmt.Sprint(a.IsAsync), fmt.Sprint(b.IsAsync), "-"},
+		{"Stateful", fmt.Sprint(a.HasState), fmt.Sprint(b.HasState), boolWins(!a.HasState, !b.HasState)},
+	}
+}
+
+// lowerWins picks the side with the smaller metric
+func lowerWins(a, b int) string {
+	return higherWins(b, a)
+}
+
+// higherWins picks the side with the larger metric
+func higherWins(a, b int) string {
+	switch {
+	case a > b:
+		return "A"
+	case b > a:
+		return "B"
+	default:
+		return "tie"
+	}
+}
+
+// boolWins picks the side where the desirable property holds
+func boolWins(a, b bool) string {
+	switch {
+	case a && !b:
+		return "A"
+	case b && !a:
+		return "B"
+	default:
+		return "tie"
+	}
+}
+
+// printTradeoffMatrix outputs the tradeoff matrix as a table
+func printTradeoffMatrix(comp PathComparison) {
+	fmt.Println("🔀 Tradeoff Matrix")
+	fmt.Println()
+	fmt.Printf("  A: %s\n", comp.PathA.Path)
+	fmt.Printf("  B: %s\n", comp.PathB.Path)
+	fmt.Println()
+
+	fmt.Printf("%-24s | %-12s | %-12s | %s\n", "Dimension", "A", "B", "Winner")
+	fmt.Println("-------------------------+--------------+--------------+-------")
+	winsA, winsB := 0, 0
+	for _, row := range comp.Matrix {
+		fmt.Printf("%-24s | %-12s | %-12s | %s\n", row.Dimension, row.A, row.B, row.Winner)
+		switch row.Winner {
+		case "A":
+			winsA++
+		case "B":
+			winsB++
+		}
+	}
+	fmt.Println()
+	fmt.Printf("Score: A %d, B %d\n", winsA, winsB)
+}
+
 // printComparison outputs human-readable comparison
 func printComparison(comp PathComparison) {
 	fmt.Println("🔀 Path Divergence Analysis")
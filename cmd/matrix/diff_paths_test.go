@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDiffPathsFixture writes a small stateless file and a larger class
+// based one with tests, so every scored dimension has a clear winner
+func writeDiffPathsFixture(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"a.py": "def add(x, y):\n    return x + y\n",
+		"b.py": `import os
+
+class Counter:
+    def __init__(self):
+        self.n = 0
+
+def test_counter():
+    assert Counter().n == 0
+`,
+	})
+	return filepath.Join(dir, "a.py"), filepath.Join(dir, "b.py")
+}
+
+func TestBuildTradeoffMatrix(t *testing.T) {
+	pathA, pathB := writeDiffPathsFixture(t)
+	a, err := analyzeFile(pathA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := analyzeFile(pathB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	winners := make(map[string]string)
+	for _, row := range buildTradeoffMatrix(a, b) {
+		winners[row.Dimension] = row.Winner
+	}
+	want := map[string]string{
+		"Language":               "-",
+		"Lines of code":          "A",
+		"Dependencies (imports)": "A",
+		"Nesting depth":          "tie",
+		"Code units":             "-",
+		"Classes":                "-",
+		"Test signals":           "B",
+		"Async":                  "-",
+		"Stateful":               "A",
+	}
+	for dim, winner := range want {
+		if winners[dim] != winner {
+			t.Errorf("%s: winner = %q, want %q", dim, winners[dim], winner)
+		}
+	}
+}
+
+func TestTradeoffWinners(t *testing.T) {
+	if got := lowerWins(1, 2); got != "A" {
+		t.Errorf("lowerWins(1, 2) = %s, want A", got)
+	}
+	if got := higherWins(1, 2); got != "B" {
+		t.Errorf("higherWins(1, 2) = %s, want B", got)
+	}
+	if got := higherWins(3, 3); got != "tie" {
+		t.Errorf("higherWins(3, 3) = %s, want tie", got)
+	}
+	if got := boolWins(false, true); got != "B" {
+		t.Errorf("boolWins(false, true) = %s, want B", got)
+	}
+	if got := boolWins(true, true); got != "tie" {
+		t.Errorf("boolWins(true, true) = %s, want tie", got)
+	}
+}
+
+func TestDiffPathsTable(t *testing.T) {
+	pathA, pathB := writeDiffPathsFixture(t)
+	out, err := captureStdout(t, []string{"matrix", "diff-paths", "--table", pathA, pathB}, runDiffPaths)
+	if err != nil {
+		t.Fatalf("runDiffPaths failed: %v", err)
+	}
+
+	if !strings.Contains(out, "Tradeoff Matrix") {
+		t.Errorf("Expected a tradeoff matrix header:\n%s", out)
+	}
+	if !strings.Contains(out, "Score: A 3, B 1") {
+		t.Errorf("Expected A to win three dimensions and B one:\n%s", out)
+	}
+}
+
+func TestDiffPathsJSON(t *testing.T) {
+	pathA, pathB := writeDiffPathsFixture(t)
+	out, err := captureStdout(t, []string{"matrix", "diff-paths", "--json", pathA, pathB}, runDiffPaths)
+	if err != nil {
+		t.Fatalf("runDiffPaths failed: %v", err)
+	}
+
+	var comp PathComparison
+	if err := json.Unmarshal([]byte(out), &comp); err != nil {
+		t.Fatalf("Output is not a comparison: %v\n%s", err, out)
+	}
+	if comp.PathB.Classes != 1 || comp.PathB.Imports != 1 || !comp.PathB.HasState {
+		t.Errorf("Unexpected analysis of B: %+v", comp.PathB)
+	}
+	if len(comp.Matrix) != 9 {
+		t.Errorf("Expected 9 matrix rows, got %d", len(comp.Matrix))
+	}
+}
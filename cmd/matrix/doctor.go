@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/coryzibell/matrix/internal/identity"
+	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
+)
+
+// doctorWriteDir is a RAM subdirectory a command writes to
+type doctorWriteDir struct {
+	Identity string
+	Dir      string
+	Command  string
+}
+
+// Directories commands write to, relative to ~/.claude/ram/
+var doctorWriteDirs = []doctorWriteDir{
+	{Identity: "deus", Dir: "verdicts", Command: "verdict"},
+	{Identity: "persephone", Dir: "friction-points", Command: "friction-points"},
+	{Identity: "oracle", Dir: "crossroads", Command: "crossroads"},
+	{Identity: "mouse", Dir: "harvest", Command: "data-harvest"},
+	{Identity: "librarian", Dir: "catalog", Command: "schema-catalog"},
+	{Identity: "lock", Dir: "compliance", Command: "spec-verify"},
+}
+
+// Write directory states reported by checkWritableDir
+const (
+	dirWritable   = "writable"
+	dirMissing    = "missing"
+	dirUnwritable = "unwritable"
+)
+
+// runDoctor implements the doctor command
+func runDoctor() error {
+	output.Success("🩺 Matrix Doctor")
+	fmt.Println("")
+	fmt.Printf("Version: matrix %s\n", version)
+	fmt.Println("")
+
+	ramDir, err := ram.DefaultRAMDir()
+	if err != nil {
+		return fmt.Errorf("failed to get RAM directory: %w", err)
+	}
+
+	problems := 0
+
+	output.Header("RAM directory:")
+	info, err := os.Stat(ramDir)
+	switch {
+	case os.IsNotExist(err):
+		fmt.Printf("  %s✗%s %s does not exist\n", output.Red, output.Reset, ramDir)
+		fmt.Printf("    Create it with: mkdir -p %s\n", ramDir)
+		fmt.Println("")
+		return fmt.Errorf("RAM directory not found")
+	case err != nil:
+		return fmt.Errorf("failed to access RAM directory: %w", err)
+	case !info.IsDir():
+		fmt.Printf("  %s✗%s %s is not a directory\n", output.Red, output.Reset, ramDir)
+		fmt.Printf("    Move the file aside and run: mkdir -p %s\n", ramDir)
+		fmt.Println("")
+		return fmt.Errorf("RAM directory is not a directory")
+	}
+	fmt.Printf("  %s✓%s %s\n", output.Green, output.Reset, ramDir)
+	fmt.Println("")
+
+	// Identities and file counts
+	files, err := ram.ScanDir(ramDir)
+	if err != nil {
+		return fmt.Errorf("failed to scan RAM directory: %w", err)
+	}
+	counts := countFilesByIdentity(ramDir, files)
+
+	output.Header("Identities:")
+	if len(counts) == 0 {
+		fmt.Printf("  %sNo identity directories yet - identities create them on first write%s\n", output.Dim, output.Reset)
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		note := ""
+		if !identity.IsValid(name) {
+			note = fmt.Sprintf(" %s(not a known identity)%s", output.Yellow, output.Reset)
+		}
+		fmt.Printf("  %-12s %d files%s\n", name, counts[name], note)
+	}
+	fmt.Println("")
+
+	// Write permissions for command output directories
+	output.Header("Command directories:")
+	for _, wd := range doctorWriteDirs {
+		path := filepath.Join(ramDir, wd.Identity, wd.Dir)
+		state, detail := checkWritableDir(path)
+		switch state {
+		case dirWritable:
+			fmt.Printf("  %s✓%s %-16s %s\n", output.Green, output.Reset, wd.Command, path)
+		case dirMissing:
+			fmt.Printf("  %s⚠%s %-16s %s (not created yet)\n", output.Yellow, output.Reset, wd.Command, path)
+			fmt.Printf("    %sCreated on first write, or now with: mkdir -p %s%s\n", output.Dim, path, output.Reset)
+		default:
+			problems++
+			fmt.Printf("  %s✗%s %-16s %s\n", output.Red, output.Reset, wd.Command, path)
+			fmt.Printf("    %s\n", detail)
+		}
+	}
+	fmt.Println("")
+
+	if problems > 0 {
+		return fmt.Errorf("%d problem(s) found", problems)
+	}
+
+	output.Success("✓ Environment looks healthy")
+	return nil
+}
+
+// countFilesByIdentity counts markdown files per identity directory,
+// including identity directories that contain no markdown yet
+func countFilesByIdentity(ramDir string, files []ram.File) map[string]int {
+	counts := make(map[string]int)
+
+	entries, err := os.ReadDir(ramDir)
+	if err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				counts[entry.Name()] = 0
+			}
+		}
+	}
+
+	for _, f := range files {
+		counts[f.Identity]++
+	}
+	return counts
+}
+
+// checkWritableDir reports whether path is a writable directory. A missing
+// directory is only a problem when the nearest existing parent can't be
+// written, since commands create their directories on first write.
+func checkWritableDir(path string) (string, string) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		parent := filepath.Dir(path)
+		for {
+			if _, err := os.Stat(parent); err == nil {
+				break
+			}
+			next := filepath.Dir(parent)
+			if next == parent {
+				break
+			}
+			parent = next
+		}
+		if err := probeWrite(parent); err != nil {
+			return dirUnwritable, fmt.Sprintf("cannot create it: %s is not writable (chmod u+w %s)", parent, parent)
+		}
+		return dirMissing, ""
+	}
+	if err != nil {
+		return dirUnwritable, fmt.Sprintf("cannot access: %v", err)
+	}
+	if !info.IsDir() {
+		return dirUnwritable, fmt.Sprintf("not a directory - move %s aside and run: mkdir -p %s", path, path)
+	}
+	if err := probeWrite(path); err != nil {
+		return dirUnwritable, fmt.Sprintf("not writable - run: chmod u+w %s", path)
+	}
+	return dirWritable, ""
+}
+
+// probeWrite creates and removes a temporary file to confirm dir is writable
+func probeWrite(dir string) error {
+	f, err := os.CreateTemp(dir, ".matrix-doctor-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckWritableDir(t *testing.T) {
+	dir := t.TempDir()
+
+	if state, _ := checkWritableDir(dir); state != dirWritable {
+		t.Errorf("Existing directory: expected %s, got %s", dirWritable, state)
+	}
+
+	missing := filepath.Join(dir, "deus", "verdicts")
+	if state, _ := checkWritableDir(missing); state != dirMissing {
+		t.Errorf("Missing directory under writable parent: expected %s, got %s", dirMissing, state)
+	}
+
+	file := filepath.Join(dir, "verdicts")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if state, detail := checkWritableDir(file); state != dirUnwritable || detail == "" {
+		t.Errorf("Regular file: expected %s with detail, got %s %q", dirUnwritable, state, detail)
+	}
+}
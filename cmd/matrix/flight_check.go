@@ -5,11 +5,15 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/coryzibell/matrix/internal/identity"
 	"github.com/coryzibell/matrix/internal/output"
 	"github.com/coryzibell/matrix/internal/ram"
 )
@@ -46,6 +50,32 @@ type FlightCheckReport struct {
 	Shipped  []DeploymentItem
 }
 
+// FlightCheckConfig holds options for rendering the flight check report
+type FlightCheckConfig struct {
+	ReadyOnly    bool
+	GroundedOnly bool
+	HistoryOnly  bool
+	OutputJSON   bool
+	SummaryOnly  bool
+	Owner        string // only items owned by this identity
+	ByOwner      bool   // group the report by owning identity
+}
+
+// FlightOwnerRollup is one owner's slice of the flight check report
+type FlightOwnerRollup struct {
+	Owner   string            `json:"owner"`
+	Summary FlightSummary     `json:"summary"`
+	Report  FlightCheckReport `json:"report"`
+}
+
+// FlightSummary holds the number of deployment items in each status
+type FlightSummary struct {
+	Ready    int `json:"ready"`
+	InFlight int `json:"in-flight"`
+	Grounded int `json:"grounded"`
+	Shipped  int `json:"shipped"`
+}
+
 // runFlightCheck implements the flight-check command
 func runFlightCheck() error {
 	// Parse flags
@@ -54,21 +84,52 @@ func runFlightCheck() error {
 	groundedFlag := fs.Bool("grounded", false, "Show only grounded items")
 	historyFlag := fs.Bool("history", false, "Show only shipped items")
 	jsonFlag := fs.Bool("json", false, "Output as JSON")
+	summaryFlag := fs.Bool("summary", false, "Print only per-status counts on one line")
+	watchFlag := fs.Bool("watch", false, "Redraw the report whenever RAM files change")
+	intervalFlag := fs.Duration("interval", 2*time.Second, "Polling interval for --watch")
+	ownerFlag := fs.String("owner", "", "Show only items owned by this identity")
+	byOwnerFlag := fs.Bool("by-owner", false, "Group the report by owning identity")
 
 	// Parse remaining args (after "flight-check")
 	if len(os.Args) > 2 {
 		fs.Parse(os.Args[2:])
 	}
 
+	config := FlightCheckConfig{
+		ReadyOnly:    *readyFlag,
+		GroundedOnly: *groundedFlag,
+		HistoryOnly:  *historyFlag,
+		OutputJSON:   *jsonFlag,
+		SummaryOnly:  *summaryFlag,
+		Owner:        strings.ToLower(strings.TrimSpace(*ownerFlag)),
+		ByOwner:      *byOwnerFlag,
+	}
+
+	if config.Owner != "" && !identity.IsValid(config.Owner) {
+		return fmt.Errorf("invalid identity: %s", *ownerFlag)
+	}
+
 	// Get RAM directory
 	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
 		return fmt.Errorf("failed to get RAM directory: %w", err)
 	}
 
+	if *watchFlag {
+		return watchFlightCheck(ramDir, config, *intervalFlag)
+	}
+
+	return renderFlightCheck(ramDir, config)
+}
+
+// renderFlightCheck scans RAM and prints the flight check report once
+func renderFlightCheck(ramDir string, config FlightCheckConfig) error {
 	// Check if garden exists
 	if _, err := os.Stat(ramDir); os.IsNotExist(err) {
-		if *jsonFlag {
+		if config.SummaryOnly {
+			return outputFlightSummary(FlightCheckReport{}, config.OutputJSON)
+		}
+		if config.OutputJSON {
 			emptyReport := FlightCheckReport{}
 			outputFlightJSON(emptyReport)
 			return nil
@@ -84,7 +145,10 @@ func runFlightCheck() error {
 	}
 
 	if len(files) == 0 {
-		if *jsonFlag {
+		if config.SummaryOnly {
+			return outputFlightSummary(FlightCheckReport{}, config.OutputJSON)
+		}
+		if config.OutputJSON {
 			emptyReport := FlightCheckReport{}
 			outputFlightJSON(emptyReport)
 			return nil
@@ -94,22 +158,20 @@ func runFlightCheck() error {
 	}
 
 	// Parse deployment items
-	items := parseDeploymentItems(files)
+	items := filterByOwner(parseDeploymentItems(files), config.Owner)
 
-	// Group by status
-	report := groupByStatus(items)
-
-	// Apply filters
-	if *readyFlag {
-		report = FlightCheckReport{Ready: report.Ready}
-	} else if *groundedFlag {
-		report = FlightCheckReport{Grounded: report.Grounded}
-	} else if *historyFlag {
-		report = FlightCheckReport{Shipped: report.Shipped}
+	if config.ByOwner {
+		return outputFlightRollup(rollupByOwner(items, config), config)
 	}
 
+	// Group by status
+	report := filterFlightReport(groupByStatus(items), config)
+
 	// Output
-	if *jsonFlag {
+	if config.SummaryOnly {
+		return outputFlightSummary(report, config.OutputJSON)
+	}
+	if config.OutputJSON {
 		outputFlightJSON(report)
 	} else {
 		displayFlightReport(report)
@@ -118,6 +180,63 @@ func runFlightCheck() error {
 	return nil
 }
 
+// watchFlightCheck redraws the report whenever the RAM directory changes.
+// Changes are detected by polling file mtimes and sizes; Ctrl-C exits cleanly.
+func watchFlightCheck(ramDir string, config FlightCheckConfig, interval time.Duration) error {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastFingerprint := ""
+	for {
+		fingerprint := ramFingerprint(ramDir)
+		if fingerprint != lastFingerprint {
+			lastFingerprint = fingerprint
+
+			// Clear screen and move cursor home
+			fmt.Print("\033[H\033[2J")
+			if err := renderFlightCheck(ramDir, config); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			fmt.Println("")
+			fmt.Printf("Last updated: %s (watching every %s, Ctrl-C to exit)\n",
+				time.Now().Format("2006-01-02 15:04:05"), interval)
+		}
+
+		select {
+		case <-interrupt:
+			fmt.Println("")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ramFingerprint summarizes the path, size, and mtime of every markdown file
+// under ramDir so changes can be detected without re-parsing content
+func ramFingerprint(ramDir string) string {
+	var b strings.Builder
+	filepath.WalkDir(ramDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintf(&b, "%s|%d|%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return b.String()
+}
+
 // parseDeploymentItems scans files for deployment artifacts
 func parseDeploymentItems(files []ram.File) []DeploymentItem {
 	var items []DeploymentItem
@@ -134,7 +253,71 @@ func parseDeploymentItems(files []ram.File) []DeploymentItem {
 		}
 	}
 
-	return items
+	return dedupeDeploymentItems(items)
+}
+
+// dedupeDeploymentItems collapses items describing the same project
+// (case-insensitive name) into one, keeping first-seen order
+func dedupeDeploymentItems(items []DeploymentItem) []DeploymentItem {
+	var order []string
+	byName := make(map[string]DeploymentItem)
+
+	for _, item := range items {
+		key := strings.ToLower(item.Name)
+		existing, seen := byName[key]
+		if !seen {
+			order = append(order, key)
+			byName[key] = item
+			continue
+		}
+		byName[key] = mergeDeploymentItems(existing, item)
+	}
+
+	deduped := make([]DeploymentItem, 0, len(order))
+	for _, key := range order {
+		deduped = append(deduped, byName[key])
+	}
+	return deduped
+}
+
+// mergeDeploymentItems combines two views of the same project. The most
+// recently built item supplies owner, file, and test/CI status (falling back
+// to the other for unknowns); dates take the latest value, blockers are
+// unioned, and status is recomputed so a shipped signal outranks a grounded one.
+func mergeDeploymentItems(a, b DeploymentItem) DeploymentItem {
+	primary, other := a, b
+	if b.BuiltDate.After(a.BuiltDate) {
+		primary, other = b, a
+	}
+
+	merged := primary
+	if merged.TestStatus == "n/a" {
+		merged.TestStatus = other.TestStatus
+	}
+	if merged.CIStatus == "n/a" {
+		merged.CIStatus = other.CIStatus
+	}
+	if other.ShippedDate.After(merged.ShippedDate) {
+		merged.ShippedDate = other.ShippedDate
+	}
+	merged.Blocker = joinUnique(a.Blocker, b.Blocker)
+	merged.NeedsWho = joinUnique(a.NeedsWho, b.NeedsWho)
+
+	merged.Status = determineStatus(merged)
+	return merged
+}
+
+// joinUnique joins the distinct non-empty "; "-separated parts of a and b
+func joinUnique(a, b string) string {
+	var parts []string
+	for _, s := range []string{a, b} {
+		for _, part := range strings.Split(s, "; ") {
+			if part != "" && !contains(parts, part) {
+				parts = append(parts, part)
+			}
+		}
+	}
+	return strings.Join(parts, "; ")
 }
 
 // isDeploymentFile checks if a file is a deployment artifact
@@ -495,6 +678,131 @@ func groupByStatus(items []DeploymentItem) FlightCheckReport {
 	return report
 }
 
+// filterFlightReport keeps only the status group selected by --ready,
+// --grounded, or --history, if any
+func filterFlightReport(report FlightCheckReport, config FlightCheckConfig) FlightCheckReport {
+	switch {
+	case config.ReadyOnly:
+		return FlightCheckReport{Ready: report.Ready}
+	case config.GroundedOnly:
+		return FlightCheckReport{Grounded: report.Grounded}
+	case config.HistoryOnly:
+		return FlightCheckReport{Shipped: report.Shipped}
+	}
+	return report
+}
+
+// filterByOwner keeps the items owned by owner; an empty owner keeps all
+func filterByOwner(items []DeploymentItem, owner string) []DeploymentItem {
+	if owner == "" {
+		return items
+	}
+	var owned []DeploymentItem
+	for _, item := range items {
+		if strings.EqualFold(strings.TrimSpace(item.Identity), owner) {
+			owned = append(owned, item)
+		}
+	}
+	return owned
+}
+
+// rollupByOwner groups items by owning identity, each with its own status
+// report and counts. Owners are sorted by name with unowned items last.
+func rollupByOwner(items []DeploymentItem, config FlightCheckConfig) []FlightOwnerRollup {
+	byOwner := make(map[string][]DeploymentItem)
+	for _, item := range items {
+		owner := strings.ToLower(strings.TrimSpace(item.Identity))
+		byOwner[owner] = append(byOwner[owner], item)
+	}
+
+	owners := make([]string, 0, len(byOwner))
+	for owner := range byOwner {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if (owners[i] == "") != (owners[j] == "") {
+			return owners[j] == ""
+		}
+		return owners[i] < owners[j]
+	})
+
+	rollups := []FlightOwnerRollup{}
+	for _, owner := range owners {
+		report := filterFlightReport(groupByStatus(byOwner[owner]), config)
+		summary := summarizeFlight(report)
+		if summary == (FlightSummary{}) {
+			continue
+		}
+		rollups = append(rollups, FlightOwnerRollup{Owner: owner, Summary: summary, Report: report})
+	}
+	return rollups
+}
+
+// outputFlightRollup prints the per-owner report, its counts only with
+// --summary, or either as JSON
+func outputFlightRollup(rollups []FlightOwnerRollup, config FlightCheckConfig) error {
+	if config.OutputJSON {
+		if config.SummaryOnly {
+			summaries := make(map[string]FlightSummary)
+			for _, rollup := range rollups {
+				summaries[flightOwnerLabel(rollup.Owner)] = rollup.Summary
+			}
+			return output.EmitJSON(summaries)
+		}
+		return output.EmitJSON(rollups)
+	}
+
+	if config.SummaryOnly {
+		for _, rollup := range rollups {
+			fmt.Printf("%s %s\n", flightOwnerLabel(rollup.Owner), formatFlightSummary(rollup.Summary))
+		}
+		return nil
+	}
+
+	output.Success("🚀 Flight Check by Owner - " + time.Now().Format("2006-01-02 15:04:05"))
+	fmt.Println("")
+
+	if len(rollups) == 0 {
+		fmt.Println("No deployments tracked yet.")
+		return nil
+	}
+
+	for _, rollup := range rollups {
+		fmt.Println(strings.Repeat("━", 70))
+		output.Header(fmt.Sprintf("  %s", flightOwnerLabel(rollup.Owner)))
+		fmt.Printf("  %s\n", formatFlightSummary(rollup.Summary))
+		fmt.Println(strings.Repeat("━", 70))
+		fmt.Println("")
+
+		for _, item := range rollup.Report.Ready {
+			fmt.Printf("  ✓ %s %s(ready)%s\n", output.Green+item.Name+output.Reset, output.Dim, output.Reset)
+		}
+		for _, item := range rollup.Report.InFlight {
+			fmt.Printf("  ⟳ %s %s(in flight)%s\n", output.Yellow+item.Name+output.Reset, output.Dim, output.Reset)
+		}
+		for _, item := range rollup.Report.Grounded {
+			fmt.Printf("  ✗ %s %s(grounded)%s\n", item.Name, output.Dim, output.Reset)
+			if item.Blocker != "" {
+				fmt.Printf("    Blocker: %s\n", item.Blocker)
+			}
+		}
+		for _, item := range rollup.Report.Shipped {
+			fmt.Printf("  %s✓ %s (shipped)%s\n", output.Dim, item.Name, output.Reset)
+		}
+		fmt.Println("")
+	}
+
+	return nil
+}
+
+// flightOwnerLabel names an owner for display
+func flightOwnerLabel(owner string) string {
+	if owner == "" {
+		return "(unowned)"
+	}
+	return owner
+}
+
 // displayFlightReport outputs the flight check report to stdout
 func displayFlightReport(report FlightCheckReport) {
 	output.Success("🚀 Flight Check - " + time.Now().Format("2006-01-02 15:04:05"))
@@ -641,6 +949,32 @@ func outputFlightJSON(report FlightCheckReport) {
 	encoder.Encode(report)
 }
 
+// summarizeFlight counts the items in each status group
+func summarizeFlight(report FlightCheckReport) FlightSummary {
+	return FlightSummary{
+		Ready:    len(report.Ready),
+		InFlight: len(report.InFlight),
+		Grounded: len(report.Grounded),
+		Shipped:  len(report.Shipped),
+	}
+}
+
+// formatFlightSummary renders counts as a single status line
+func formatFlightSummary(summary FlightSummary) string {
+	return fmt.Sprintf("ready:%d in-flight:%d grounded:%d shipped:%d",
+		summary.Ready, summary.InFlight, summary.Grounded, summary.Shipped)
+}
+
+// outputFlightSummary prints the per-status counts as a line or JSON object
+func outputFlightSummary(report FlightCheckReport, asJSON bool) error {
+	summary := summarizeFlight(report)
+	if asJSON {
+		return output.EmitJSON(summary)
+	}
+	fmt.Println(formatFlightSummary(summary))
+	return nil
+}
+
 // formatDate formats a date for display
 func formatDate(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coryzibell/matrix/internal/ram"
+)
+
+func TestParseDeploymentItemsDedupesProjects(t *testing.T) {
+	files := []ram.File{
+		{
+			Identity: "niobe",
+			Path:     "/ram/niobe/checkout-deployment.md",
+			Name:     "checkout-deployment",
+			Content: `# Checkout deploy
+Built: 2024-03-01
+Tests: failing
+Blocker: flaky payment sandbox
+Needs: trinity
+`,
+		},
+		{
+			Identity: "smith",
+			Path:     "/ram/smith/checkout-ship.md",
+			Name:     "checkout-ship",
+			Content: `# Checkout ship checklist
+Built: 2024-03-04
+Tests passing
+CI: passing
+Shipped: 2024-03-05
+`,
+		},
+		{
+			Identity: "niobe",
+			Path:     "/ram/niobe/search-deploy.md",
+			Name:     "search-deploy",
+			Content:  "Built: 2024-03-02\nCI: pending\n",
+		},
+	}
+
+	items := parseDeploymentItems(files)
+
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 deployment items, got %d: %+v", len(items), items)
+	}
+
+	checkout := items[0]
+	if checkout.Name != "checkout" {
+		t.Fatalf("Expected checkout first, got %q", checkout.Name)
+	}
+	if checkout.Status != StatusShipped {
+		t.Errorf("Expected shipped to win over grounded, got %s", checkout.Status)
+	}
+	if got := checkout.BuiltDate.Format("2006-01-02"); got != "2024-03-04" {
+		t.Errorf("Expected most recent build date, got %s", got)
+	}
+	if checkout.Identity != "smith" || checkout.TestStatus != "passing" {
+		t.Errorf("Expected details from the most recent build, got identity=%s tests=%s", checkout.Identity, checkout.TestStatus)
+	}
+	if checkout.Blocker != "flaky payment sandbox" || checkout.NeedsWho != "trinity" {
+		t.Errorf("Expected blockers carried over, got blocker=%q needs=%q", checkout.Blocker, checkout.NeedsWho)
+	}
+
+	if items[1].Name != "search" || items[1].Status != StatusInFlight {
+		t.Errorf("Unexpected second item: %+v", items[1])
+	}
+}
+
+func TestMergeDeploymentItemsUnionsBlockers(t *testing.T) {
+	a := DeploymentItem{Name: "api", TestStatus: "n/a", CIStatus: "n/a", Blocker: "waiting on DNS"}
+	b := DeploymentItem{Name: "API", TestStatus: "passing", CIStatus: "passing", Blocker: "security review; waiting on DNS"}
+
+	merged := mergeDeploymentItems(a, b)
+
+	if merged.Blocker != "waiting on DNS; security review" {
+		t.Errorf("Unexpected merged blocker: %q", merged.Blocker)
+	}
+	if merged.Status != StatusGrounded {
+		t.Errorf("Expected grounded with open blockers, got %s", merged.Status)
+	}
+}
+
+func TestRollupByOwner(t *testing.T) {
+	items := []DeploymentItem{
+		{Name: "web", Identity: "smith", Status: StatusGrounded},
+		{Name: "api", Identity: "Niobe", Status: StatusReady},
+		{Name: "docs", Identity: "", Status: StatusShipped},
+		{Name: "cli", Identity: "niobe", Status: StatusShipped},
+		{Name: "auth", Identity: "niobe", Status: StatusReady},
+	}
+
+	owned := filterByOwner(items, "niobe")
+	if len(owned) != 3 {
+		t.Errorf("Expected 3 items owned by niobe, got %+v", owned)
+	}
+	if all := filterByOwner(items, ""); len(all) != len(items) {
+		t.Errorf("An empty owner should keep every item, got %d", len(all))
+	}
+
+	rollups := rollupByOwner(items, FlightCheckConfig{})
+	var owners []string
+	for _, rollup := range rollups {
+		owners = append(owners, rollup.Owner)
+	}
+	if want := []string{"niobe", "smith", ""}; strings.Join(owners, ",") != strings.Join(want, ",") {
+		t.Fatalf("owners = %q, want %q (unowned last)", owners, want)
+	}
+
+	niobe := rollups[0]
+	if niobe.Summary != (FlightSummary{Ready: 2, Shipped: 1}) {
+		t.Errorf("Unexpected niobe summary: %+v", niobe.Summary)
+	}
+	if niobe.Report.Ready[0].Name != "api" || niobe.Report.Ready[1].Name != "auth" {
+		t.Errorf("Expected groupByStatus ordering within an owner, got %+v", niobe.Report.Ready)
+	}
+
+	// Status filters apply per owner and drop owners left with nothing
+	grounded := rollupByOwner(items, FlightCheckConfig{GroundedOnly: true})
+	if len(grounded) != 1 || grounded[0].Owner != "smith" {
+		t.Errorf("Expected only smith with --grounded, got %+v", grounded)
+	}
+}
+
+func TestRAMFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"niobe/checkout-deploy.md": "Built: 2024-03-01\n",
+		"niobe/notes.txt":          "ignored",
+	})
+	before := ramFingerprint(dir)
+	if before == "" || strings.Contains(before, "notes.txt") {
+		t.Fatalf("Expected only markdown files in the fingerprint:\n%s", before)
+	}
+
+	// Non-markdown edits don't count as changes
+	writeFixture(t, dir, map[string]string{"niobe/notes.txt": "still ignored"})
+	if got := ramFingerprint(dir); got != before {
+		t.Errorf("Fingerprint changed for a non-markdown file:\n%s", got)
+	}
+
+	// A touched file changes it even when its size stays the same
+	path := filepath.Join(dir, "niobe", "checkout-deploy.md")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	touched := ramFingerprint(dir)
+	if touched == before {
+		t.Error("Expected a new mtime to change the fingerprint")
+	}
+
+	writeFixture(t, dir, map[string]string{"smith/search-ship.md": "Shipped: 2024-03-05\n"})
+	if ramFingerprint(dir) == touched {
+		t.Error("Expected a new file to change the fingerprint")
+	}
+}
+
+func TestFlightCheckWatchRedraws(t *testing.T) {
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ramDir := t.TempDir()
+	writeFixture(t, ramDir, map[string]string{
+		"niobe/checkout-deployment.md": "Built: 2024-03-01\nCI: pending\n",
+	})
+
+	cmd := exec.Command(self, "--ram-dir", ramDir, "flight-check", "--watch", "--interval", "20ms")
+	cmd.Env = append(os.Environ(), matrixTestExecEnv+"=1", "HOME="+t.TempDir())
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill()
+
+	// Each redraw ends with a "Last updated" line; collect the frames
+	frames := make(chan string)
+	go func() {
+		var frame strings.Builder
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			frame.WriteString(scanner.Text() + "\n")
+			if strings.HasPrefix(scanner.Text(), "Last updated:") {
+				frames <- frame.String()
+				frame.Reset()
+			}
+		}
+		close(frames)
+	}()
+	nextFrame := func() string {
+		t.Helper()
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				t.Fatal("flight-check --watch exited early")
+			}
+			return frame
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for a redraw")
+		}
+		return ""
+	}
+
+	if first := nextFrame(); !strings.Contains(first, "checkout") || strings.Contains(first, "search") {
+		t.Errorf("Unexpected first frame:\n%s", first)
+	}
+
+	writeFixture(t, ramDir, map[string]string{
+		"niobe/search-deploy.md": "Built: 2024-03-02\nCI: pending\n",
+	})
+	if second := nextFrame(); !strings.Contains(second, "search") {
+		t.Errorf("Expected the redraw to include the new item:\n%s", second)
+	}
+
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	for range frames {
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("Expected a clean exit on interrupt, got %v", err)
+	}
+}
@@ -1,16 +1,19 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/coryzibell/matrix/internal/identity"
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // FrictionPoint represents a UX review item
@@ -27,6 +30,7 @@ type FrictionPoint struct {
 	Approved     bool      `json:"approved"`
 	ApprovalNote string    `json:"approval_note,omitempty"`
 	QueuedDate   string    `json:"queued_date"`
+	DueDate      string    `json:"due_date,omitempty"`
 }
 
 // FrictionData represents the storage file structure
@@ -58,6 +62,8 @@ func runFrictionPoints() error {
 		return approveFrictionPoint()
 	case "status":
 		return showFrictionStatus()
+	case "import":
+		return importFrictionPointsFile()
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown friction-points subcommand: %s\n", subcommand)
 		printFrictionPointsUsage()
@@ -71,22 +77,29 @@ func printFrictionPointsUsage() {
 	fmt.Println("friction-points - Track UX review queue and feedback")
 	fmt.Println("")
 	fmt.Println("Usage:")
-	fmt.Println("  matrix friction-points queue \"name\" --type=X --owner=Y --priority=low|medium|high")
-	fmt.Println("  matrix friction-points list")
+	fmt.Println("  matrix friction-points queue \"name\" --type=X --owner=Y --priority=low|medium|high [--due=YYYY-MM-DD]")
+	fmt.Println("  matrix friction-points list [--overdue]")
 	fmt.Println("  matrix friction-points review \"name\" --status=needs-changes|approved --feedback=\"text\"")
 	fmt.Println("  matrix friction-points tag \"name\" <tag>")
-	fmt.Println("  matrix friction-points patterns")
+	fmt.Println("  matrix friction-points patterns [--cooccurrence [--min=N]]")
 	fmt.Println("  matrix friction-points approve \"name\" --note=\"text\"")
 	fmt.Println("  matrix friction-points status \"name\"")
+	fmt.Println("  matrix friction-points import <file>")
 	fmt.Println("")
 	fmt.Println("Subcommands:")
 	fmt.Println("  queue     Add item to UX review queue")
 	fmt.Println("  list      Show review queue")
 	fmt.Println("  review    Mark item as reviewed with feedback")
 	fmt.Println("  tag       Add friction pattern tag to item")
-	fmt.Println("  patterns  Show common friction patterns")
+	fmt.Println("  patterns  Show common friction patterns, or with --cooccurrence the tag")
+	fmt.Println("            pairs that most often land on the same items")
 	fmt.Println("  approve   Approve item for shipping")
 	fmt.Println("  status    Check item review status")
+	fmt.Println("  import    Queue items in bulk from a markdown table or CSV file")
+	fmt.Println("")
+	fmt.Println("Import file rows are: name, type, owner, priority[, due]")
+	fmt.Println("  | Login errors | error-handling | persephone | high |")
+	fmt.Println("  \"Install guide\", documentation, morpheus, low, 2024-06-01")
 }
 
 func queueFrictionPoint() error {
@@ -97,7 +110,7 @@ func queueFrictionPoint() error {
 	name := os.Args[3]
 
 	// Parse flags
-	var itemType, owner, priority string
+	var itemType, owner, priority, dueDate string
 
 	for i := 4; i < len(os.Args); i++ {
 		arg := os.Args[i]
@@ -108,31 +121,19 @@ func queueFrictionPoint() error {
 			owner = strings.TrimPrefix(arg, "--owner=")
 		} else if strings.HasPrefix(arg, "--priority=") {
 			priority = strings.TrimPrefix(arg, "--priority=")
+		} else if strings.HasPrefix(arg, "--due=") {
+			dueDate = strings.TrimPrefix(arg, "--due=")
+		} else if arg == "--due" && i+1 < len(os.Args) {
+			i++
+			dueDate = os.Args[i]
 		}
 	}
 
-	// Validate required fields
-	if itemType == "" {
-		return fmt.Errorf("--type is required (e.g., cli-output, error-handling, documentation)")
-	}
-
-	if owner == "" {
-		return fmt.Errorf("--owner is required (identity name)")
-	}
-
-	// Validate priority
 	if priority == "" {
 		priority = "medium"
 	}
-
-	validPriorities := map[string]bool{"low": true, "medium": true, "high": true}
-	if !validPriorities[priority] {
-		return fmt.Errorf("invalid priority: %s (valid: low, medium, high)", priority)
-	}
-
-	// Validate owner is a valid identity
-	if !identity.IsValid(owner) {
-		return fmt.Errorf("invalid identity: %s", owner)
+	if err := validateFrictionFields(itemType, owner, priority, dueDate); err != nil {
+		return err
 	}
 
 	// Load existing data
@@ -158,6 +159,7 @@ func queueFrictionPoint() error {
 		Resolved:   false,
 		Approved:   false,
 		QueuedDate: time.Now().Format("2006-01-02"),
+		DueDate:    dueDate,
 	}
 
 	// Add to data
@@ -175,12 +177,23 @@ func queueFrictionPoint() error {
 	fmt.Printf("Type: %s\n", itemType)
 	fmt.Printf("Owner: %s\n", owner)
 	fmt.Printf("Priority: %s\n", priority)
+	if dueDate != "" {
+		fmt.Printf("Due: %s\n", dueDate)
+	}
 	fmt.Printf("Status: waiting\n")
 
 	return nil
 }
 
 func listFrictionPoints() error {
+	// Parse flags
+	overdueOnly := false
+	for _, arg := range os.Args[3:] {
+		if arg == "--overdue" {
+			overdueOnly = true
+		}
+	}
+
 	data, err := loadFrictionData()
 	if err != nil {
 		return fmt.Errorf("failed to load friction data: %w", err)
@@ -193,6 +206,19 @@ func listFrictionPoints() error {
 		return nil
 	}
 
+	overdue := findOverdue(data.Entries, time.Now())
+
+	if overdueOnly {
+		output.Success("Overdue UX Reviews")
+		fmt.Println("")
+		if len(overdue) == 0 {
+			fmt.Println("No overdue reviews.")
+			return nil
+		}
+		displayOverdue(overdue)
+		return nil
+	}
+
 	// Organize by status
 	var waiting, inProgress, needsChanges, approved []FrictionPoint
 
@@ -226,6 +252,11 @@ func listFrictionPoints() error {
 	output.Success("UX Review Queue")
 	fmt.Println("")
 
+	// Overdue section
+	if len(overdue) > 0 {
+		displayOverdue(overdue)
+	}
+
 	// Waiting Review section
 	if len(waiting) > 0 {
 		output.Header(fmt.Sprintf("Waiting Review: %d items", len(waiting)))
@@ -258,7 +289,7 @@ func listFrictionPoints() error {
 		fmt.Println("")
 		for _, entry := range needsChanges {
 			priorityColor := getPriorityColor(entry.Priority)
-			feedbackSnippet := truncate(entry.Feedback, 60)
+			feedbackSnippet := util.Truncate(entry.Feedback, 60)
 			fmt.Printf("  [%s%s%s] %s - %s\n",
 				priorityColor, entry.Priority, output.Reset,
 				entry.Name, feedbackSnippet)
@@ -437,6 +468,29 @@ func tagFrictionPoint() error {
 }
 
 func showFrictionPatterns() error {
+	cooccurrence := false
+	minTogether := frictionDefaultMinTogether
+	for i := 3; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		value := ""
+		if arg == "--cooccurrence" {
+			cooccurrence = true
+			continue
+		} else if strings.HasPrefix(arg, "--min=") {
+			value = strings.TrimPrefix(arg, "--min=")
+		} else if arg == "--min" && i+1 < len(os.Args) {
+			i++
+			value = os.Args[i]
+		} else {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid --min: %s (must be a positive number)", value)
+		}
+		minTogether = n
+	}
+
 	data, err := loadFrictionData()
 	if err != nil {
 		return fmt.Errorf("failed to load friction data: %w", err)
@@ -457,6 +511,11 @@ func showFrictionPatterns() error {
 		return nil
 	}
 
+	if cooccurrence {
+		displayPatternPairs(findPatternPairs(data.Entries, minTogether), minTogether)
+		return nil
+	}
+
 	// Sort by count
 	type patternCount struct {
 		pattern string
@@ -580,6 +639,14 @@ func showFrictionStatus() error {
 	fmt.Printf("Status: %s\n", entry.Status)
 	fmt.Printf("Queued: %s\n", entry.QueuedDate)
 
+	if entry.DueDate != "" {
+		if days := daysOverdue(*entry, time.Now()); days > 0 {
+			fmt.Printf("Due: %s (%s%d days overdue%s)\n", entry.DueDate, output.Red, days, output.Reset)
+		} else {
+			fmt.Printf("Due: %s\n", entry.DueDate)
+		}
+	}
+
 	if entry.ReviewedDate != "" {
 		fmt.Printf("Reviewed: %s\n", entry.ReviewedDate)
 	}
@@ -602,8 +669,211 @@ func showFrictionStatus() error {
 	return nil
 }
 
+// frictionImportRow is one item parsed from an import file
+type frictionImportRow struct {
+	Line     int
+	Name     string
+	Type     string
+	Owner    string
+	Priority string
+	DueDate  string
+}
+
+// frictionImportSkip records why an import row was not queued
+type frictionImportSkip struct {
+	Line   int
+	Name   string
+	Reason string
+}
+
+// importFrictionPointsFile implements friction-points import
+func importFrictionPointsFile() error {
+	if len(os.Args) < 4 {
+		return fmt.Errorf("import requires a file argument")
+	}
+
+	path := util.ExpandPath(os.Args[3])
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	rows, err := parseFrictionImport(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no items found in %s (rows are: name, type, owner, priority)", path)
+	}
+
+	data, err := loadFrictionData()
+	if err != nil {
+		return fmt.Errorf("failed to load friction data: %w", err)
+	}
+
+	added, skipped := importFrictionRows(data, rows, time.Now().Format("2006-01-02"))
+
+	if len(added) > 0 {
+		if err := saveFrictionData(data); err != nil {
+			return fmt.Errorf("failed to save friction data: %w", err)
+		}
+	}
+
+	output.Success("UX review queue import")
+	fmt.Println("")
+	for _, fp := range added {
+		fmt.Printf("  + %s (%s, %s, %s)\n", fp.Name, fp.Type, fp.Owner, getPriorityColor(fp.Priority)+fp.Priority+output.Reset)
+	}
+	for _, skip := range skipped {
+		fmt.Printf("  %s- line %d: %s: %s%s\n", output.Dim, skip.Line, skip.Name, skip.Reason, output.Reset)
+	}
+	if len(added)+len(skipped) > 0 {
+		fmt.Println("")
+	}
+	fmt.Printf("Added: %d, Skipped: %d\n", len(added), len(skipped))
+
+	return nil
+}
+
+// parseFrictionImport reads markdown table rows ("| name | type | owner |
+// priority |") and CSV lines ("name, type, owner, priority"), optionally
+// bulleted. Headings, blank lines, table separators, and a header row
+// starting with "name" are ignored.
+func parseFrictionImport(content string) ([]frictionImportRow, error) {
+	var rows []frictionImportRow
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		var fields []string
+		if strings.HasPrefix(trimmed, "|") {
+			trimmed = strings.Trim(trimmed, "|")
+			if strings.Trim(trimmed, "|-: ") == "" {
+				continue // table separator
+			}
+			fields = strings.Split(trimmed, "|")
+		} else {
+			trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "- "), "* ")
+			reader := csv.NewReader(strings.NewReader(trimmed))
+			reader.TrimLeadingSpace = true
+			record, err := reader.Read()
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			fields = record
+		}
+
+		for j := range fields {
+			fields[j] = strings.TrimSpace(fields[j])
+		}
+		if strings.EqualFold(fields[0], "name") {
+			continue // header row
+		}
+
+		row := frictionImportRow{Line: i + 1, Name: fields[0]}
+		if len(fields) > 1 {
+			row.Type = fields[1]
+		}
+		if len(fields) > 2 {
+			row.Owner = strings.ToLower(fields[2])
+		}
+		if len(fields) > 3 {
+			row.Priority = strings.ToLower(fields[3])
+		}
+		if len(fields) > 4 {
+			row.DueDate = fields[4]
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// importFrictionRows queues each valid row into data, skipping rows that
+// fail validation or duplicate an existing (or earlier imported) name
+func importFrictionRows(data *FrictionData, rows []frictionImportRow, today string) ([]FrictionPoint, []frictionImportSkip) {
+	existing := make(map[string]bool)
+	for _, entry := range data.Entries {
+		existing[entry.Name] = true
+	}
+
+	var added []FrictionPoint
+	var skipped []frictionImportSkip
+	for _, row := range rows {
+		if row.Name == "" {
+			skipped = append(skipped, frictionImportSkip{Line: row.Line, Name: "(unnamed)", Reason: "missing name"})
+			continue
+		}
+		if existing[row.Name] {
+			skipped = append(skipped, frictionImportSkip{Line: row.Line, Name: row.Name, Reason: "already queued"})
+			continue
+		}
+
+		if row.Type == "" || row.Owner == "" {
+			skipped = append(skipped, frictionImportSkip{Line: row.Line, Name: row.Name, Reason: "missing type or owner column"})
+			continue
+		}
+
+		priority := row.Priority
+		if priority == "" {
+			priority = "medium"
+		}
+		if err := validateFrictionFields(row.Type, row.Owner, priority, row.DueDate); err != nil {
+			skipped = append(skipped, frictionImportSkip{Line: row.Line, Name: row.Name, Reason: err.Error()})
+			continue
+		}
+
+		fp := FrictionPoint{
+			Name:       row.Name,
+			Type:       row.Type,
+			Owner:      row.Owner,
+			Priority:   priority,
+			Status:     "waiting",
+			QueuedDate: today,
+			DueDate:    row.DueDate,
+		}
+		data.Entries = append(data.Entries, fp)
+		existing[row.Name] = true
+		added = append(added, fp)
+	}
+
+	return added, skipped
+}
+
 // Helper functions
 
+// validateFrictionFields checks the fields shared by queue and import
+func validateFrictionFields(itemType, owner, priority, dueDate string) error {
+	if itemType == "" {
+		return fmt.Errorf("--type is required (e.g., cli-output, error-handling, documentation)")
+	}
+
+	if owner == "" {
+		return fmt.Errorf("--owner is required (identity name)")
+	}
+
+	validPriorities := map[string]bool{"low": true, "medium": true, "high": true}
+	if !validPriorities[priority] {
+		return fmt.Errorf("invalid priority: %s (valid: low, medium, high)", priority)
+	}
+
+	// Owner must be a valid identity
+	if !identity.IsValid(owner) {
+		return fmt.Errorf("invalid identity: %s", owner)
+	}
+
+	if dueDate != "" {
+		if _, err := time.Parse("2006-01-02", dueDate); err != nil {
+			return fmt.Errorf("invalid due date: %s (expected YYYY-MM-DD)", dueDate)
+		}
+	}
+
+	return nil
+}
+
 func loadFrictionData() (*FrictionData, error) {
 	// Get persephone RAM path
 	persephonePath, err := identity.RAMPath("persephone")
@@ -684,11 +954,63 @@ func getPriorityColor(priority string) string {
 	}
 }
 
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// overduePoint pairs an unreviewed friction point with how late it is
+type overduePoint struct {
+	Entry FrictionPoint
+	Days  int
+}
+
+// daysOverdue returns how many days past its due date an unreviewed item is,
+// or 0 if it has no due date, isn't late, or has already been reviewed
+func daysOverdue(entry FrictionPoint, now time.Time) int {
+	if entry.DueDate == "" || entry.ReviewedDate != "" || entry.Approved {
+		return 0
 	}
-	return s[:maxLen-3] + "..."
+
+	due, err := time.Parse("2006-01-02", entry.DueDate)
+	if err != nil {
+		return 0
+	}
+
+	today, _ := time.Parse("2006-01-02", now.Format("2006-01-02"))
+	days := int(today.Sub(due).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// findOverdue returns unreviewed items past due, most overdue first
+func findOverdue(entries []FrictionPoint, now time.Time) []overduePoint {
+	var overdue []overduePoint
+	for _, entry := range entries {
+		if days := daysOverdue(entry, now); days > 0 {
+			overdue = append(overdue, overduePoint{Entry: entry, Days: days})
+		}
+	}
+
+	sort.Slice(overdue, func(i, j int) bool {
+		if overdue[i].Days != overdue[j].Days {
+			return overdue[i].Days > overdue[j].Days
+		}
+		priorityOrder := map[string]int{"high": 0, "medium": 1, "low": 2}
+		return priorityOrder[overdue[i].Entry.Priority] < priorityOrder[overdue[j].Entry.Priority]
+	})
+
+	return overdue
+}
+
+func displayOverdue(overdue []overduePoint) {
+	output.Header(fmt.Sprintf("Overdue: %d items", len(overdue)))
+	fmt.Println("")
+	for _, item := range overdue {
+		priorityColor := getPriorityColor(item.Entry.Priority)
+		fmt.Printf("  %s%d days overdue%s [%s%s%s] %s (due %s, owner: %s)\n",
+			output.Red, item.Days, output.Reset,
+			priorityColor, item.Entry.Priority, output.Reset,
+			item.Entry.Name, item.Entry.DueDate, item.Entry.Owner)
+	}
+	fmt.Println("")
 }
 
 func countPatterns(entries []FrictionPoint) map[string]int {
@@ -700,3 +1022,79 @@ func countPatterns(entries []FrictionPoint) map[string]int {
 	}
 	return counts
 }
+
+// frictionDefaultMinTogether is how many items a tag pair must share before
+// it's reported, so one-off coincidences don't crowd the ranking
+const frictionDefaultMinTogether = 2
+
+// PatternPair is two friction tags applied to the same items
+type PatternPair struct {
+	A        string
+	B        string
+	Together int     // items tagged with both
+	Either   int     // items tagged with at least one
+	Overlap  float64 // Together / Either, 0-1
+}
+
+// findPatternPairs counts every pair of tags that share at least
+// minTogether items, strongest association (overlap, then shared items)
+// first. Repeated tags on one item count once.
+func findPatternPairs(entries []FrictionPoint, minTogether int) []PatternPair {
+	tagCounts := make(map[string]int)
+	pairCounts := make(map[[2]string]int)
+	for _, entry := range entries {
+		tags := unique(entry.Tags)
+		sort.Strings(tags)
+		for i, a := range tags {
+			tagCounts[a]++
+			for _, b := range tags[i+1:] {
+				pairCounts[[2]string{a, b}]++
+			}
+		}
+	}
+
+	var pairs []PatternPair
+	for key, together := range pairCounts {
+		if together < minTogether {
+			continue
+		}
+		either := tagCounts[key[0]] + tagCounts[key[1]] - together
+		pairs = append(pairs, PatternPair{
+			A:        key[0],
+			B:        key[1],
+			Together: together,
+			Either:   either,
+			Overlap:  float64(together) / float64(either),
+		})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Overlap != pairs[j].Overlap {
+			return pairs[i].Overlap > pairs[j].Overlap
+		}
+		if pairs[i].Together != pairs[j].Together {
+			return pairs[i].Together > pairs[j].Together
+		}
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
+
+// displayPatternPairs prints the co-occurrence ranking
+func displayPatternPairs(pairs []PatternPair, minTogether int) {
+	output.Success("Friction Pattern Co-occurrence")
+	fmt.Println("")
+
+	if len(pairs) == 0 {
+		fmt.Printf("No tag pairs appear together on %d or more items.\n", minTogether)
+		return
+	}
+
+	for _, p := range pairs {
+		fmt.Printf("  %s + %s: %d items together (%.0f%% of the %d tagged with either)\n",
+			p.A, p.B, p.Together, p.Overlap*100, p.Either)
+	}
+}
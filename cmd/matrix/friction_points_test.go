@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFrictionImport(t *testing.T) {
+	content := `# UX backlog
+
+| Name | Type | Owner | Priority |
+|------|------|-------|----------|
+| Login errors | error-handling | Persephone | high |
+| Help text | cli-output | morpheus | |
+
+- "Install guide, part 2", documentation, morpheus, low, 2024-06-01
+`
+
+	rows, err := parseFrictionImport(content)
+	if err != nil {
+		t.Fatalf("parseFrictionImport() failed: %v", err)
+	}
+
+	want := []frictionImportRow{
+		{Line: 5, Name: "Login errors", Type: "error-handling", Owner: "persephone", Priority: "high"},
+		{Line: 6, Name: "Help text", Type: "cli-output", Owner: "morpheus"},
+		{Line: 8, Name: "Install guide, part 2", Type: "documentation", Owner: "morpheus", Priority: "low", DueDate: "2024-06-01"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("Expected %d rows, got %+v", len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestImportFrictionRows(t *testing.T) {
+	data := &FrictionData{Entries: []FrictionPoint{{Name: "Login errors", Status: "approved"}}}
+	rows := []frictionImportRow{
+		{Line: 1, Name: "Login errors", Type: "error-handling", Owner: "persephone"},
+		{Line: 2, Name: "Help text", Type: "cli-output", Owner: "morpheus"},
+		{Line: 3, Name: "Help text", Type: "cli-output", Owner: "morpheus"},
+		{Line: 4, Name: "Dark mode", Type: "visual", Owner: "nobody"},
+		{Line: 5, Name: "Empty states", Type: "visual", Owner: "morpheus", Priority: "urgent"},
+		{Line: 6, Name: "Onboarding", Owner: "morpheus"},
+	}
+
+	added, skipped := importFrictionRows(data, rows, "2024-05-01")
+
+	if len(added) != 1 || added[0].Name != "Help text" || added[0].Priority != "medium" || added[0].Status != "waiting" {
+		t.Errorf("Expected only Help text queued with defaults, got %+v", added)
+	}
+	if len(data.Entries) != 2 {
+		t.Errorf("Expected 2 stored entries, got %d", len(data.Entries))
+	}
+
+	wantSkipped := []int{1, 3, 4, 5, 6}
+	if len(skipped) != len(wantSkipped) {
+		t.Fatalf("Expected %d skipped rows, got %+v", len(wantSkipped), skipped)
+	}
+	for i, line := range wantSkipped {
+		if skipped[i].Line != line {
+			t.Errorf("skipped %d = line %d, want line %d", i, skipped[i].Line, line)
+		}
+	}
+}
+
+func TestFindPatternPairs(t *testing.T) {
+	entries := []FrictionPoint{
+		{Name: "a", Tags: []string{"confusing-error", "missing-docs"}},
+		{Name: "b", Tags: []string{"missing-docs", "confusing-error", "confusing-error"}},
+		{Name: "c", Tags: []string{"confusing-error", "missing-docs", "slow"}},
+		{Name: "d", Tags: []string{"slow", "cli-output"}},
+		{Name: "e", Tags: []string{"slow", "cli-output", "missing-docs"}},
+		{Name: "f", Tags: []string{"slow"}},
+	}
+
+	pairs := findPatternPairs(entries, 2)
+	if len(pairs) != 3 {
+		t.Fatalf("Expected 3 pairs sharing 2+ items, got %+v", pairs)
+	}
+
+	first := pairs[0]
+	if first.A != "confusing-error" || first.B != "missing-docs" || first.Together != 3 || first.Either != 4 {
+		t.Errorf("Expected confusing-error + missing-docs (3 of 4) first, got %+v", first)
+	}
+	second := pairs[1]
+	if second.A != "cli-output" || second.B != "slow" || second.Together != 2 || second.Either != 4 {
+		t.Errorf("Expected cli-output + slow (2 of 4) second, got %+v", second)
+	}
+	if third := pairs[2]; third.A != "missing-docs" || third.B != "slow" || third.Either != 6 {
+		t.Errorf("Expected missing-docs + slow (2 of 6) last, got %+v", third)
+	}
+	if first.Overlap != 0.75 || second.Overlap != 0.5 {
+		t.Errorf("Unexpected overlap: %v, %v", first.Overlap, second.Overlap)
+	}
+
+	if all := findPatternPairs(entries, 1); len(all) != 5 {
+		t.Errorf("Expected 5 pairs with --min=1, got %d", len(all))
+	}
+}
+
+func TestFindOverdue(t *testing.T) {
+	now := time.Date(2024, 6, 10, 15, 0, 0, 0, time.UTC)
+	entries := []FrictionPoint{
+		{Name: "Login errors", Priority: "low", DueDate: "2024-06-01"},
+		{Name: "Help text", Priority: "high", DueDate: "2024-06-01"},
+		{Name: "Dark mode", Priority: "medium", DueDate: "2024-06-08"},
+		{Name: "Due today", DueDate: "2024-06-10"},
+		{Name: "Not yet", DueDate: "2024-07-01"},
+		{Name: "Reviewed", DueDate: "2024-05-01", ReviewedDate: "2024-05-02"},
+		{Name: "Approved", DueDate: "2024-05-01", Approved: true},
+		{Name: "No date"},
+		{Name: "Bad date", DueDate: "June 1st"},
+	}
+
+	overdue := findOverdue(entries, now)
+
+	var got []string
+	for _, item := range overdue {
+		got = append(got, fmt.Sprintf("%s=%d", item.Entry.Name, item.Days))
+	}
+	want := []string{"Help text=9", "Login errors=9", "Dark mode=2"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("overdue = %v, want %v", got, want)
+	}
+}
+
+func TestFrictionPointsDueAndOverdue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	queue := func(args ...string) (string, error) {
+		return captureStdout(t, append([]string{"matrix", "friction-points", "queue"}, args...), queueFrictionPoint)
+	}
+	out, err := queue("Login errors", "--type=error-handling", "--owner=persephone", "--due", "2000-01-01")
+	if err != nil {
+		t.Fatalf("queue failed: %v", err)
+	}
+	if !strings.Contains(out, "Due: 2000-01-01") {
+		t.Errorf("Expected the due date in the confirmation:\n%s", out)
+	}
+	if _, err := queue("Help text", "--type=cli-output", "--owner=morpheus", "--due=2999-01-01"); err != nil {
+		t.Fatalf("queue failed: %v", err)
+	}
+	if _, err := queue("Dark mode", "--type=visual", "--owner=morpheus", "--due=01/06/2024"); err == nil || !strings.Contains(err.Error(), "invalid due date") {
+		t.Errorf("Expected an invalid due date error, got %v", err)
+	}
+
+	out, err = captureStdout(t, []string{"matrix", "friction-points", "list", "--overdue"}, listFrictionPoints)
+	if err != nil {
+		t.Fatalf("list --overdue failed: %v", err)
+	}
+	if !strings.Contains(out, "Overdue: 1 items") || !strings.Contains(out, "Login errors (due 2000-01-01") {
+		t.Errorf("Expected Login errors overdue:\n%s", out)
+	}
+	if strings.Contains(out, "Help text") {
+		t.Errorf("Expected items not yet due to be left out:\n%s", out)
+	}
+}
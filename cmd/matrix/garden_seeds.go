@@ -10,6 +10,7 @@ import (
 
 	"github.com/coryzibell/matrix/internal/identity"
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // Template types
@@ -68,7 +69,7 @@ func runGardenSeeds() error {
 	}
 
 	// Slugify title for filename
-	slug := slugify(title)
+	slug := util.Slugify(title)
 	filename := slug + ".md"
 	filePath := filepath.Join(ramPath, filename)
 
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// compileGlobs compiles path globs once so a tree walk can test every file
+// against them cheaply. recon --exclude and spec-verify --include and
+// file_scope all go through here, so they agree on what a glob means:
+//
+//   - a glob without a slash matches a file or directory name at any depth
+//     ("generated", "*.pb.go")
+//   - a glob with a slash matches the relative path from the root
+//     ("pkg/*.go"), with ** spanning directories ("src/**/*.go")
+//   - a glob that matches a directory also matches everything under it, and
+//     a trailing "/" or "/**" is the same as naming the directory
+func compileGlobs(globs []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, glob := range globs {
+		glob = filepath.ToSlash(glob)
+		glob = strings.TrimSuffix(glob, "/**")
+		glob = strings.TrimSuffix(glob, "/")
+		if glob == "" {
+			continue
+		}
+		compiled = append(compiled, globToRegexp(glob))
+	}
+	return compiled
+}
+
+// matchesGlobs reports whether a relative path matches any compiled glob
+func matchesGlobs(relPath string, globs []*regexp.Regexp) bool {
+	relPath = filepath.ToSlash(relPath)
+	for _, re := range globs {
+		if re.MatchString(relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converts a glob with *, ?, ** and [...] classes into a
+// regexp anchored as described on compileGlobs
+func globToRegexp(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !strings.Contains(glob, "/") {
+		sb.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			sb.WriteString("[^/]*")
+		case glob[i] == '?':
+			sb.WriteString("[^/]")
+		case glob[i] == '[':
+			// A well-formed class is kept as is; anything else is literal
+			if class, n := globClass(glob[i:]); n > 0 {
+				sb.WriteString(class)
+				i += n - 1
+				continue
+			}
+			sb.WriteString(regexp.QuoteMeta("["))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+	sb.WriteString("(?:/.*)?$")
+	return regexp.MustCompile(sb.String())
+}
+
+// globClass converts the [...] class at the start of s into a regexp class,
+// returning it and the number of bytes consumed, or 0 if it is not a class
+func globClass(s string) (string, int) {
+	end := strings.Index(s[1:], "]")
+	if end <= 0 {
+		return "", 0
+	}
+	body := s[1 : end+1]
+	if strings.Contains(body, "/") {
+		return "", 0
+	}
+	if strings.HasPrefix(body, "!") {
+		body = "^" + body[1:]
+	}
+	class := "[" + body + "]"
+	if _, err := regexp.Compile(class); err != nil {
+		return "", 0
+	}
+	return class, end + 2
+}
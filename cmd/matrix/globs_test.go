@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestMatchesGlobs(t *testing.T) {
+	tests := []struct {
+		path string
+		glob string
+		want bool
+	}{
+		// Bare names match at any depth, directories included
+		{"generated", "generated", true},
+		{"pkg/generated", "generated/**", true},
+		{"pkg/generated/api.go", "generated", true},
+		{"api/api.pb.go", "*.pb.go", true},
+		{"api/service.go", "*.pb.go", false},
+		{"pkg/deep/file.go", "*.go", true},
+		{"a.go", "?.go", true},
+		{"b.go", "[a-c].go", true},
+		{"d.go", "[!a-c].go", true},
+		{"b.go", "[!a-c].go", false},
+
+		// Globs with a slash match from the root
+		{"pkg/generated", "pkg/generated/", true},
+		{"src/pkg/generated", "pkg/generated", false},
+		{"pkg/deep/file.go", "pkg/*.go", false},
+		{"pkg/deep/file.go", "pkg/**/*.go", true},
+		{"pkg/file.go", "pkg/**/*.go", true},
+		{"pkg/file.go", "pkg/**", true},
+		{"cmd/main.rs", "**/*.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesGlobs(tt.path, compileGlobs([]string{tt.glob})); got != tt.want {
+			t.Errorf("matchesGlobs(%q, %q) = %v, want %v", tt.path, tt.glob, got, tt.want)
+		}
+	}
+
+	if matchesGlobs("main.go", compileGlobs(nil)) {
+		t.Error("Expected no globs to match nothing")
+	}
+}
@@ -7,24 +7,35 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/coryzibell/matrix/internal/identity"
 	"github.com/coryzibell/matrix/internal/output"
 	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // IncidentData represents extracted incident information
 type IncidentData struct {
 	Title       string
 	FilePath    string
-	Timestamp   time.Time
+	Timestamp   time.Time // when the incident started, else file mtime
+	ResolvedAt  time.Time // zero unless the file says when it was resolved
 	Status      string
 	RootCauses  []RootCause
 	Fixes       []Fix
 	Insights    []string
 	Tests       *TestResults
+	Timeline    []TimelineEvent
+	Verdicts    *VerdictCorroboration // set with --component
+}
+
+// TimelineEvent is one step in an incident's sequence of events
+type TimelineEvent struct {
+	When        string `json:"when"` // clock time, offset like T+5m, or "step N"
+	Description string `json:"description"`
 }
 
 // RootCause represents a single root cause
@@ -48,24 +59,144 @@ type TestResults struct {
 	Fixed  int
 }
 
+// VerdictCorroboration is how a component's recorded verdict test runs around
+// an incident line up with its claimed fix
+type VerdictCorroboration struct {
+	Component     string   `json:"component"`
+	Status        string   `json:"status"`         // corroborated, contradicted, unconfirmed, no-data
+	FailingBefore int      `json:"failing_before"` // tests whose last run before the fix failed
+	PassingAfter  int      `json:"passing_after"`  // tests whose last run after the fix passed
+	FailingAfter  int      `json:"failing_after"`
+	Fixed         int      `json:"fixed"` // failing before and passing after
+	StillFailing  []string `json:"still_failing,omitempty"`
+	Note          string   `json:"note"`
+}
+
+// Verdict corroboration statuses
+const (
+	verdictsCorroborated = "corroborated"
+	verdictsContradicted = "contradicted"
+	verdictsUnconfirmed  = "unconfirmed"
+	verdictsNoData       = "no-data"
+)
+
+// incidentVerdictWindow is how far either side of the fix recorded test runs
+// count toward corroborating it
+const incidentVerdictWindow = 72 * time.Hour
+
+// StaleLineRef is a fix whose cited line range no longer fits the file
+type StaleLineRef struct {
+	Incident  string `json:"incident"`
+	File      string `json:"file"`
+	Lines     string `json:"lines"`
+	FileLines int    `json:"file_lines"`
+	Reason    string `json:"reason"`
+}
+
+// IncidentGroup clusters incidents that share a root cause or affected file
+type IncidentGroup struct {
+	Key       string   `json:"key"`
+	Count     int      `json:"count"`
+	Examples  []string `json:"examples"`
+	LastSeen  string   `json:"last_seen"`
+	lastSeenT time.Time
+}
+
+// Incident statuses
+const (
+	incidentOpen     = "open"
+	incidentResolved = "resolved"
+)
+
+// incidentStatusPattern matches an explicit "Status: ..." line, optionally
+// bulleted or bolded
+var incidentStatusPattern = regexp.MustCompile(`(?i)^[-*]?\s*\**status\**\s*:\s*\**\s*([a-z][a-z -]*)`)
+
+// incidentTimePattern matches "Started: ..." / "Resolved: ..." lines,
+// optionally bulleted or bolded
+var incidentTimePattern = regexp.MustCompile(`(?i)^[-*]?\s*\**(started|start time|detected|opened|began|resolved|resolved at|closed)\**\s*:\s*\**\s*(.+?)\**$`)
+
+// incidentTimeLayouts are the timestamp formats accepted in incident files
+var incidentTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// openStatusValues are explicit status values that mean work is ongoing
+var openStatusValues = map[string]bool{
+	"open":          true,
+	"unresolved":    true,
+	"investigating": true,
+	"in progress":   true,
+	"in-progress":   true,
+	"ongoing":       true,
+	"active":        true,
+	"monitoring":    true,
+}
+
 // runIncidentTrace implements the incident-trace command
 func runIncidentTrace() error {
 	// Parse flags
 	jsonFlag := false
 	neoFlag := false
 	allFlag := false
+	verifyLines := false
+	openOnly := false
+	metrics := false
+	windowDays := incidentDefaultWindowDays
 	pattern := ""
+	groupBy := ""
+	outputDir := ""
 	filePath := ""
+	component := ""
 
 	// Simple flag parsing
 	for i := 2; i < len(os.Args); i++ {
 		arg := os.Args[i]
-		if arg == "--json" {
+		if arg == "--group-by" && i+1 < len(os.Args) {
+			i++
+			groupBy = os.Args[i]
+		} else if strings.HasPrefix(arg, "--group-by=") {
+			groupBy = strings.TrimPrefix(arg, "--group-by=")
+		} else if arg == "--output" && i+1 < len(os.Args) {
+			i++
+			outputDir = os.Args[i]
+		} else if strings.HasPrefix(arg, "--output=") {
+			outputDir = strings.TrimPrefix(arg, "--output=")
+		} else if arg == "--json" {
 			jsonFlag = true
 		} else if arg == "--neo" {
 			neoFlag = true
 		} else if arg == "--all" {
 			allFlag = true
+		} else if arg == "--verify-lines" {
+			verifyLines = true
+		} else if arg == "--open-only" {
+			openOnly = true
+		} else if arg == "--metrics" {
+			metrics = true
+		} else if arg == "--window" || strings.HasPrefix(arg, "--window=") {
+			value := strings.TrimPrefix(arg, "--window=")
+			if arg == "--window" {
+				if i+1 >= len(os.Args) {
+					return fmt.Errorf("--window requires a number of days")
+				}
+				i++
+				value = os.Args[i]
+			}
+			days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+			if err != nil || days < 0 {
+				return fmt.Errorf("invalid --window: %s (days, 0 for all time)", value)
+			}
+			windowDays = days
+		} else if arg == "--component" && i+1 < len(os.Args) {
+			i++
+			component = os.Args[i]
+		} else if strings.HasPrefix(arg, "--component=") {
+			component = strings.TrimPrefix(arg, "--component=")
 		} else if strings.HasPrefix(arg, "--pattern=") {
 			pattern = strings.TrimPrefix(arg, "--pattern=")
 		} else if !strings.HasPrefix(arg, "--") {
@@ -82,6 +213,14 @@ func runIncidentTrace() error {
 		return fmt.Errorf("must specify either --all or a file path")
 	}
 
+	if metrics && !allFlag {
+		return fmt.Errorf("--metrics requires --all")
+	}
+
+	if groupBy != "" && groupBy != "root-cause" && groupBy != "file" {
+		return fmt.Errorf("invalid --group-by: %s (valid: root-cause, file)", groupBy)
+	}
+
 	// Get Trinity's RAM path
 	trinityPath, err := identity.RAMPath("trinity")
 	if err != nil {
@@ -91,14 +230,15 @@ func runIncidentTrace() error {
 	var incidents []IncidentData
 
 	if allFlag {
-		// Scan all markdown files directly in Trinity's directory
+		// Scan all markdown and JSON files directly in Trinity's directory
 		dirEntries, err := os.ReadDir(trinityPath)
 		if err != nil {
 			return fmt.Errorf("failed to read Trinity's RAM directory: %w", err)
 		}
 
 		for _, entry := range dirEntries {
-			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".md" && ext != ".json") {
 				continue
 			}
 
@@ -111,32 +251,28 @@ func runIncidentTrace() error {
 			file := ram.File{
 				Path:     filePath,
 				Identity: "trinity",
-				Name:     strings.TrimSuffix(entry.Name(), ".md"),
+				Name:     strings.TrimSuffix(entry.Name(), ext),
 				Content:  string(content),
 			}
 
-			// Skip non-incident files
-			if !isIncidentFile(file.Content) {
-				continue
-			}
-
 			// Apply pattern filter if specified
 			if pattern != "" && !strings.Contains(strings.ToLower(file.Content), strings.ToLower(pattern)) {
 				continue
 			}
 
-			incident := extractIncidentData(file)
+			// Skip non-incident files
+			incident, err := loadIncident(file)
+			if err != nil {
+				continue
+			}
 			incidents = append(incidents, incident)
 		}
 
-		// Sort by timestamp
-		sort.Slice(incidents, func(i, j int) bool {
-			return incidents[i].Timestamp.After(incidents[j].Timestamp)
-		})
+		sortIncidents(incidents)
 
 	} else {
 		// Process single file
-		expandedPath := expandPath(filePath)
+		expandedPath := util.ExpandPath(filePath)
 		content, err := os.ReadFile(expandedPath)
 		if err != nil {
 			return fmt.Errorf("failed to read file %s: %w", expandedPath, err)
@@ -148,11 +284,45 @@ func runIncidentTrace() error {
 			Content:  string(content),
 		}
 
-		if !isIncidentFile(file.Content) {
-			return fmt.Errorf("file does not appear to be an incident report")
+		incident, err := loadIncident(file)
+		if err != nil {
+			return err
 		}
+		incidents = append(incidents, incident)
+	}
 
-		incidents = append(incidents, extractIncidentData(file))
+	if openOnly {
+		var open []IncidentData
+		for _, incident := range incidents {
+			if incident.Status == incidentOpen {
+				open = append(open, incident)
+			}
+		}
+		if len(open) == 0 {
+			fmt.Println("No open incidents found")
+			return nil
+		}
+		incidents = open
+	}
+
+	// Cross-reference claimed fixes with the component's recorded test runs
+	if component != "" {
+		data, err := loadVerdictData()
+		if err != nil {
+			return err
+		}
+		for i := range incidents {
+			incidents[i].Verdicts = corroborateIncident(incidents[i], data.Entries, component, incidentVerdictWindow)
+		}
+	}
+
+	if metrics {
+		m := computeIncidentMetrics(incidents, time.Now(), windowDays)
+		if jsonFlag {
+			return output.EmitJSON(m)
+		}
+		displayIncidentMetrics(m)
+		return nil
 	}
 
 	if len(incidents) == 0 {
@@ -161,7 +331,27 @@ func runIncidentTrace() error {
 	}
 
 	// Output based on flags
-	if jsonFlag {
+	if outputDir != "" {
+		written, err := writeIncidentFiles(incidents, util.ExpandPath(outputDir))
+		if err != nil {
+			return err
+		}
+		for _, path := range written {
+			fmt.Printf("  %s\n", path)
+		}
+		output.Success(fmt.Sprintf("✓ Wrote %d incident file(s)", len(written)))
+		return nil
+	} else if verifyLines {
+		return outputLineVerification(incidents, jsonFlag)
+	} else if groupBy != "" {
+		groups := groupIncidents(incidents, groupBy)
+		if jsonFlag {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(groups)
+		}
+		return outputIncidentGroups(groups, groupBy, len(incidents))
+	} else if jsonFlag {
 		return outputIncidentJSON(incidents)
 	} else if neoFlag {
 		return outputNeoSummary(incidents)
@@ -172,6 +362,126 @@ func runIncidentTrace() error {
 	}
 }
 
+// loadIncident reads an incident from a RAM file, mapping structured .json
+// incidents directly and scraping markdown prose otherwise
+func loadIncident(file ram.File) (IncidentData, error) {
+	if strings.EqualFold(filepath.Ext(file.Path), ".json") {
+		return parseStructuredIncident(file)
+	}
+	if !isIncidentFile(file.Content) {
+		return IncidentData{}, fmt.Errorf("file does not appear to be an incident report")
+	}
+	return extractIncidentData(file), nil
+}
+
+// structuredIncident is the shape of a JSON incident file. "incident" is
+// accepted as the title so files written by --output read back unchanged.
+type structuredIncident struct {
+	Title      string          `json:"title"`
+	Incident   string          `json:"incident"`
+	Timestamp  string          `json:"timestamp"`
+	StartedAt  string          `json:"started_at"`
+	ResolvedAt string          `json:"resolved_at"`
+	Status     string          `json:"status"`
+	RootCauses []RootCause     `json:"root_causes"`
+	Fixes      []Fix           `json:"fixes"`
+	Insights   []string        `json:"insights"`
+	Tests      *TestResults    `json:"tests"`
+	Timeline   []TimelineEvent `json:"timeline"`
+}
+
+// UnmarshalJSON accepts a root cause as an object or as a plain string,
+// which becomes its Detail
+func (rc *RootCause) UnmarshalJSON(data []byte) error {
+	var detail string
+	if err := json.Unmarshal(data, &detail); err == nil {
+		*rc = RootCause{Detail: detail}
+		return nil
+	}
+	type plain RootCause
+	return json.Unmarshal(data, (*plain)(rc))
+}
+
+// UnmarshalJSON accepts a fix as an object or as a plain string, which
+// becomes its File
+func (f *Fix) UnmarshalJSON(data []byte) error {
+	var file string
+	if err := json.Unmarshal(data, &file); err == nil {
+		*f = Fix{File: file}
+		return nil
+	}
+	type plain Fix
+	return json.Unmarshal(data, (*plain)(f))
+}
+
+// parseStructuredIncident maps a JSON incident file onto IncidentData. A
+// file needs a title and at least one of root_causes, fixes, insights or
+// tests to count as an incident. A root cause with only an issue uses it as
+// its detail, since that's what reports and grouping show. Without an
+// explicit status, an incident with no fixes is open, as with markdown.
+func parseStructuredIncident(file ram.File) (IncidentData, error) {
+	var raw structuredIncident
+	if err := json.Unmarshal([]byte(file.Content), &raw); err != nil {
+		return IncidentData{}, fmt.Errorf("failed to parse JSON incident %s: %w", file.Path, err)
+	}
+
+	title := raw.Title
+	if title == "" {
+		title = raw.Incident
+	}
+	if title == "" || (len(raw.RootCauses) == 0 && len(raw.Fixes) == 0 && len(raw.Insights) == 0 && raw.Tests == nil) {
+		return IncidentData{}, fmt.Errorf("file does not appear to be an incident report")
+	}
+
+	incident := IncidentData{
+		Title:      title,
+		FilePath:   file.Path,
+		Status:     incidentResolved,
+		RootCauses: raw.RootCauses,
+		Fixes:      raw.Fixes,
+		Insights:   raw.Insights,
+		Tests:      raw.Tests,
+		Timeline:   raw.Timeline,
+	}
+	if incident.RootCauses == nil {
+		incident.RootCauses = []RootCause{}
+	}
+	for i, cause := range incident.RootCauses {
+		if cause.Detail == "" {
+			incident.RootCauses[i].Detail = cause.Issue
+		}
+	}
+	if incident.Fixes == nil {
+		incident.Fixes = []Fix{}
+	}
+	if incident.Insights == nil {
+		incident.Insights = []string{}
+	}
+	if incident.Timeline == nil {
+		incident.Timeline = []TimelineEvent{}
+	}
+
+	started := raw.StartedAt
+	if started == "" {
+		started = raw.Timestamp
+	}
+	if t, ok := parseIncidentTime(started); ok {
+		incident.Timestamp = t
+	} else if info, err := os.Stat(file.Path); err == nil {
+		incident.Timestamp = info.ModTime()
+	}
+	if t, ok := parseIncidentTime(raw.ResolvedAt); ok {
+		incident.ResolvedAt = t
+	}
+
+	status := strings.TrimSpace(strings.ToLower(raw.Status))
+	if openStatusValues[status] || (status == "" && len(incident.Fixes) == 0) {
+		incident.Status = incidentOpen
+	}
+
+	return incident, nil
+}
+
 // isIncidentFile checks if content looks like an incident report
 func isIncidentFile(content string) bool {
 	lower := strings.ToLower(content)
@@ -199,10 +509,11 @@ func isIncidentFile(content string) bool {
 func extractIncidentData(file ram.File) IncidentData {
 	incident := IncidentData{
 		FilePath:   file.Path,
-		Status:     "resolved",
+		Status:     incidentResolved,
 		RootCauses: []RootCause{},
 		Fixes:      []Fix{},
 		Insights:   []string{},
+		Timeline:   []TimelineEvent{},
 	}
 
 	lines := strings.Split(file.Content, "\n")
@@ -233,9 +544,116 @@ func extractIncidentData(file ram.File) IncidentData {
 	// Extract test results
 	incident.Tests = extractTestResults(lines)
 
+	// Extract timeline
+	incident.Timeline = extractTimeline(lines)
+
+	// Explicit start/resolve times
+	started, resolved := extractIncidentTimes(lines)
+	if !started.IsZero() {
+		incident.Timestamp = started
+	}
+	incident.ResolvedAt = resolved
+
+	incident.Status = detectIncidentStatus(lines)
+
 	return incident
 }
 
+// extractIncidentTimes finds "Started:" and "Resolved:" lines (or
+// Detected/Opened and Closed) whose values parse as timestamps
+func extractIncidentTimes(lines []string) (started, resolved time.Time) {
+	for _, line := range lines {
+		m := incidentTimePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		t, ok := parseIncidentTime(m[2])
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(m[1]) {
+		case "resolved", "resolved at", "closed":
+			if resolved.IsZero() {
+				resolved = t
+			}
+		default:
+			if started.IsZero() {
+				started = t
+			}
+		}
+	}
+	return started, resolved
+}
+
+// parseIncidentTime parses a timestamp in any of incidentTimeLayouts, in
+// local time when no zone is given
+func parseIncidentTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range incidentTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil && !t.IsZero() {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// detectIncidentStatus decides whether an incident is still open. An
+// explicit "Status:" line wins; otherwise "still failing", a TODO in the
+// resolution, or no fixes section at all mark it open.
+func detectIncidentStatus(lines []string) string {
+	hasFixSection := false
+	inResolution := false
+	todoInResolution := false
+	stillFailing := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		lower := strings.ToLower(trimmed)
+
+		if m := incidentStatusPattern.FindStringSubmatch(trimmed); m != nil {
+			value := strings.TrimSpace(strings.ToLower(m[1]))
+			if openStatusValues[value] {
+				return incidentOpen
+			}
+			return incidentResolved
+		}
+
+		if strings.HasPrefix(lower, "#") {
+			inResolution = strings.Contains(lower, "resolution")
+			if strings.Contains(lower, "fix") || strings.Contains(lower, "files modified") {
+				hasFixSection = true
+			}
+		} else if strings.Contains(lower, "files modified") || strings.HasPrefix(lower, "fixed:") || strings.HasPrefix(lower, "resolution:") {
+			hasFixSection = true
+			inResolution = strings.HasPrefix(lower, "resolution:")
+		}
+
+		if inResolution && strings.Contains(lower, "todo") {
+			todoInResolution = true
+		}
+		if strings.Contains(lower, "still failing") {
+			stillFailing = true
+		}
+	}
+
+	if stillFailing || todoInResolution || !hasFixSection {
+		return incidentOpen
+	}
+	return incidentResolved
+}
+
+// sortIncidents orders open incidents first, newest first within each group
+func sortIncidents(incidents []IncidentData) {
+	sort.SliceStable(incidents, func(i, j int) bool {
+		iOpen := incidents[i].Status == incidentOpen
+		jOpen := incidents[j].Status == incidentOpen
+		if iOpen != jOpen {
+			return iOpen
+		}
+		return incidents[i].Timestamp.After(incidents[j].Timestamp)
+	})
+}
+
 // extractRootCauses finds root cause information
 func extractRootCauses(lines []string) []RootCause {
 	var causes []RootCause
@@ -404,6 +822,96 @@ func extractLineRange(text string) string {
 	return ""
 }
 
+// parseLineRange parses "123" or "123-456" into start and end lines
+func parseLineRange(lines string) (start, end int, ok bool) {
+	startStr, endStr, isRange := strings.Cut(lines, "-")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	end = start
+	if isRange {
+		if end, err = strconv.Atoi(endStr); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, end, true
+}
+
+// verifyFixLines checks each fix's cited line range against the current
+// length of the referenced file. Fixes without a range, and files that no
+// longer exist, are skipped. Returns the number of references checked.
+func verifyFixLines(incident IncidentData) (checked int, stale []StaleLineRef) {
+	for _, fix := range incident.Fixes {
+		start, end, ok := parseLineRange(fix.Lines)
+		if !ok {
+			continue
+		}
+		content, err := os.ReadFile(util.ExpandPath(fix.File))
+		if err != nil {
+			continue
+		}
+		checked++
+
+		fileLines := strings.Count(string(content), "\n")
+		if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+			fileLines++
+		}
+
+		reason := ""
+		switch {
+		case start < 1 || end < start:
+			reason = "invalid range"
+		case start > fileLines:
+			reason = "range starts past end of file"
+		case end > fileLines:
+			reason = "range ends past end of file"
+		}
+		if reason != "" {
+			stale = append(stale, StaleLineRef{
+				Incident:  incident.Title,
+				File:      fix.File,
+				Lines:     fix.Lines,
+				FileLines: fileLines,
+				Reason:    reason,
+			})
+		}
+	}
+	return checked, stale
+}
+
+// outputLineVerification reports fixes whose line references have drifted
+func outputLineVerification(incidents []IncidentData, asJSON bool) error {
+	checked := 0
+	stale := []StaleLineRef{}
+	for _, incident := range incidents {
+		n, refs := verifyFixLines(incident)
+		checked += n
+		stale = append(stale, refs...)
+	}
+
+	if asJSON {
+		return output.EmitJSON(stale)
+	}
+
+	output.Header("LINE REFERENCES:")
+	fmt.Printf("  Checked %d line references across %d incidents\n", checked, len(incidents))
+	fmt.Println()
+
+	if len(stale) == 0 {
+		output.Success("All cited line ranges fit their files")
+		return nil
+	}
+
+	fmt.Printf("%s%d stale references:%s\n", output.Yellow, len(stale), output.Reset)
+	for _, ref := range stale {
+		fmt.Printf("  %s\n", ref.Incident)
+		fmt.Printf("    %s Lines %s: %s (file has %d lines)\n", ref.File, ref.Lines, ref.Reason, ref.FileLines)
+	}
+
+	return nil
+}
+
 // extractInsights finds key learnings
 func extractInsights(lines []string) []string {
 	var insights []string
@@ -426,6 +934,53 @@ func extractInsights(lines []string) []string {
 	return insights
 }
 
+// timelineEventPattern matches "10:32 - deployed fix", "2024-03-01 14:05 UTC: paged",
+// or "T+15m - rollback started", optionally as a list item with bold timestamp
+var timelineEventPattern = regexp.MustCompile(`^(?:[-*]\s+|\d+\.\s+)?\**((?:\d{4}-\d{2}-\d{2}[ T])?\d{1,2}:\d{2}(?::\d{2})?(?:\s?[AaPp][Mm])?(?:\s?UTC)?|T[+-]\d+\s?[smhd]\w*)\**\s*[-–—:|]\s*(.+)$`)
+
+// timelineStepPattern matches numbered steps inside a timeline section
+var timelineStepPattern = regexp.MustCompile(`^(\d+)\.\s+(.+)$`)
+
+// extractTimeline finds the chronological sequence of events. Timestamped
+// lines are picked up anywhere; plain numbered steps only count inside a
+// timeline/sequence/events section.
+func extractTimeline(lines []string) []TimelineEvent {
+	events := []TimelineEvent{}
+	inTimeline := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		// Track which section we're in
+		if strings.HasPrefix(trimmed, "#") {
+			lower := strings.ToLower(trimmed)
+			inTimeline = strings.Contains(lower, "timeline") ||
+				strings.Contains(lower, "sequence") ||
+				strings.Contains(lower, "events")
+			continue
+		}
+
+		if match := timelineEventPattern.FindStringSubmatch(trimmed); match != nil {
+			events = append(events, TimelineEvent{
+				When:        strings.TrimSpace(match[1]),
+				Description: strings.TrimSpace(match[2]),
+			})
+			continue
+		}
+
+		if inTimeline {
+			if match := timelineStepPattern.FindStringSubmatch(trimmed); match != nil {
+				events = append(events, TimelineEvent{
+					When:        "step " + match[1],
+					Description: strings.TrimSpace(match[2]),
+				})
+			}
+		}
+	}
+
+	return events
+}
+
 // extractTestResults finds before/after test counts
 func extractTestResults(lines []string) *TestResults {
 	for _, line := range lines {
@@ -465,19 +1020,126 @@ func extractTestResults(lines []string) *TestResults {
 	return nil
 }
 
+// corroborateIncident checks a component's verdict test runs against an
+// incident's fix. Runs are split at the fix (ResolvedAt, else the incident's
+// timestamp) and only runs within window of it count; each test's last run
+// on either side decides whether it was failing before and passing after.
+func corroborateIncident(incident IncidentData, entries []VerdictEntry, component string, window time.Duration) *VerdictCorroboration {
+	fixAt := incident.ResolvedAt
+	if fixAt.IsZero() {
+		fixAt = incident.Timestamp
+	}
+	from := incident.Timestamp.Add(-window)
+	if fixAt.Before(incident.Timestamp) {
+		from = fixAt.Add(-window)
+	}
+	until := fixAt.Add(window)
+
+	type lastRun struct {
+		at     time.Time
+		result string
+	}
+	before := make(map[string]lastRun)
+	after := make(map[string]lastRun)
+	for _, entry := range entries {
+		if entry.Type != "test" || !strings.EqualFold(entry.Component, component) {
+			continue
+		}
+		if entry.Timestamp.Before(from) || entry.Timestamp.After(until) {
+			continue
+		}
+		side := after
+		if entry.Timestamp.Before(fixAt) {
+			side = before
+		}
+		if run, ok := side[entry.Test]; !ok || !entry.Timestamp.Before(run.at) {
+			side[entry.Test] = lastRun{entry.Timestamp, entry.Result}
+		}
+	}
+
+	c := &VerdictCorroboration{Component: component}
+	for _, run := range before {
+		if run.result == "fail" {
+			c.FailingBefore++
+		}
+	}
+	for test, run := range after {
+		if run.result == "fail" {
+			c.FailingAfter++
+			c.StillFailing = append(c.StillFailing, test)
+			continue
+		}
+		c.PassingAfter++
+		if before[test].result == "fail" {
+			c.Fixed++
+		}
+	}
+	sort.Strings(c.StillFailing)
+
+	claimed := 0
+	if incident.Tests != nil {
+		claimed = incident.Tests.Fixed
+	}
+	days := int(window.Hours() / 24)
+
+	switch {
+	case len(after) == 0:
+		c.Status = verdictsNoData
+		c.Note = fmt.Sprintf("no %s test runs recorded within %d days after the fix", component, days)
+	case c.FailingAfter > 0:
+		c.Status = verdictsContradicted
+		c.Note = fmt.Sprintf("%d %s tests still failing after the fix", c.FailingAfter, component)
+	case claimed > 0 && c.Fixed == 0:
+		c.Status = verdictsUnconfirmed
+		c.Note = fmt.Sprintf("all %d recorded tests pass after the fix, but none were recorded failing before it", c.PassingAfter)
+	default:
+		c.Status = verdictsCorroborated
+		c.Note = fmt.Sprintf("%d failing → %d passing in recorded runs", c.Fixed, c.PassingAfter)
+		if claimed > 0 && claimed != c.Fixed {
+			c.Note += fmt.Sprintf(" (incident claims %d fixed)", claimed)
+		}
+	}
+	return c
+}
+
 // outputHumanReadable outputs incident data in human-readable format
 func outputHumanReadable(incidents []IncidentData) error {
+	openCount := 0
+	for _, incident := range incidents {
+		if incident.Status == incidentOpen {
+			openCount++
+		}
+	}
+	// Section headers only help when open and resolved incidents are mixed
+	showSections := openCount > 0 && len(incidents) > 1
+
 	for i, incident := range incidents {
+		startsSection := showSections &&
+			(i == 0 || (incident.Status != incidentOpen && incidents[i-1].Status == incidentOpen))
+
 		if i > 0 {
 			fmt.Println()
 			fmt.Println(strings.Repeat("─", 70))
 			fmt.Println()
 		}
 
+		if startsSection {
+			if incident.Status == incidentOpen {
+				fmt.Printf("%s🔥 OPEN INCIDENTS (%d)%s\n", output.Red, openCount, output.Reset)
+			} else {
+				fmt.Printf("%s✓ RESOLVED INCIDENTS (%d)%s\n", output.Dim, len(incidents)-openCount, output.Reset)
+			}
+			fmt.Println()
+		}
+
 		output.Success(fmt.Sprintf("INCIDENT: %s", incident.Title))
 		fmt.Println()
 		output.Item("DATE", incident.Timestamp.Format("2006-01-02"))
-		output.Item("STATUS", incident.Status)
+		if incident.Status == incidentOpen {
+			output.Item("STATUS", output.Red+incident.Status+output.Reset)
+		} else {
+			output.Item("STATUS", incident.Status)
+		}
 		fmt.Println()
 
 		if len(incident.RootCauses) > 0 {
@@ -507,6 +1169,14 @@ func outputHumanReadable(incidents []IncidentData) error {
 			fmt.Println()
 		}
 
+		if len(incident.Timeline) > 0 {
+			output.Header("TIMELINE:")
+			for _, event := range incident.Timeline {
+				fmt.Printf("  %-12s %s\n", event.When, event.Description)
+			}
+			fmt.Println()
+		}
+
 		if len(incident.Insights) > 0 {
 			output.Header("INSIGHTS:")
 			for _, insight := range incident.Insights {
@@ -527,35 +1197,70 @@ func outputHumanReadable(incidents []IncidentData) error {
 				fmt.Printf("  %d/%d passing\n", incident.Tests.After, incident.Tests.After)
 			}
 		}
+
+		if v := incident.Verdicts; v != nil {
+			if incident.Tests != nil {
+				fmt.Println()
+			}
+			output.Header(fmt.Sprintf("VERDICTS (%s):", v.Component))
+			color := output.Dim
+			switch v.Status {
+			case verdictsCorroborated:
+				color = output.Green
+			case verdictsContradicted:
+				color = output.Red
+			case verdictsUnconfirmed:
+				color = output.Yellow
+			}
+			fmt.Printf("  %s%s%s: %s\n", color, v.Status, output.Reset, v.Note)
+			for _, test := range v.StillFailing {
+				fmt.Printf("    ✗ %s\n", test)
+			}
+		}
 	}
 
 	return nil
 }
 
+// incidentJSON is the JSON-friendly form of an incident
+type incidentJSON struct {
+	Incident   string                `json:"incident"`
+	Timestamp  string                `json:"timestamp"`
+	ResolvedAt string                `json:"resolved_at,omitempty"`
+	Status     string                `json:"status"`
+	RootCauses []RootCause           `json:"root_causes"`
+	Fixes      []Fix                 `json:"fixes"`
+	Insights   []string              `json:"insights"`
+	Tests      *TestResults          `json:"tests,omitempty"`
+	Timeline   []TimelineEvent       `json:"timeline"`
+	Verdicts   *VerdictCorroboration `json:"verdicts,omitempty"`
+}
+
+// toIncidentJSON converts an incident to its JSON form
+func toIncidentJSON(incident IncidentData) incidentJSON {
+	resolvedAt := ""
+	if !incident.ResolvedAt.IsZero() {
+		resolvedAt = incident.ResolvedAt.Format(time.RFC3339)
+	}
+	return incidentJSON{
+		Incident:   incident.Title,
+		Timestamp:  incident.Timestamp.Format(time.RFC3339),
+		ResolvedAt: resolvedAt,
+		Status:     incident.Status,
+		RootCauses: incident.RootCauses,
+		Fixes:      incident.Fixes,
+		Insights:   incident.Insights,
+		Tests:      incident.Tests,
+		Timeline:   incident.Timeline,
+		Verdicts:   incident.Verdicts,
+	}
+}
+
 // outputIncidentJSON outputs incident data as JSON
 func outputIncidentJSON(incidents []IncidentData) error {
-	// Convert to JSON-friendly format
-	type JSONIncident struct {
-		Incident   string       `json:"incident"`
-		Timestamp  string       `json:"timestamp"`
-		Status     string       `json:"status"`
-		RootCauses []RootCause  `json:"root_causes"`
-		Fixes      []Fix        `json:"fixes"`
-		Insights   []string     `json:"insights"`
-		Tests      *TestResults `json:"tests,omitempty"`
-	}
-
-	var jsonIncidents []JSONIncident
+	var jsonIncidents []incidentJSON
 	for _, incident := range incidents {
-		jsonIncidents = append(jsonIncidents, JSONIncident{
-			Incident:   incident.Title,
-			Timestamp:  incident.Timestamp.Format(time.RFC3339),
-			Status:     incident.Status,
-			RootCauses: incident.RootCauses,
-			Fixes:      incident.Fixes,
-			Insights:   incident.Insights,
-			Tests:      incident.Tests,
-		})
+		jsonIncidents = append(jsonIncidents, toIncidentJSON(incident))
 	}
 
 	encoder := json.NewEncoder(os.Stdout)
@@ -563,6 +1268,45 @@ func outputIncidentJSON(incidents []IncidentData) error {
 	return encoder.Encode(jsonIncidents)
 }
 
+// writeIncidentFiles writes each incident to <dir>/<slug>.json and returns
+// the paths written. Slugs come from the title (or source file name) and get
+// a numeric suffix when two incidents would share a file.
+func writeIncidentFiles(incidents []IncidentData, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	used := make(map[string]bool)
+	var written []string
+	for _, incident := range incidents {
+		slug := util.Slugify(incident.Title)
+		if slug == "" {
+			slug = util.Slugify(strings.TrimSuffix(filepath.Base(incident.FilePath), filepath.Ext(incident.FilePath)))
+		}
+		if slug == "" {
+			slug = "incident"
+		}
+		name := slug
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s-%d", slug, n)
+		}
+		used[name] = true
+
+		data, err := json.MarshalIndent(toIncidentJSON(incident), "", "  ")
+		if err != nil {
+			return written, fmt.Errorf("failed to encode incident %q: %w", incident.Title, err)
+		}
+
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
 // outputNeoSummary outputs one-paragraph handoff summary
 func outputNeoSummary(incidents []IncidentData) error {
 	for i, incident := range incidents {
@@ -695,6 +1439,173 @@ func outputPatternAnalysis(incidents []IncidentData, pattern string) error {
 	return nil
 }
 
+// groupIncidents clusters incidents by simplified root cause or by affected file.
+// An incident is counted once per group even if it mentions the key repeatedly.
+func groupIncidents(incidents []IncidentData, groupBy string) []IncidentGroup {
+	groupMap := make(map[string]*IncidentGroup)
+
+	for _, incident := range incidents {
+		keys := make(map[string]bool)
+		if groupBy == "file" {
+			for _, fix := range incident.Fixes {
+				keys[fix.File] = true
+			}
+		} else {
+			for _, cause := range incident.RootCauses {
+				keys[simplifyText(cause.Detail)] = true
+			}
+		}
+		if len(keys) == 0 {
+			keys["(none recorded)"] = true
+		}
+
+		for key := range keys {
+			group := groupMap[key]
+			if group == nil {
+				group = &IncidentGroup{Key: key}
+				groupMap[key] = group
+			}
+			group.Count++
+			if len(group.Examples) < 3 {
+				group.Examples = append(group.Examples, incident.Title)
+			}
+			if incident.Timestamp.After(group.lastSeenT) {
+				group.lastSeenT = incident.Timestamp
+				group.LastSeen = incident.Timestamp.Format("2006-01-02")
+			}
+		}
+	}
+
+	var groups []IncidentGroup
+	for _, group := range groupMap {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+
+	return groups
+}
+
+// outputIncidentGroups outputs clustered incidents with representative examples
+func outputIncidentGroups(groups []IncidentGroup, groupBy string, total int) error {
+	output.Success(fmt.Sprintf("INCIDENT GROUPS by %s (%d incidents, %d groups)", groupBy, total, len(groups)))
+	fmt.Println()
+
+	for _, group := range groups {
+		fmt.Printf("%s (%d incidents, last seen %s)\n",
+			output.Yellow+group.Key+output.Reset, group.Count, group.LastSeen)
+		for _, example := range group.Examples {
+			fmt.Printf("  - %s\n", example)
+		}
+		if group.Count > len(group.Examples) {
+			fmt.Printf("  ... and %d more\n", group.Count-len(group.Examples))
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// incidentDefaultWindowDays is the --metrics window when --window isn't given
+const incidentDefaultWindowDays = 90
+
+// IncidentMetrics summarizes incident volume and resolution time over a window
+type IncidentMetrics struct {
+	WindowDays  int     `json:"window_days"` // 0 = all time
+	Since       string  `json:"since"`       // first day counted, YYYY-MM-DD
+	Incidents   int     `json:"incidents"`
+	Open        int     `json:"open"`
+	Resolved    int     `json:"resolved"`
+	PerWeek     float64 `json:"per_week"`
+	MTTRHours   float64 `json:"mttr_hours"`   // mean start-to-resolve time
+	MTTRSamples int     `json:"mttr_samples"` // resolved incidents with both times
+}
+
+// computeIncidentMetrics counts incidents that started in the last
+// windowDays (all of them for 0) and averages time to resolution over those
+// with a resolve time after their start. Frequency is per week of window;
+// for all time the window runs from the oldest incident, at least a week.
+func computeIncidentMetrics(incidents []IncidentData, now time.Time, windowDays int) IncidentMetrics {
+	m := IncidentMetrics{WindowDays: windowDays}
+
+	since := now.AddDate(0, 0, -windowDays)
+	var total time.Duration
+	for _, incident := range incidents {
+		if windowDays > 0 && incident.Timestamp.Before(since) {
+			continue
+		}
+		if windowDays == 0 && (m.Incidents == 0 || incident.Timestamp.Before(since)) {
+			since = incident.Timestamp
+		}
+
+		m.Incidents++
+		if incident.Status == incidentOpen {
+			m.Open++
+			continue
+		}
+		m.Resolved++
+		if !incident.ResolvedAt.IsZero() && incident.ResolvedAt.After(incident.Timestamp) {
+			total += incident.ResolvedAt.Sub(incident.Timestamp)
+			m.MTTRSamples++
+		}
+	}
+
+	if m.Incidents > 0 || windowDays > 0 {
+		m.Since = since.Format("2006-01-02")
+	}
+	weeks := now.Sub(since).Hours() / (24 * 7)
+	if weeks < 1 {
+		weeks = 1
+	}
+	m.PerWeek = float64(m.Incidents) / weeks
+	if m.MTTRSamples > 0 {
+		m.MTTRHours = total.Hours() / float64(m.MTTRSamples)
+	}
+
+	return m
+}
+
+// displayIncidentMetrics prints incident KPIs
+func displayIncidentMetrics(m IncidentMetrics) {
+	window := "all time"
+	if m.WindowDays > 0 {
+		window = fmt.Sprintf("last %d days", m.WindowDays)
+	}
+	output.Success(fmt.Sprintf("INCIDENT METRICS (%s)", window))
+	fmt.Println()
+
+	if m.Incidents == 0 {
+		fmt.Println("No incidents in this window")
+		return
+	}
+
+	output.Item("SINCE", m.Since)
+	output.Item("INCIDENTS", fmt.Sprintf("%d (%d open, %d resolved)", m.Incidents, m.Open, m.Resolved))
+	output.Item("FREQUENCY", fmt.Sprintf("%.1f per week", m.PerWeek))
+	if m.MTTRSamples > 0 {
+		output.Item("MTTR", fmt.Sprintf("%s (from %d of %d resolved incidents with start and resolve times)",
+			formatIncidentDuration(m.MTTRHours), m.MTTRSamples, m.Resolved))
+	} else {
+		output.Item("MTTR", "n/a (add Started:/Resolved: times to incidents)")
+	}
+}
+
+// formatIncidentDuration renders hours as minutes, hours or days
+func formatIncidentDuration(hours float64) string {
+	switch {
+	case hours < 1:
+		return fmt.Sprintf("%.0fm", hours*60)
+	case hours < 48:
+		return fmt.Sprintf("%.1fh", hours)
+	default:
+		return fmt.Sprintf("%.1fd", hours/24)
+	}
+}
+
 // simplifyText extracts key phrases from text
 func simplifyText(text string) string {
 	// Extract first meaningful phrase
@@ -705,13 +1616,3 @@ func simplifyText(text string) string {
 	return strings.ToLower(text)
 }
 
-// expandPath expands ~ to home directory
-func expandPath(path string) string {
-	if strings.HasPrefix(path, "~") {
-		home, err := os.UserHomeDir()
-		if err == nil {
-			return strings.Replace(path, "~", home, 1)
-		}
-	}
-	return path
-}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coryzibell/matrix/internal/identity"
+	"github.com/coryzibell/matrix/internal/ram"
+)
+
+func TestExtractTimeline(t *testing.T) {
+	content := `# Incident: checkout outage
+
+## Root Cause
+
+1. Connection pool exhausted
+
+## Timeline
+
+- 10:32 - deploy of v2.3 finished
+- **10:41** — error rate alarm fired
+1. Paged on-call
+2. Rolled back v2.3
+T+45m: traffic recovered
+
+## Notes
+
+2024-03-01 14:05 UTC: postmortem scheduled
+3. not a timeline step
+`
+
+	events := extractTimeline(strings.Split(content, "\n"))
+
+	want := []TimelineEvent{
+		{When: "10:32", Description: "deploy of v2.3 finished"},
+		{When: "10:41", Description: "error rate alarm fired"},
+		{When: "step 1", Description: "Paged on-call"},
+		{When: "step 2", Description: "Rolled back v2.3"},
+		{When: "T+45m", Description: "traffic recovered"},
+		{When: "2024-03-01 14:05 UTC", Description: "postmortem scheduled"},
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+}
+
+func TestVerifyFixLines(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "handler.go")
+	if err := os.WriteFile(source, []byte(strings.Repeat("line\n", 50)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	incident := IncidentData{
+		Title: "checkout outage",
+		Fixes: []Fix{
+			{File: source, Lines: "10-20"},
+			{File: source, Lines: "50"},
+			{File: source, Lines: "45-80"},
+			{File: source, Lines: "120-140"},
+			{File: source, Lines: "30-12"},
+			{File: source, Function: "retry"},
+			{File: filepath.Join(dir, "deleted.go"), Lines: "1-5"},
+		},
+	}
+
+	checked, stale := verifyFixLines(incident)
+
+	if checked != 5 {
+		t.Errorf("Expected 5 checked references (no range and missing file skipped), got %d", checked)
+	}
+	wantReasons := map[string]string{
+		"45-80":   "range ends past end of file",
+		"120-140": "range starts past end of file",
+		"30-12":   "invalid range",
+	}
+	if len(stale) != len(wantReasons) {
+		t.Fatalf("Expected %d stale references, got %+v", len(wantReasons), stale)
+	}
+	for _, ref := range stale {
+		if ref.Reason != wantReasons[ref.Lines] {
+			t.Errorf("Lines %s: expected reason %q, got %q", ref.Lines, wantReasons[ref.Lines], ref.Reason)
+		}
+		if ref.FileLines != 50 || ref.Incident != "checkout outage" {
+			t.Errorf("Unexpected stale reference: %+v", ref)
+		}
+	}
+}
+
+func TestWriteIncidentFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+
+	incidents := []IncidentData{
+		{Title: "Checkout outage!", Status: "resolved"},
+		{Title: "checkout outage", Status: "open"},
+		{Title: "", FilePath: "/ram/trinity/db-lock.md"},
+	}
+
+	written, err := writeIncidentFiles(incidents, dir)
+	if err != nil {
+		t.Fatalf("writeIncidentFiles() failed: %v", err)
+	}
+
+	want := []string{"checkout-outage.json", "checkout-outage-2.json", "db-lock.json"}
+	if len(written) != len(want) {
+		t.Fatalf("Expected %d files, got %v", len(want), written)
+	}
+	for i, name := range want {
+		if filepath.Base(written[i]) != name {
+			t.Errorf("file %d = %s, want %s", i, filepath.Base(written[i]), name)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "checkout-outage-2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"status": "open"`) {
+		t.Errorf("Unexpected file content: %s", data)
+	}
+}
+
+func TestDetectIncidentStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"fixed with files modified", "# Bug\n## Root Cause\nrace\n## Files Modified\n- /src/a.go: Line 10\n", incidentResolved},
+		{"explicit open status", "# Bug\n**Status:** Open\n## Files Modified\n- /src/a.go\n", incidentOpen},
+		{"explicit resolved beats missing fixes", "# Bug\n- Status: resolved\nroot cause: race\n", incidentResolved},
+		{"no fixes section", "# Bug\n## Root Cause\nrace in the pool\nProblem: checkout hangs\n", incidentOpen},
+		{"still failing", "# Bug\n## Files Modified\n- /src/a.go\nResult: integration suite still failing\n", incidentOpen},
+		{"todo in resolution", "# Bug\n## Resolution\nTODO: confirm the retry fix\n## Files Modified\n- /src/a.go\n", incidentOpen},
+		{"todo elsewhere", "# Bug\n## Notes\nTODO: write blog post\n## Files Modified\n- /src/a.go\n", incidentResolved},
+	}
+
+	for _, tt := range tests {
+		if got := detectIncidentStatus(strings.Split(tt.content, "\n")); got != tt.want {
+			t.Errorf("%s: status = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSortIncidentsOpenFirst(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	incidents := []IncidentData{
+		{Title: "old resolved", Status: incidentResolved, Timestamp: day(1)},
+		{Title: "new resolved", Status: incidentResolved, Timestamp: day(9)},
+		{Title: "old open", Status: incidentOpen, Timestamp: day(2)},
+		{Title: "new open", Status: incidentOpen, Timestamp: day(5)},
+	}
+
+	sortIncidents(incidents)
+
+	want := []string{"new open", "old open", "new resolved", "old resolved"}
+	for i, title := range want {
+		if incidents[i].Title != title {
+			t.Errorf("position %d = %s, want %s", i, incidents[i].Title, title)
+		}
+	}
+}
+
+func TestParseStructuredIncident(t *testing.T) {
+	content := `{
+  "title": "Cache stampede",
+  "timestamp": "2024-05-01T10:00:00Z",
+  "root_causes": ["TTLs expired together", {"issue": "No jitter", "location": "cache.go:40"}],
+  "fixes": [{"file": "cache.go", "lines": "40-52", "function": "Get"}, "config.yaml"],
+  "insights": ["Add jitter to TTLs"],
+  "tests": {"before": 3, "after": 0, "fixed": 3}
+}`
+	incident, err := loadIncident(ram.File{Path: "/ram/trinity/stampede.json", Content: content})
+	if err != nil {
+		t.Fatalf("loadIncident() failed: %v", err)
+	}
+	if incident.Title != "Cache stampede" || incident.Status != incidentResolved {
+		t.Errorf("Unexpected incident: %+v", incident)
+	}
+	if !incident.Timestamp.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Timestamp = %v", incident.Timestamp)
+	}
+	if len(incident.RootCauses) != 2 || incident.RootCauses[0].Detail != "TTLs expired together" ||
+		incident.RootCauses[1].Detail != "No jitter" || incident.RootCauses[1].Location != "cache.go:40" {
+		t.Errorf("Unexpected root causes: %+v", incident.RootCauses)
+	}
+	if len(incident.Fixes) != 2 || incident.Fixes[0].Function != "Get" || incident.Fixes[1].File != "config.yaml" {
+		t.Errorf("Unexpected fixes: %+v", incident.Fixes)
+	}
+	if incident.Tests == nil || incident.Tests.Fixed != 3 {
+		t.Errorf("Unexpected tests: %+v", incident.Tests)
+	}
+
+	// Files written by --output read back with their status intact
+	archived, err := json.Marshal(toIncidentJSON(IncidentData{
+		Title:      "Deadlock",
+		Status:     incidentOpen,
+		RootCauses: []RootCause{{Issue: "Lock order"}},
+		Fixes:      []Fix{{File: "db.go"}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	incident, err = loadIncident(ram.File{Path: "deadlock.json", Content: string(archived)})
+	if err != nil || incident.Title != "Deadlock" || incident.Status != incidentOpen {
+		t.Errorf("Unexpected round trip: %+v, %v", incident, err)
+	}
+
+	// No fixes and no status means still open
+	incident, err = loadIncident(ram.File{Path: "x.json", Content: `{"title": "Flaky CI", "root_causes": ["race"]}`})
+	if err != nil || incident.Status != incidentOpen {
+		t.Errorf("Expected open incident, got %+v, %v", incident, err)
+	}
+
+	for _, content := range []string{`{"title": "Notes"}`, `["not", "an", "incident"]`, `{"fixes": ["a.go"]}`} {
+		if _, err := loadIncident(ram.File{Path: "x.json", Content: content}); err == nil {
+			t.Errorf("Expected %s to be rejected", content)
+		}
+	}
+}
+
+func TestExtractIncidentTimes(t *testing.T) {
+	lines := strings.Split(`# Queue backlog
+
+- **Started:** 2024-05-01 09:30
+- Detected: 2024-05-01 09:45
+Resolved: 2024-05-01T13:00:00Z
+Closed: when the dashboards were green
+`, "\n")
+
+	started, resolved := extractIncidentTimes(lines)
+	if !started.Equal(time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local)) {
+		t.Errorf("started = %v", started)
+	}
+	if !resolved.Equal(time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("resolved = %v", resolved)
+	}
+
+	incident, err := loadIncident(ram.File{Path: "x.json", Content: `{"title": "Outage", "started_at": "2024-05-02T08:00:00Z",
+		"resolved_at": "2024-05-02T10:30:00Z", "fixes": ["lb.go"]}`})
+	if err != nil || incident.ResolvedAt.Sub(incident.Timestamp) != 150*time.Minute {
+		t.Errorf("Expected 2.5h outage from JSON, got %+v, %v", incident, err)
+	}
+}
+
+func TestComputeIncidentMetrics(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	incidents := []IncidentData{
+		{Title: "a", Status: incidentResolved, Timestamp: day(3), ResolvedAt: day(3).Add(2 * time.Hour)},
+		{Title: "b", Status: incidentResolved, Timestamp: day(10), ResolvedAt: day(10).Add(6 * time.Hour)},
+		{Title: "c", Status: incidentResolved, Timestamp: day(20)}, // no resolve time
+		{Title: "d", Status: incidentOpen, Timestamp: day(1)},
+		{Title: "e", Status: incidentResolved, Timestamp: day(40), ResolvedAt: day(40).Add(48 * time.Hour)},
+	}
+
+	m := computeIncidentMetrics(incidents, now, 28)
+	if m.Incidents != 4 || m.Open != 1 || m.Resolved != 3 {
+		t.Errorf("Unexpected counts: %+v", m)
+	}
+	if m.PerWeek != 1 {
+		t.Errorf("PerWeek = %v, want 1", m.PerWeek)
+	}
+	if m.MTTRSamples != 2 || m.MTTRHours != 4 {
+		t.Errorf("MTTR = %vh over %d, want 4h over 2", m.MTTRHours, m.MTTRSamples)
+	}
+	if m.Since != "2024-06-02" {
+		t.Errorf("Since = %s", m.Since)
+	}
+
+	// All time runs from the oldest incident
+	m = computeIncidentMetrics(incidents, now, 0)
+	if m.Incidents != 5 || m.Since != "2024-05-21" || m.MTTRSamples != 3 {
+		t.Errorf("Unexpected all-time metrics: %+v", m)
+	}
+	if m.PerWeek != 5/(40.0/7) {
+		t.Errorf("PerWeek = %v", m.PerWeek)
+	}
+}
+
+func TestCorroborateIncident(t *testing.T) {
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	resolved := started.Add(3 * time.Hour)
+	run := func(test, result string, at time.Time) VerdictEntry {
+		return VerdictEntry{Type: "test", Component: "auth", Test: test, Result: result, Timestamp: at}
+	}
+	incident := IncidentData{Timestamp: started, ResolvedAt: resolved, Tests: &TestResults{Fixed: 2}}
+
+	entries := []VerdictEntry{
+		run("login", "fail", started.Add(time.Hour)),
+		run("refresh", "fail", started.Add(time.Hour)),
+		run("login", "pass", resolved.Add(time.Hour)),
+		run("refresh", "pass", resolved.Add(2*time.Hour)),
+		// Other components and runs far from the fix don't count
+		run("logout", "fail", resolved.Add(30*24*time.Hour)),
+		{Type: "test", Component: "billing", Test: "charge", Result: "fail", Timestamp: resolved.Add(time.Hour)},
+	}
+
+	c := corroborateIncident(incident, entries, "Auth", incidentVerdictWindow)
+	if c.Status != verdictsCorroborated || c.Fixed != 2 || c.PassingAfter != 2 {
+		t.Errorf("Expected corroborated 2 → 2, got %+v", c)
+	}
+
+	// A test that keeps failing after the fix contradicts it
+	entries = append(entries, run("login", "fail", resolved.Add(5*time.Hour)))
+	c = corroborateIncident(incident, entries, "auth", incidentVerdictWindow)
+	if c.Status != verdictsContradicted || c.FailingAfter != 1 || len(c.StillFailing) != 1 || c.StillFailing[0] != "login" {
+		t.Errorf("Expected contradicted by login, got %+v", c)
+	}
+
+	// Passing runs after the fix with no recorded failures before it
+	c = corroborateIncident(incident, entries[2:4], "auth", incidentVerdictWindow)
+	if c.Status != verdictsUnconfirmed {
+		t.Errorf("Expected unconfirmed, got %+v", c)
+	}
+
+	if c = corroborateIncident(incident, nil, "auth", incidentVerdictWindow); c.Status != verdictsNoData {
+		t.Errorf("Expected no-data, got %+v", c)
+	}
+}
+
+// groupedIncidents has two incidents with the same root cause in different
+// words and one with no root cause recorded
+func groupedIncidents() []IncidentData {
+	day := func(month, d int) time.Time { return time.Date(2024, time.Month(month), d, 9, 0, 0, 0, time.UTC) }
+	return []IncidentData{
+		{
+			Title:     "Pool exhausted",
+			Timestamp: day(5, 1),
+			RootCauses: []RootCause{
+				{Detail: "Connection pool exhausted under load spikes"},
+				{Detail: "connection pool exhausted under load again"},
+			},
+			Fixes: []Fix{{File: "db.go"}, {File: "db.go"}, {File: "pool.go"}},
+		},
+		{
+			Title:      "Pool exhausted again",
+			Timestamp:  day(6, 1),
+			RootCauses: []RootCause{{Detail: "Connection pool exhausted under load"}},
+			Fixes:      []Fix{{File: "db.go"}},
+		},
+		{
+			Title:     "Bad deploy",
+			Timestamp: day(4, 1),
+			Fixes:     []Fix{{File: "deploy.sh"}},
+		},
+	}
+}
+
+func TestGroupIncidentsByRootCause(t *testing.T) {
+	groups := groupIncidents(groupedIncidents(), "root-cause")
+
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d: %+v", len(groups), groups)
+	}
+	top := groups[0]
+	if top.Key != "connection pool exhausted under load" || top.Count != 2 || top.LastSeen != "2024-06-01" {
+		t.Errorf("Unexpected top group: %+v", top)
+	}
+	if len(top.Examples) != 2 || top.Examples[0] != "Pool exhausted" {
+		t.Errorf("Examples = %v", top.Examples)
+	}
+	if groups[1].Key != "(none recorded)" || groups[1].Count != 1 {
+		t.Errorf("Expected the incident without a cause in its own group: %+v", groups[1])
+	}
+}
+
+func TestGroupIncidentsByFile(t *testing.T) {
+	groups := groupIncidents(groupedIncidents(), "file")
+
+	var got []string
+	for _, g := range groups {
+		got = append(got, fmt.Sprintf("%s=%d", g.Key, g.Count))
+	}
+	want := []string{"db.go=2", "deploy.sh=1", "pool.go=1"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+}
+
+func TestIncidentTraceGroupBy(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ramDir := t.TempDir()
+	t.Setenv(identity.RAMDirEnv, ramDir)
+	writeFixture(t, ramDir, map[string]string{
+		"trinity/pool.json":  `{"title": "Pool exhausted", "timestamp": "2024-05-01T09:00:00Z", "root_causes": ["Pool too small"], "fixes": ["db.go"]}`,
+		"trinity/again.json": `{"title": "Pool exhausted again", "timestamp": "2024-06-01T09:00:00Z", "root_causes": ["Pool too small"], "fixes": ["db.go", "pool.go"]}`,
+	})
+
+	out, err := captureStdout(t, []string{"matrix", "incident-trace", "--all", "--group-by", "file", "--json"}, runIncidentTrace)
+	if err != nil {
+		t.Fatalf("runIncidentTrace failed: %v", err)
+	}
+	var groups []IncidentGroup
+	if err := json.Unmarshal([]byte(out), &groups); err != nil {
+		t.Fatalf("Output is not a list of groups: %v\n%s", err, out)
+	}
+	if len(groups) != 2 || groups[0].Key != "db.go" || groups[0].Count != 2 || groups[0].LastSeen != "2024-06-01" {
+		t.Errorf("Unexpected groups: %+v", groups)
+	}
+
+	out, err = captureStdout(t, []string{"matrix", "incident-trace", "--all", "--group-by=root-cause"}, runIncidentTrace)
+	if err != nil {
+		t.Fatalf("runIncidentTrace failed: %v", err)
+	}
+	if !strings.Contains(out, "INCIDENT GROUPS by root-cause (2 incidents, 1 groups)") {
+		t.Errorf("Expected one root-cause group:\n%s", out)
+	}
+
+	_, err = captureStdout(t, []string{"matrix", "incident-trace", "--all", "--group-by", "owner"}, runIncidentTrace)
+	if err == nil || !strings.Contains(err.Error(), "invalid --group-by") {
+		t.Errorf("Expected an invalid --group-by error, got %v", err)
+	}
+}
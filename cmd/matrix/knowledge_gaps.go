@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -11,6 +13,7 @@ import (
 	"github.com/coryzibell/matrix/internal/identity"
 	"github.com/coryzibell/matrix/internal/output"
 	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // GapType represents category of knowledge gap
@@ -24,11 +27,27 @@ const (
 
 // Gap represents a detected knowledge gap
 type Gap struct {
-	Type     GapType
-	FilePath string
-	Identity string
-	LineNum  int
-	Quote    string
+	Type     GapType `json:"type"`
+	FilePath string  `json:"file"`
+	Identity string  `json:"identity"`
+	LineNum  int     `json:"line"`
+	Quote    string  `json:"quote"`
+}
+
+// GapSummary holds the counts shown at the end of a knowledge-gaps report
+type GapSummary struct {
+	Questions    int      `json:"questions"`
+	Todos        int      `json:"todos"`
+	Complexity   int      `json:"complexity"`
+	Total        int      `json:"total"`
+	Identities   []string `json:"identities"`
+	FilesScanned int      `json:"files_scanned"`
+}
+
+// GapReport is the JSON output of knowledge-gaps
+type GapReport struct {
+	Gaps    []Gap      `json:"gaps"`
+	Summary GapSummary `json:"summary"`
 }
 
 // GapGroup groups gaps by type
@@ -44,6 +63,49 @@ type FileGaps struct {
 	Gaps     []Gap
 }
 
+// GapLimits holds the thresholds that make knowledge-gaps exit non-zero.
+// A negative limit disables that check.
+type GapLimits struct {
+	Questions int
+	Todos     int
+	Total     int
+}
+
+// GapPatterns holds the compiled detection patterns for each gap type
+type GapPatterns struct {
+	Questions  []*regexp.Regexp
+	Todos      []*regexp.Regexp
+	Complexity []*regexp.Regexp
+}
+
+// gapPatternFile is the format of a --patterns file. Each list adds regexes
+// to the built-in ones for that gap type.
+type gapPatternFile struct {
+	Questions  []string `json:"questions"`
+	Todos      []string `json:"todos"`
+	Complexity []string `json:"complexity"`
+}
+
+// gapDefaultAnswerWindow is how many lines after a question are searched
+// for its answer before the question counts as a gap
+const gapDefaultAnswerWindow = 3
+
+// gapAnswerMarker matches lines that explicitly answer the question above,
+// after any list, quote, or bold markup
+var gapAnswerMarker = regexp.MustCompile(`^(?:[-*>]\s*)*(?:\*\*)?(?:a:|a\.|answer\b|ans:|resolved\b|solution\b|turns out\b)`)
+
+// gapWordPattern splits lines into words for the question/answer overlap check
+var gapWordPattern = regexp.MustCompile(`[a-z0-9_]+`)
+
+// gapStopWords are long-enough words that say nothing about a topic
+var gapStopWords = map[string]bool{
+	"about": true, "after": true, "also": true, "because": true, "been": true,
+	"could": true, "does": true, "from": true, "have": true, "into": true,
+	"just": true, "should": true, "that": true, "there": true, "these": true,
+	"they": true, "this": true, "what": true, "when": true, "where": true,
+	"which": true, "will": true, "with": true, "would": true,
+}
+
 // runKnowledgeGaps implements the knowledge-gaps command
 func runKnowledgeGaps() error {
 	// Parse flags
@@ -53,9 +115,38 @@ func runKnowledgeGaps() error {
 	showComplexity := flags.Bool("complexity", false, "Show only high-complexity areas")
 	detailed := flags.Bool("detailed", false, "Include context around findings")
 	filterIdentity := flags.String("identity", "", "Filter to specific identity")
+	failOnQuestions := flags.Int("fail-on-questions", -1, "Exit non-zero if unanswered questions exceed N")
+	failOnTodos := flags.Int("fail-on-todos", -1, "Exit non-zero if documentation TODOs exceed N")
+	maxGaps := flags.Int("max-gaps", -1, "Exit non-zero if total reported gaps exceed N")
+	jsonOutput := flags.Bool("json", false, "Output gaps and summary as JSON")
+	patternsFile := flags.String("patterns", "", "JSON file of extra regexes per gap type (questions, todos, complexity)")
+	strict := flags.Bool("strict", false, "Report questions even when an answer follows them")
+	answerWindow := flags.Int("answer-window", gapDefaultAnswerWindow, "Lines after a question searched for its answer")
 
 	flags.Parse(os.Args[2:])
 
+	if *answerWindow < 0 {
+		return fmt.Errorf("--answer-window must be 0 or more, got %d", *answerWindow)
+	}
+	if *strict {
+		*answerWindow = 0
+	}
+
+	patterns := defaultGapPatterns()
+	if *patternsFile != "" {
+		var err error
+		patterns, err = loadGapPatterns(util.ExpandPath(*patternsFile))
+		if err != nil {
+			return err
+		}
+	}
+
+	limits := GapLimits{
+		Questions: *failOnQuestions,
+		Todos:     *failOnTodos,
+		Total:     *maxGaps,
+	}
+
 	// Determine which types to show
 	showAll := !*showQuestions && !*showTodos && !*showComplexity
 	showTypes := make(map[GapType]bool)
@@ -77,55 +168,63 @@ func runKnowledgeGaps() error {
 
 	// Check if RAM exists
 	if _, err := os.Stat(ramDir); os.IsNotExist(err) {
+		if *jsonOutput {
+			return outputGapsJSON(nil, 0)
+		}
 		fmt.Println("🌾 No RAM found at ~/.claude/ram/ - nothing to scan yet")
 		return nil
 	}
 
-	// Scan RAM directory
-	files, err := ram.ScanDir(ramDir)
-	if err != nil {
-		return fmt.Errorf("failed to scan RAM directory: %w", err)
-	}
-
-	if len(files) == 0 {
-		fmt.Println("🌾 RAM exists but no markdown files found yet")
-		return nil
-	}
-
-	// Filter by identity if requested
+	// Scan RAM directory, or only the requested identity's directory
+	var files []ram.File
 	if *filterIdentity != "" {
 		normalizedFilter := strings.ToLower(strings.TrimSpace(*filterIdentity))
 		if !identity.IsValid(normalizedFilter) {
 			return fmt.Errorf("invalid identity: %s", *filterIdentity)
 		}
 
-		var filtered []ram.File
-		for _, f := range files {
-			if f.Identity == normalizedFilter {
-				filtered = append(filtered, f)
-			}
+		files, err = ram.ScanIdentity(normalizedFilter)
+		if err != nil {
+			return fmt.Errorf("failed to scan RAM directory: %w", err)
 		}
-		files = filtered
 
 		if len(files) == 0 {
+			if *jsonOutput {
+				return outputGapsJSON(nil, 0)
+			}
 			fmt.Printf("No files found for identity: %s\n", normalizedFilter)
 			return nil
 		}
+	} else {
+		files, err = ram.ScanDir(ramDir)
+		if err != nil {
+			return fmt.Errorf("failed to scan RAM directory: %w", err)
+		}
+
+		if len(files) == 0 {
+			if *jsonOutput {
+				return outputGapsJSON(nil, 0)
+			}
+			fmt.Println("🌾 RAM exists but no markdown files found yet")
+			return nil
+		}
 	}
 
-	output.Success("🔍 Knowledge Gaps Report")
-	fmt.Println("")
-	if *filterIdentity != "" {
-		fmt.Printf("Filtering to identity: %s\n", *filterIdentity)
+	if !*jsonOutput {
+		output.Success("🔍 Knowledge Gaps Report")
+		fmt.Println("")
+		if *filterIdentity != "" {
+			fmt.Printf("Filtering to identity: %s\n", *filterIdentity)
+			fmt.Println("")
+		}
+		fmt.Println("Scanning for unanswered questions and missing documentation...")
 		fmt.Println("")
 	}
-	fmt.Println("Scanning for unanswered questions and missing documentation...")
-	fmt.Println("")
 
 	// Scan all files for gaps
 	var allGaps []Gap
 	for _, file := range files {
-		gaps := detectKnowledgeGaps(file)
+		gaps := detectKnowledgeGaps(file, patterns, *answerWindow)
 		allGaps = append(allGaps, gaps...)
 	}
 
@@ -137,9 +236,18 @@ func runKnowledgeGaps() error {
 		}
 	}
 
+	limitErr := checkGapLimits(allGaps, filteredGaps, limits)
+
+	if *jsonOutput {
+		if err := outputGapsJSON(filteredGaps, len(files)); err != nil {
+			return err
+		}
+		return limitErr
+	}
+
 	if len(filteredGaps) == 0 {
 		fmt.Println("✨ No knowledge gaps detected - documentation is complete")
-		return nil
+		return limitErr
 	}
 
 	// Display results
@@ -152,11 +260,78 @@ func runKnowledgeGaps() error {
 	fmt.Println("")
 	displayGapSummary(filteredGaps, len(files))
 
+	return limitErr
+}
+
+// outputGapsJSON emits the reported gaps and their summary as JSON
+func outputGapsJSON(gaps []Gap, filesScanned int) error {
+	if gaps == nil {
+		gaps = []Gap{}
+	}
+	return output.EmitJSON(GapReport{
+		Gaps:    gaps,
+		Summary: summarizeGaps(gaps, filesScanned),
+	})
+}
+
+// summarizeGaps counts gaps by type and lists the affected identities
+func summarizeGaps(gaps []Gap, filesScanned int) GapSummary {
+	summary := GapSummary{
+		Total:        len(gaps),
+		Identities:   []string{},
+		FilesScanned: filesScanned,
+	}
+
+	identitySet := make(map[string]bool)
+	for _, gap := range gaps {
+		switch gap.Type {
+		case GapQuestion:
+			summary.Questions++
+		case GapTodo:
+			summary.Todos++
+		case GapComplexity:
+			summary.Complexity++
+		}
+		identitySet[gap.Identity] = true
+	}
+
+	for id := range identitySet {
+		summary.Identities = append(summary.Identities, id)
+	}
+	sort.Strings(summary.Identities)
+
+	return summary
+}
+
+// checkGapLimits returns an error when gap counts exceed the configured limits.
+// Per-type limits count every detected gap so display filters can't hide them;
+// the total limit applies to the gaps actually reported.
+func checkGapLimits(allGaps, reported []Gap, limits GapLimits) error {
+	typeCounts := make(map[GapType]int)
+	for _, gap := range allGaps {
+		typeCounts[gap.Type]++
+	}
+
+	var exceeded []string
+	if limits.Questions >= 0 && typeCounts[GapQuestion] > limits.Questions {
+		exceeded = append(exceeded, fmt.Sprintf("%d unanswered questions (limit %d)", typeCounts[GapQuestion], limits.Questions))
+	}
+	if limits.Todos >= 0 && typeCounts[GapTodo] > limits.Todos {
+		exceeded = append(exceeded, fmt.Sprintf("%d documentation TODOs (limit %d)", typeCounts[GapTodo], limits.Todos))
+	}
+	if limits.Total >= 0 && len(reported) > limits.Total {
+		exceeded = append(exceeded, fmt.Sprintf("%d knowledge gaps (limit %d)", len(reported), limits.Total))
+	}
+
+	if len(exceeded) > 0 {
+		return fmt.Errorf("knowledge gap limits exceeded: %s", strings.Join(exceeded, ", "))
+	}
 	return nil
 }
 
-// detectKnowledgeGaps scans a file for knowledge gaps
-func detectKnowledgeGaps(file ram.File) []Gap {
+// detectKnowledgeGaps scans a file for knowledge gaps. Questions answered
+// within answerWindow lines are skipped; 0 reports every question.
+func detectKnowledgeGaps(file ram.File, patterns GapPatterns, answerWindow int) []Gap {
 	var gaps []Gap
 	lines := strings.Split(file.Content, "\n")
 
@@ -174,7 +349,10 @@ func detectKnowledgeGaps(file ram.File) []Gap {
 		}
 
 		// Check for questions
-		if matchesPattern(lineLower, questionPatterns()) {
+		if matchesPattern(lineLower, patterns.Questions) {
+			if answeredNearby(lines, lineNum, answerWindow, patterns) {
+				continue
+			}
 			gaps = append(gaps, Gap{
 				Type:     GapQuestion,
 				FilePath: relativePath,
@@ -186,7 +364,7 @@ func detectKnowledgeGaps(file ram.File) []Gap {
 		}
 
 		// Check for documentation TODOs
-		if matchesPattern(lineLower, todoPatterns()) {
+		if matchesPattern(lineLower, patterns.Todos) {
 			gaps = append(gaps, Gap{
 				Type:     GapTodo,
 				FilePath: relativePath,
@@ -198,7 +376,7 @@ func detectKnowledgeGaps(file ram.File) []Gap {
 		}
 
 		// Check for complexity markers
-		if matchesPattern(lineLower, complexityPatterns()) {
+		if matchesPattern(lineLower, patterns.Complexity) {
 			gaps = append(gaps, Gap{
 				Type:     GapComplexity,
 				FilePath: relativePath,
@@ -213,6 +391,105 @@ func detectKnowledgeGaps(file ram.File) []Gap {
 	return gaps
 }
 
+// answeredNearby reports whether one of the window lines after a question
+// answers it: an explicit "A:"/"Answer" line, or a declarative sentence that
+// shares a topic word with the question. A new heading ends the search.
+func answeredNearby(lines []string, questionLine, window int, patterns GapPatterns) bool {
+	questionWords := gapTopicWords(lines[questionLine])
+
+	for i := questionLine + 1; i < len(lines) && i <= questionLine+window; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		lower := strings.ToLower(trimmed)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			return false
+		}
+		if gapAnswerMarker.MatchString(lower) {
+			return true
+		}
+		if matchesPattern(lower, patterns.Questions) || matchesPattern(lower, patterns.Todos) {
+			continue
+		}
+		if !strings.HasSuffix(trimmed, ".") || len(strings.Fields(trimmed)) < 3 {
+			continue
+		}
+		for word := range gapTopicWords(trimmed) {
+			if questionWords[word] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gapTopicWords returns the lowercased words of a line worth comparing
+func gapTopicWords(line string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range gapWordPattern.FindAllString(strings.ToLower(line), -1) {
+		if len(word) >= 4 && !gapStopWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// defaultGapPatterns returns the built-in detection patterns
+func defaultGapPatterns() GapPatterns {
+	return GapPatterns{
+		Questions:  questionPatterns(),
+		Todos:      todoPatterns(),
+		Complexity: complexityPatterns(),
+	}
+}
+
+// loadGapPatterns reads a --patterns file and merges its regexes with the
+// built-in patterns. Lines are matched lowercased, so custom patterns are
+// compiled case-insensitively. All bad regexes are reported together.
+func loadGapPatterns(path string) (GapPatterns, error) {
+	patterns := defaultGapPatterns()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return patterns, fmt.Errorf("failed to read patterns file: %w", err)
+	}
+
+	var custom gapPatternFile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&custom); err != nil {
+		return patterns, fmt.Errorf("failed to parse patterns file %s (expected keys: questions, todos, complexity): %w", path, err)
+	}
+
+	var problems []string
+	compile := func(category string, exprs []string) []*regexp.Regexp {
+		var compiled []*regexp.Regexp
+		for i, expr := range exprs {
+			if strings.TrimSpace(expr) == "" {
+				problems = append(problems, fmt.Sprintf("%s pattern %d: empty pattern", category, i+1))
+				continue
+			}
+			if _, err := regexp.Compile(expr); err != nil {
+				problems = append(problems, fmt.Sprintf("%s pattern %d: %v", category, i+1, err))
+				continue
+			}
+			compiled = append(compiled, regexp.MustCompile("(?i)"+expr))
+		}
+		return compiled
+	}
+
+	patterns.Questions = append(patterns.Questions, compile("questions", custom.Questions)...)
+	patterns.Todos = append(patterns.Todos, compile("todos", custom.Todos)...)
+	patterns.Complexity = append(patterns.Complexity, compile("complexity", custom.Complexity)...)
+
+	if len(problems) > 0 {
+		return patterns, fmt.Errorf("invalid patterns in %s:\n  %s", path, strings.Join(problems, "\n  "))
+	}
+
+	return patterns, nil
+}
+
 // Pattern matching functions
 func questionPatterns() []*regexp.Regexp {
 	patterns := []string{
@@ -380,10 +657,7 @@ func displayDetailedGaps(gaps []Gap, showTypes map[GapType]bool) {
 				strings.ToLower(string(gapType)))
 
 			for _, gap := range typeGaps {
-				quote := gap.Quote
-				if len(quote) > 100 {
-					quote = quote[:97] + "..."
-				}
+				quote := util.Truncate(gap.Quote, 100)
 				fmt.Printf("    → %s\n", quote)
 			}
 			fmt.Println("")
@@ -435,10 +709,7 @@ func displayGapGroup(group GapGroup) {
 
 		for i := 0; i < limit; i++ {
 			gap := gaps[i]
-			quote := gap.Quote
-			if len(quote) > 100 {
-				quote = quote[:97] + "..."
-			}
+			quote := util.Truncate(gap.Quote, 100)
 			fmt.Printf("    → %s\n", quote)
 		}
 
@@ -480,43 +751,29 @@ func displayGapSummary(gaps []Gap, filesScanned int) {
 	fmt.Println(strings.Repeat("━", 70))
 	fmt.Println("")
 
-	// Count by type
-	typeCounts := make(map[GapType]int)
-	for _, gap := range gaps {
-		typeCounts[gap.Type]++
-	}
+	summary := summarizeGaps(gaps, filesScanned)
 
-	if count, ok := typeCounts[GapQuestion]; ok && count > 0 {
-		fmt.Printf("  - %d unanswered questions\n", count)
+	// Count by type
+	if summary.Questions > 0 {
+		fmt.Printf("  - %d unanswered questions\n", summary.Questions)
 	}
-	if count, ok := typeCounts[GapTodo]; ok && count > 0 {
-		fmt.Printf("  - %d documentation TODOs\n", count)
+	if summary.Todos > 0 {
+		fmt.Printf("  - %d documentation TODOs\n", summary.Todos)
 	}
-	if count, ok := typeCounts[GapComplexity]; ok && count > 0 {
-		fmt.Printf("  - %d high-complexity areas\n", count)
+	if summary.Complexity > 0 {
+		fmt.Printf("  - %d high-complexity areas\n", summary.Complexity)
 	}
 
 	fmt.Println("")
 
-	// Count affected identities
-	identitySet := make(map[string]bool)
-	for _, gap := range gaps {
-		identitySet[gap.Identity] = true
-	}
-
-	identities := make([]string, 0, len(identitySet))
-	for id := range identitySet {
-		identities = append(identities, id)
-	}
-	sort.Strings(identities)
-
-	fmt.Printf("Affected Identities: %d\n", len(identities))
-	if len(identities) > 0 {
-		fmt.Printf("  %s\n", strings.Join(identities, ", "))
+	// Affected identities
+	fmt.Printf("Affected Identities: %d\n", len(summary.Identities))
+	if len(summary.Identities) > 0 {
+		fmt.Printf("  %s\n", strings.Join(summary.Identities, ", "))
 	}
 	fmt.Println("")
 
-	fmt.Printf("Files Scanned: %d markdown files\n", filesScanned)
+	fmt.Printf("Files Scanned: %d markdown files\n", summary.FilesScanned)
 	fmt.Println("")
 
 	output.Success("🔍 Knowledge gaps surfaced - ready for documentation")
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coryzibell/matrix/internal/ram"
+)
+
+func TestLoadGapPatternsMergesCustom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.json")
+	content := `{"questions": ["^OPEN QUESTION:"], "complexity": ["\\bhairy\\b"]}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	patterns, err := loadGapPatterns(path)
+	if err != nil {
+		t.Fatalf("loadGapPatterns() failed: %v", err)
+	}
+
+	file := ram.File{
+		Path:     "/ram/tank/notes.md",
+		Identity: "tank",
+		Content:  "OPEN QUESTION: retry budget for the sync job\nThe merge logic is hairy.\nWhy does it loop?\nPlain line.",
+	}
+	gaps := detectKnowledgeGaps(file, patterns, gapDefaultAnswerWindow)
+
+	want := []GapType{GapQuestion, GapComplexity, GapQuestion}
+	if len(gaps) != len(want) {
+		t.Fatalf("Expected %d gaps, got %+v", len(want), gaps)
+	}
+	for i, gapType := range want {
+		if gaps[i].Type != gapType {
+			t.Errorf("gap %d type = %s, want %s", i, gaps[i].Type, gapType)
+		}
+	}
+
+	// Defaults alone don't know the team vocabulary
+	if got := detectKnowledgeGaps(file, defaultGapPatterns(), gapDefaultAnswerWindow); len(got) != 1 {
+		t.Errorf("Expected only the default question match, got %+v", got)
+	}
+}
+
+func TestLoadGapPatternsRejectsBadInput(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := map[string]string{
+		"bad-regex.json":   `{"todos": ["(unclosed", "fine"], "questions": ["[z-a]"]}`,
+		"unknown-key.json": `{"question": ["typo in key"]}`,
+		"empty.json":       `{"todos": [""]}`,
+	}
+	for name, content := range cases {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadGapPatterns(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	_, err := loadGapPatterns(filepath.Join(dir, "bad-regex.json"))
+	if err == nil || !strings.Contains(err.Error(), "todos pattern 1") || !strings.Contains(err.Error(), "questions pattern 1") {
+		t.Errorf("Expected every bad regex to be reported, got: %v", err)
+	}
+}
+
+func TestDetectKnowledgeGapsSkipsAnsweredQuestions(t *testing.T) {
+	file := ram.File{
+		Path:     "/ram/tank/notes.md",
+		Identity: "tank",
+		Content: strings.Join([]string{
+			"Why does the sync job retry forever?",
+			"",
+			"A: the backoff cap was never set.",
+			"Which port does the cache listen on?",
+			"The cache listens on 6380 in staging.",
+			"How does eviction work?",
+			"Lunch was good today.",
+			"What is the deploy window?",
+			"## Deploys",
+			"Answer: Tuesdays.",
+		}, "\n"),
+	}
+	patterns := defaultGapPatterns()
+
+	var quotes []string
+	for _, gap := range detectKnowledgeGaps(file, patterns, gapDefaultAnswerWindow) {
+		quotes = append(quotes, gap.Quote)
+	}
+	want := []string{"How does eviction work?", "What is the deploy window?"}
+	if strings.Join(quotes, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected unanswered questions %v, got %v", want, quotes)
+	}
+
+	// --strict reports every question
+	if got := detectKnowledgeGaps(file, patterns, 0); len(got) != 4 {
+		t.Errorf("Expected 4 questions in strict mode, got %+v", got)
+	}
+}
@@ -3,208 +3,102 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/coryzibell/matrix/internal/identity"
+	"github.com/coryzibell/matrix/internal/output"
 )
 
+// version is the matrix release reported by help and doctor
+const version = "v0.0.1"
+
 func main() {
-	// Simple command routing without cobra for now
+	// Global flags may appear anywhere; strip them so commands only see their own
+	args, ramDir, err := extractRAMDirFlag(os.Args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if ramDir != "" {
+		identity.SetRAMDir(ramDir)
+	}
+	args, output.Quiet = extractQuietFlag(args)
+	os.Args = args
+
+	// Commands are dispatched from the registry in commands.go
 	if len(os.Args) < 2 {
-		fmt.Println("matrix v0.0.1")
-		fmt.Println("")
-		fmt.Println("Intelligence tools for the Claude Code identity system.")
-		fmt.Println("Analyzes and surfaces patterns across ~/.claude/ram/")
-		fmt.Println("")
-		fmt.Println("Commands:")
-		fmt.Println("  garden-paths    Discover connections in the matrix garden")
-		fmt.Println("  garden-seeds    Create well-structured RAM files from templates")
-		fmt.Println("  tension-map     Surface conflicts and tensions across RAM")
-		fmt.Println("  velocity        Track task completion velocity by identity")
-		fmt.Println("  recon           Scan codebases and generate intelligence reports")
-		fmt.Println("  incident-trace  Extract structured post-mortem data from debugging sessions")
-		fmt.Println("  crossroads      Capture decision points and paths not taken")
-		fmt.Println("  balance-checker Detect drift between design docs and implementation")
-		fmt.Println("  breach-points   Audit for security vulnerabilities and exposures")
-		fmt.Println("  vault-keys      Map authentication, authorization, and security boundaries")
-		fmt.Println("  flight-check    Track deployment state across identity work")
-		fmt.Println("  knowledge-gaps  Find unanswered questions and missing documentation")
-		fmt.Println("  contract-ledger Track data flows and dependencies between identities")
-		fmt.Println("  schema-catalog  Track database schemas across projects")
-		fmt.Println("  phase-shift     Track cross-language compatibility and migration patterns")
-		fmt.Println("  platform-map    Scan for cross-platform compatibility markers")
-		fmt.Println("  verdict         Track test results and performance metrics")
-		fmt.Println("  question        Surface hidden assumptions behind documented work")
-		fmt.Println("  debt-ledger     Track technical debt markers and generate remediation tasks")
-		fmt.Println("  friction-points Track UX review queue and feedback")
-		fmt.Println("  spec-verify     Verify implementations against formal specifications")
-		fmt.Println("  alt-routes      Accessibility audit and alternative output formats")
-		fmt.Println("  data-harvest    Scan RAM for data patterns to build better fixtures")
-		fmt.Println("  dependency-map  Map installed toolchains and package dependencies")
-		fmt.Println("  diff-paths      Compare two implementations and extract architectural tradeoffs")
+		printMatrixUsage()
 		return
 	}
 
-	cmd := os.Args[1]
+	name := os.Args[1]
+	switch name {
+	case "--help", "-h", "help":
+		printMatrixUsage()
+		return
+	}
 
-	switch cmd {
-	case "garden-paths":
-		if err := runGardenPaths(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "garden-seeds":
-		if err := runGardenSeeds(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "tension-map":
-		if err := runTensionMap(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "velocity":
-		if err := runVelocity(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "recon":
-		if err := runRecon(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "incident-trace":
-		if err := runIncidentTrace(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "crossroads":
-		if err := runCrossroads(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "balance-checker":
-		if err := runBalanceChecker(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "breach-points":
-		if err := runBreachPoints(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "vault-keys":
-		if err := runVaultKeys(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "flight-check":
-		if err := runFlightCheck(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "knowledge-gaps":
-		if err := runKnowledgeGaps(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "contract-ledger":
-		if err := runContractLedger(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "schema-catalog":
-		if err := runSchemaCatalog(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "phase-shift":
-		if err := runPhaseShift(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "platform-map":
-		if err := runPlatformMap(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "verdict":
-		if err := runVerdict(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "question":
-		if err := runQuestion(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "debt-ledger":
-		if err := runDebtLedger(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "friction-points":
-		if err := runFrictionPoints(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "spec-verify":
-		if err := runSpecVerify(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "alt-routes":
-		if err := runAltRoutes(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "data-harvest":
-		if err := runDataHarvest(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+	cmd, ok := findCommand(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", name)
+		fmt.Println("Run 'matrix help' for usage")
+		os.Exit(1)
+	}
+	// Aliases dispatch under the canonical name
+	os.Args[1] = cmd.Name
+	if err := cmd.run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// extractQuietFlag removes a global --quiet from args and reports whether it
+// was given. Arguments after a bare "--" are left alone.
+func extractQuietFlag(args []string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	quiet := false
+
+	for i, arg := range args {
+		if arg == "--" {
+			return append(rest, args[i:]...), quiet
 		}
-	case "dependency-map":
-		if err := runDependencyMap(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+		if arg == "--quiet" || arg == "-quiet" {
+			quiet = true
+			continue
 		}
-	case "diff-paths":
-		if err := runDiffPaths(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+		rest = append(rest, arg)
+	}
+
+	return rest, quiet
+}
+
+// extractRAMDirFlag removes a global --ram-dir <dir> or --ram-dir=<dir> from
+// args and returns the remaining args and the directory. Arguments after a
+// bare "--" are left alone.
+func extractRAMDirFlag(args []string) ([]string, string, error) {
+	rest := make([]string, 0, len(args))
+	ramDir := ""
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return append(rest, args[i:]...), ramDir, nil
+		case arg == "--ram-dir" || arg == "-ram-dir":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return nil, "", fmt.Errorf("--ram-dir requires a directory")
+			}
+			i++
+			ramDir = args[i]
+		case strings.HasPrefix(arg, "--ram-dir=") || strings.HasPrefix(arg, "-ram-dir="):
+			_, ramDir, _ = strings.Cut(arg, "=")
+			if ramDir == "" {
+				return nil, "", fmt.Errorf("--ram-dir requires a directory")
+			}
+		default:
+			rest = append(rest, arg)
 		}
-	case "--help", "-h", "help":
-		fmt.Println("matrix v0.0.1")
-		fmt.Println("")
-		fmt.Println("Intelligence tools for the Claude Code identity system.")
-		fmt.Println("Analyzes and surfaces patterns across ~/.claude/ram/")
-		fmt.Println("")
-		fmt.Println("Commands:")
-		fmt.Println("  garden-paths    Discover connections in the matrix garden")
-		fmt.Println("  garden-seeds    Create well-structured RAM files from templates")
-		fmt.Println("  tension-map     Surface conflicts and tensions across RAM")
-		fmt.Println("  velocity        Track task completion velocity by identity")
-		fmt.Println("  recon           Scan codebases and generate intelligence reports")
-		fmt.Println("  incident-trace  Extract structured post-mortem data from debugging sessions")
-		fmt.Println("  crossroads      Capture decision points and paths not taken")
-		fmt.Println("  balance-checker Detect drift between design docs and implementation")
-		fmt.Println("  breach-points   Audit for security vulnerabilities and exposures")
-		fmt.Println("  vault-keys      Map authentication, authorization, and security boundaries")
-		fmt.Println("  flight-check    Track deployment state across identity work")
-		fmt.Println("  knowledge-gaps  Find unanswered questions and missing documentation")
-		fmt.Println("  contract-ledger Track data flows and dependencies between identities")
-		fmt.Println("  schema-catalog  Track database schemas across projects")
-		fmt.Println("  phase-shift     Track cross-language compatibility and migration patterns")
-		fmt.Println("  platform-map    Scan for cross-platform compatibility markers")
-		fmt.Println("  verdict         Track test results and performance metrics")
-		fmt.Println("  question        Surface hidden assumptions behind documented work")
-		fmt.Println("  debt-ledger     Track technical debt markers and generate remediation tasks")
-		fmt.Println("  friction-points Track UX review queue and feedback")
-		fmt.Println("  spec-verify     Verify implementations against formal specifications")
-		fmt.Println("  alt-routes      Accessibility audit and alternative output formats")
-		fmt.Println("  data-harvest    Scan RAM for data patterns to build better fixtures")
-		fmt.Println("  dependency-map  Map installed toolchains and package dependencies")
-		fmt.Println("  diff-paths      Compare two implementations and extract architectural tradeoffs")
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", cmd)
-		fmt.Println("Run 'matrix help' for usage")
-		os.Exit(1)
 	}
+
+	return rest, ramDir, nil
 }
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coryzibell/matrix/internal/identity"
+)
+
+// matrixTestExecEnv makes the test binary act as matrix itself, for tests
+// of commands that re-run the executable (alt-routes plain)
+const matrixTestExecEnv = "MATRIX_TEST_EXEC"
+
+// TestMain clears MATRIX_RAM_DIR so tests that point HOME at a temp dir
+// never write into a developer's real RAM directory
+func TestMain(m *testing.M) {
+	if os.Getenv(matrixTestExecEnv) == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Unsetenv(identity.RAMDirEnv)
+	os.Exit(m.Run())
+}
+
+// captureStdout runs fn with os.Args set to args and returns what it
+// printed to stdout
+func captureStdout(t *testing.T, args []string, fn func() error) (string, error) {
+	t.Helper()
+	oldArgs, oldStdout := os.Args, os.Stdout
+	defer func() { os.Args, os.Stdout = oldArgs, oldStdout }()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() failed: %v", err)
+	}
+	os.Args, os.Stdout = args, w
+
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- out
+	}()
+	runErr := fn()
+	w.Close()
+	return string(<-done), runErr
+}
+
+// runMatrix runs the test binary as matrix with args and returns its
+// combined output and exit code, for flags that exit the process
+func runMatrix(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatalf("os.Executable() failed: %v", err)
+	}
+	cmd := exec.Command(self, args...)
+	cmd.Env = append(os.Environ(), matrixTestExecEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return string(out), exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatalf("running matrix %v failed: %v", args, err)
+	}
+	return string(out), 0
+}
+
+// writeFixture creates files relative to dir
+func writeFixture(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		full := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+}
+
+func TestExtractRAMDirFlag(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    []string
+		ramDir  string
+		wantErr bool
+	}{
+		{args: []string{"matrix", "velocity", "--json"}, want: []string{"matrix", "velocity", "--json"}},
+		{args: []string{"matrix", "--ram-dir", "/tmp/ram", "velocity"}, want: []string{"matrix", "velocity"}, ramDir: "/tmp/ram"},
+		{args: []string{"matrix", "verdict", "record", "--ram-dir=fixtures/ram", "--component", "api"}, want: []string{"matrix", "verdict", "record", "--component", "api"}, ramDir: "fixtures/ram"},
+		{args: []string{"matrix", "recon", "--", "--ram-dir", "x"}, want: []string{"matrix", "recon", "--", "--ram-dir", "x"}},
+		{args: []string{"matrix", "velocity", "--ram-dir"}, wantErr: true},
+		{args: []string{"matrix", "--ram-dir=", "velocity"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, ramDir, err := extractRAMDirFlag(tt.args)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%v: expected an error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tt.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) || ramDir != tt.ramDir {
+			t.Errorf("%v: got %v, %q; want %v, %q", tt.args, got, ramDir, tt.want, tt.ramDir)
+		}
+	}
+}
+
+func TestExtractQuietFlag(t *testing.T) {
+	got, quiet := extractQuietFlag([]string{"matrix", "--quiet", "recon", "--", "--quiet"})
+	if !quiet || !reflect.DeepEqual(got, []string{"matrix", "recon", "--", "--quiet"}) {
+		t.Errorf("got %v, %v", got, quiet)
+	}
+	if _, quiet := extractQuietFlag([]string{"matrix", "recon", "."}); quiet {
+		t.Error("Expected quiet off without the flag")
+	}
+}
+
+func TestRAMDirOverrideRedirectsStores(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ramDir := t.TempDir()
+	t.Setenv(identity.RAMDirEnv, ramDir)
+
+	path, err := getVerdictPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(ramDir, "deus", "verdicts", "entries.json"); path != want {
+		t.Errorf("verdict path = %s, want %s", path, want)
+	}
+	if got := getSpecsDir(); got != filepath.Join(ramDir, "lock", "specs") {
+		t.Errorf("specs dir = %s", got)
+	}
+}
+
+func TestCommandRegistry(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, cmd := range commands {
+		if cmd.Name == "" || cmd.Description == "" || cmd.run == nil {
+			t.Errorf("Incomplete command entry: %+v", cmd)
+		}
+		if seen[cmd.Name] {
+			t.Errorf("Duplicate command: %s", cmd.Name)
+		}
+		seen[cmd.Name] = true
+		for _, f := range cmd.Flags {
+			if len(f) < 3 || f[:2] != "--" {
+				t.Errorf("%s: flag %q should be spelled --name", cmd.Name, f)
+			}
+		}
+	}
+
+	if _, ok := findCommand("verdict"); !ok {
+		t.Error("Expected verdict to be registered")
+	}
+	if _, ok := findCommand("nope"); ok {
+		t.Error("Expected unknown command lookup to fail")
+	}
+}
+
+func TestCommandAliases(t *testing.T) {
+	// Aliases share one namespace with names, so none can shadow another
+	owner := make(map[string]string)
+	for _, cmd := range commands {
+		owner[cmd.Name] = cmd.Name
+	}
+	for _, cmd := range commands {
+		for _, alias := range cmd.Aliases {
+			if other, taken := owner[alias]; taken {
+				t.Errorf("Alias %q of %s is already used by %s", alias, cmd.Name, other)
+			}
+			owner[alias] = cmd.Name
+		}
+	}
+
+	for alias, name := range map[string]string{"bp": "breach-points", "deps": "dependency-map", "fp": "friction-points"} {
+		cmd, ok := findCommand(alias)
+		if !ok || cmd.Name != name {
+			t.Errorf("findCommand(%q) = %q, %v; want %s", alias, cmd.Name, ok, name)
+		}
+	}
+}
+
+func TestBuildCommandCatalog(t *testing.T) {
+	catalog := buildCommandCatalog()
+	if catalog.Version != version || len(catalog.Commands) != len(commands) {
+		t.Fatalf("Unexpected catalog: version %s, %d commands", catalog.Version, len(catalog.Commands))
+	}
+	if !reflect.DeepEqual(catalog.GlobalFlags, []string{"--quiet", "--ram-dir"}) {
+		t.Errorf("GlobalFlags = %v", catalog.GlobalFlags)
+	}
+
+	for _, cmd := range catalog.Commands {
+		if cmd.Aliases == nil || cmd.Subcommands == nil || cmd.Flags == nil {
+			t.Errorf("%s: expected empty lists rather than nil", cmd.Name)
+		}
+		if cmd.Name == "spec-verify" && !reflect.DeepEqual(cmd.Subcommands, []string{"list", "verify", "report", "trend"}) {
+			t.Errorf("spec-verify subcommands = %v", cmd.Subcommands)
+		}
+	}
+}
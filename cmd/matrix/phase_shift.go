@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
 )
 
 // EntryType represents the type of compatibility entry
@@ -20,6 +21,8 @@ const (
 	EntryTypeCompatibility EntryType = "compatibility"
 	EntryTypeBreak         EntryType = "break"
 	EntryTypePattern       EntryType = "pattern"
+	EntryTypeMigrated      EntryType = "migrated"
+	EntryTypePending       EntryType = "pending"
 )
 
 // PhaseShiftEntry represents a single compatibility/pattern/break record
@@ -36,6 +39,16 @@ type PhaseShiftData struct {
 	Entries []PhaseShiftEntry `json:"entries"`
 }
 
+// MigrationProgress summarizes migrated vs pending constructs for a language pair
+type MigrationProgress struct {
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Migrated int     `json:"migrated"`
+	Pending  int     `json:"pending"`
+	Total    int     `json:"total"`
+	Percent  float64 `json:"percent"`
+}
+
 // VersionSpec represents a parsed version specification (e.g., "python:3.9")
 type VersionSpec struct {
 	Language string
@@ -66,6 +79,12 @@ func runPhaseShift() error {
 		return runPhaseShiftBreaks()
 	case "list":
 		return runPhaseShiftList()
+	case "migrated":
+		return runPhaseShiftMigrated()
+	case "pending":
+		return runPhaseShiftPending()
+	case "progress":
+		return runPhaseShiftProgress()
 	case "--help", "-h", "help":
 		printPhaseShiftHelp()
 		return nil
@@ -85,6 +104,9 @@ func printPhaseShiftHelp() {
 	fmt.Println("  matrix phase-shift patterns <lang1> <lang2>     List patterns for language pair")
 	fmt.Println("  matrix phase-shift breaks <from> <to>           Show breaking changes")
 	fmt.Println("  matrix phase-shift list                         List all entries")
+	fmt.Println("  matrix phase-shift migrated <from> <to> <item>  Mark a construct as ported")
+	fmt.Println("  matrix phase-shift pending <from> <to> <item>   Mark a construct as awaiting port")
+	fmt.Println("  matrix phase-shift progress [<from> <to>]       Show migration completion per pair")
 	fmt.Println("")
 	fmt.Println("Version specs: language:version (e.g., python:3.9, rust:1.70)")
 }
@@ -128,6 +150,162 @@ func runPhaseShiftPattern() error {
 	return addEntry(EntryTypePattern, from, to, note)
 }
 
+// runPhaseShiftMigrated records a construct that has been ported
+func runPhaseShiftMigrated() error {
+	if len(os.Args) < 6 {
+		return fmt.Errorf("usage: phase-shift migrated <from> <to> <item>")
+	}
+
+	from := os.Args[3]
+	to := os.Args[4]
+	note := strings.Join(os.Args[5:], " ")
+
+	return addEntry(EntryTypeMigrated, from, to, note)
+}
+
+// runPhaseShiftPending records a construct that still needs porting
+func runPhaseShiftPending() error {
+	if len(os.Args) < 6 {
+		return fmt.Errorf("usage: phase-shift pending <from> <to> <item>")
+	}
+
+	from := os.Args[3]
+	to := os.Args[4]
+	note := strings.Join(os.Args[5:], " ")
+
+	return addEntry(EntryTypePending, from, to, note)
+}
+
+// runPhaseShiftProgress reports migration completion per language pair
+func runPhaseShiftProgress() error {
+	fs := flag.NewFlagSet("phase-shift-progress", flag.ExitOnError)
+	jsonFlag := fs.Bool("json", false, "Output as JSON")
+
+	// Optional positional <from> <to>, before or after flags
+	args := os.Args[3:]
+	var from, to string
+	if len(args) >= 2 && !strings.HasPrefix(args[0], "-") && !strings.HasPrefix(args[1], "-") {
+		from, to = args[0], args[1]
+		args = args[2:]
+	}
+	fs.Parse(args)
+
+	rest := fs.Args()
+	if from == "" && len(rest) >= 2 {
+		from, to = rest[0], rest[1]
+		rest = rest[2:]
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("usage: matrix phase-shift progress [<from> <to>] [--json]")
+	}
+
+	data, err := loadPhaseShiftData()
+	if err != nil {
+		return err
+	}
+
+	progress := calculateMigrationProgress(data.Entries)
+
+	// Restrict to the requested pair
+	if from != "" {
+		var filtered []MigrationProgress
+		for _, p := range progress {
+			if p.From == parseVersionSpec(from).Language && p.To == parseVersionSpec(to).Language {
+				filtered = append(filtered, p)
+			}
+		}
+		progress = filtered
+	}
+
+	if *jsonFlag {
+		if progress == nil {
+			progress = []MigrationProgress{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(progress)
+	}
+
+	output.Success("🔄 Phase Shift")
+	fmt.Println("")
+	fmt.Println("═══ MIGRATION PROGRESS ═══")
+	fmt.Println("")
+
+	if len(progress) == 0 {
+		fmt.Println("No migration markers recorded yet.")
+		fmt.Println("Use 'phase-shift migrated' and 'phase-shift pending' to track constructs.")
+		return nil
+	}
+
+	for _, p := range progress {
+		fmt.Printf("  %s → %s\n", output.Yellow+p.From+output.Reset, output.Cyan+p.To+output.Reset)
+		fmt.Printf("    %s %5.1f%%  (%d/%d migrated, %d pending)\n",
+			progressBar(p.Percent, 20), p.Percent, p.Migrated, p.Total, p.Pending)
+		fmt.Println("")
+	}
+
+	return nil
+}
+
+// calculateMigrationProgress counts migrated vs pending constructs per language pair.
+// A construct marked migrated counts as migrated even if it was recorded as pending earlier.
+func calculateMigrationProgress(entries []PhaseShiftEntry) []MigrationProgress {
+	type pairKey struct{ from, to string }
+	constructs := make(map[pairKey]map[string]bool) // pair -> construct -> migrated
+
+	for _, entry := range entries {
+		if entry.Type != EntryTypeMigrated && entry.Type != EntryTypePending {
+			continue
+		}
+
+		key := pairKey{parseVersionSpec(entry.From).Language, parseVersionSpec(entry.To).Language}
+		if constructs[key] == nil {
+			constructs[key] = make(map[string]bool)
+		}
+
+		construct := strings.ToLower(strings.TrimSpace(entry.Note))
+		if entry.Type == EntryTypeMigrated {
+			constructs[key][construct] = true
+		} else if _, exists := constructs[key][construct]; !exists {
+			constructs[key][construct] = false
+		}
+	}
+
+	var progress []MigrationProgress
+	for key, items := range constructs {
+		p := MigrationProgress{From: key.from, To: key.to, Total: len(items)}
+		for _, migrated := range items {
+			if migrated {
+				p.Migrated++
+			} else {
+				p.Pending++
+			}
+		}
+		if p.Total > 0 {
+			p.Percent = float64(p.Migrated) / float64(p.Total) * 100
+		}
+		progress = append(progress, p)
+	}
+
+	sort.Slice(progress, func(i, j int) bool {
+		if progress[i].From != progress[j].From {
+			return progress[i].From < progress[j].From
+		}
+		return progress[i].To < progress[j].To
+	})
+
+	return progress
+}
+
+// progressBar renders a fixed-width bar for a percentage
+func progressBar(percent float64, width int) string {
+	filled := int(percent / 100 * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
 // runPhaseShiftCheck checks compatibility between versions
 func runPhaseShiftCheck() error {
 	if len(os.Args) < 5 {
@@ -453,12 +631,12 @@ func savePhaseShiftData(data *PhaseShiftData) error {
 
 // getDataPath returns the path to the data file
 func getDataPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
 
-	return filepath.Join(homeDir, ".claude", "ram", "twins", "compatibility", "entries.json"), nil
+	return filepath.Join(ramDir, "twins", "compatibility", "entries.json"), nil
 }
 
 // parseVersionSpec parses a version specification (e.g., "python:3.9")
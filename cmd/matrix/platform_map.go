@@ -1,16 +1,20 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // PlatformCategory represents the compatibility level of a file
@@ -25,13 +29,14 @@ const (
 
 // FileCompatibility tracks platform compatibility information for a file
 type FileCompatibility struct {
-	FilePath    string           `json:"file_path"`
-	Category    PlatformCategory `json:"category"`
-	TestedOn    []string         `json:"tested_on,omitempty"`
-	Breaks      []string         `json:"breaks,omitempty"`
-	Mentions    []string         `json:"mentions,omitempty"`
-	Patterns    []string         `json:"patterns,omitempty"`
-	Description string           `json:"description,omitempty"`
+	FilePath        string           `json:"file_path"`
+	Category        PlatformCategory `json:"category"`
+	TestedOn        []string         `json:"tested_on,omitempty"`
+	Breaks          []string         `json:"breaks,omitempty"`
+	Mentions        []string         `json:"mentions,omitempty"`
+	Patterns        []string         `json:"patterns,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	Recommendations []string         `json:"recommendations,omitempty"`
 }
 
 // PlatformMapOutput contains the complete scan results
@@ -44,6 +49,22 @@ type PlatformMapOutput struct {
 	PatternCounts map[string]map[string][]string `json:"pattern_counts,omitempty"`
 }
 
+// PlatformBaseline records the issues and platform-specific files of an
+// earlier scan so later runs can report only what's new
+type PlatformBaseline struct {
+	Root    string                  `json:"root"`
+	Created time.Time               `json:"created"`
+	Entries []PlatformBaselineEntry `json:"entries"`
+}
+
+// PlatformBaselineEntry fingerprints one file: its path relative to the
+// scanned root plus the patterns detected in it
+type PlatformBaselineEntry struct {
+	File     string           `json:"file"`
+	Category PlatformCategory `json:"category"`
+	Patterns []string         `json:"patterns"` // detected patterns and "breaks: <platform>" markers
+}
+
 // Platform patterns to detect
 var platformPatterns = map[string][]string{
 	"win32":  {`\bwindows?\b`, `\bwin32\b`, `\bwsl\b`, `\bpowershell\b`, `\bcygwin\b`, `\bscoop\b`, `\.exe\b`, `\bwslpath\b`, `\bcygpath\b`},
@@ -56,11 +77,27 @@ var packageManagers = []string{
 	"scoop", "homebrew", "brew", "apt", "apt-get", "yum", "dnf", "pacman", "aqua", "chocolatey", "winget",
 }
 
+// Package managers grouped by the platform they imply
+var windowsPackageManagers = []string{"scoop", "chocolatey", "winget"}
+var darwinPackageManagers = []string{"homebrew", "brew"}
+
+// patternRecommendations maps detected patterns to suggested fixes
+var patternRecommendations = map[string]string{
+	"windows paths":         "uses C:\\ or %USERPROFILE% paths — guard with a runtime.GOOS check or build paths from the home directory",
+	"unix paths":            "hardcodes /usr/bin or /etc/ paths — resolve tools via PATH and guard system paths with a platform check",
+	"powershell":            "calls powershell — provide a POSIX fallback",
+	"path conversion tools": "relies on wslpath/cygpath — skip the conversion when not running under WSL or Cygwin",
+}
+
 // runPlatformMap implements the platform-map command
 func runPlatformMap() error {
 	fs := flag.NewFlagSet("platform-map", flag.ExitOnError)
 	issuesOnly := fs.Bool("issues-only", false, "Show only problems")
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	failOnIssues := fs.Bool("fail-on-issues", false, "Exit non-zero when known issues are found")
+	maxIssues := fs.Int("max-issues", 0, "Number of known issues tolerated before --fail-on-issues fails")
+	writeBaseline := fs.String("write-baseline", "", "Save issues and platform-specific files to this baseline file")
+	baselinePath := fs.String("baseline", "", "Report only issues not in this baseline file, and fail if there are any")
 
 	// Parse flags
 	if len(os.Args) > 2 {
@@ -81,11 +118,10 @@ func runPlatformMap() error {
 
 	// Expand ~ if present
 	if strings.HasPrefix(targetPath, "~") {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
+		if _, err := os.UserHomeDir(); err != nil {
 			return fmt.Errorf("failed to get home directory: %w", err)
 		}
-		targetPath = strings.Replace(targetPath, "~", homeDir, 1)
+		targetPath = util.ExpandPath(targetPath)
 	}
 
 	// Check if target exists
@@ -102,6 +138,28 @@ func runPlatformMap() error {
 		return fmt.Errorf("scan failed: %w", err)
 	}
 
+	if *writeBaseline != "" {
+		path := util.ExpandPath(*writeBaseline)
+		baseline := buildPlatformBaseline(targetPath, results)
+		if err := savePlatformBaseline(path, baseline); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote platform baseline with %d entries to %s\n", len(baseline.Entries), path)
+	}
+
+	// Regression gate: keep only what the baseline doesn't know about
+	newIssues := 0
+	if *baselinePath != "" {
+		baseline, err := loadPlatformBaseline(util.ExpandPath(*baselinePath))
+		if err != nil {
+			return err
+		}
+		results.Issues = newSinceBaseline(targetPath, results.Issues, baseline)
+		results.Specific = newSinceBaseline(targetPath, results.Specific, baseline)
+		newIssues = len(results.Issues) + len(results.Specific)
+		*issuesOnly = true
+	}
+
 	// Filter if issues-only
 	if *issuesOnly {
 		results.CrossPlatform = nil
@@ -112,14 +170,129 @@ func runPlatformMap() error {
 	if *jsonOutput {
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
-		return encoder.Encode(results)
+		if err := encoder.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		// Human-readable output
+		printPlatformMap(results, *issuesOnly)
+		if *baselinePath != "" && newIssues == 0 {
+			output.Success("✓ No new platform issues since the baseline")
+		}
+	}
+
+	// CI guardrail: fail after output so the report is still visible
+	if newIssues > 0 {
+		return fmt.Errorf("%d new platform issues since the baseline", newIssues)
+	}
+	if *failOnIssues && len(results.Issues) > *maxIssues {
+		return fmt.Errorf("%d known platform issues found (max %d)", len(results.Issues), *maxIssues)
 	}
 
-	// Human-readable output
-	printPlatformMap(results, *issuesOnly)
 	return nil
 }
 
+// buildPlatformBaseline fingerprints the known issues and platform-specific
+// files of a scan
+func buildPlatformBaseline(root string, results *PlatformMapOutput) PlatformBaseline {
+	baseline := PlatformBaseline{
+		Root:    root,
+		Created: time.Now(),
+		Entries: []PlatformBaselineEntry{},
+	}
+	for _, group := range [][]FileCompatibility{results.Issues, results.Specific} {
+		for _, f := range group {
+			baseline.Entries = append(baseline.Entries, PlatformBaselineEntry{
+				File:     platformRelPath(root, f.FilePath),
+				Category: f.Category,
+				Patterns: platformFingerprint(f),
+			})
+		}
+	}
+	sort.Slice(baseline.Entries, func(i, j int) bool {
+		return baseline.Entries[i].File < baseline.Entries[j].File
+	})
+	return baseline
+}
+
+// newSinceBaseline keeps the files with a pattern the baseline didn't record
+// for them. A file that gains a new pattern or BREAKS marker counts as new.
+func newSinceBaseline(root string, files []FileCompatibility, baseline *PlatformBaseline) []FileCompatibility {
+	known := make(map[string]bool)
+	for _, entry := range baseline.Entries {
+		for _, pattern := range entry.Patterns {
+			known[entry.File+"\x00"+pattern] = true
+		}
+	}
+
+	fresh := []FileCompatibility{}
+	for _, f := range files {
+		rel := platformRelPath(root, f.FilePath)
+		for _, pattern := range platformFingerprint(f) {
+			if !known[rel+"\x00"+pattern] {
+				fresh = append(fresh, f)
+				break
+			}
+		}
+	}
+	return fresh
+}
+
+// platformFingerprint lists a file's detected patterns and BREAKS markers,
+// sorted so the fingerprint doesn't depend on detection order
+func platformFingerprint(f FileCompatibility) []string {
+	fingerprint := append([]string{}, f.Patterns...)
+	for _, platform := range f.Breaks {
+		fingerprint = append(fingerprint, "breaks: "+platform)
+	}
+	if len(fingerprint) == 0 {
+		for _, platform := range f.Mentions {
+			fingerprint = append(fingerprint, "mentions: "+platform)
+		}
+	}
+	sort.Strings(fingerprint)
+	return fingerprint
+}
+
+// platformRelPath makes a reported file path relative to the scanned root,
+// so baselines survive a checkout in a different location
+func platformRelPath(root, filePath string) string {
+	displayRoot := root
+	if homeDir, err := os.UserHomeDir(); err == nil && homeDir != "" {
+		displayRoot = strings.Replace(root, homeDir, "~", 1)
+	}
+	rel, err := filepath.Rel(displayRoot, filePath)
+	if err != nil {
+		return filepath.ToSlash(filePath)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// savePlatformBaseline writes a baseline file
+func savePlatformBaseline(path string, baseline PlatformBaseline) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := util.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// loadPlatformBaseline reads a baseline written by --write-baseline
+func loadPlatformBaseline(path string) (*PlatformBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var baseline PlatformBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	return &baseline, nil
+}
+
 // scanForPlatformCompatibility scans a directory tree for platform compatibility markers
 func scanForPlatformCompatibility(rootPath string) (*PlatformMapOutput, error) {
 	output := &PlatformMapOutput{
@@ -142,8 +315,11 @@ func scanForPlatformCompatibility(rootPath string) (*PlatformMapOutput, error) {
 			return nil // Skip unreadable paths
 		}
 
-		// Skip directories
+		// Skip dependency, build, and VCS directories
 		if d.IsDir() {
+			if path != rootPath && shouldSkipPMDir(d.Name()) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
@@ -152,6 +328,11 @@ func scanForPlatformCompatibility(rootPath string) (*PlatformMapOutput, error) {
 			return nil
 		}
 
+		// Extensionless files may be compiled artifacts rather than scripts
+		if filepath.Ext(d.Name()) == "" && isBinaryFile(path) {
+			return nil
+		}
+
 		// Read file
 		content, err := os.ReadFile(path)
 		if err != nil {
@@ -313,11 +494,11 @@ func analyzeFileCompatibility(path, content string) FileCompatibility {
 			compat.Patterns = append(compat.Patterns, fmt.Sprintf("package manager: %s", pm))
 
 			// Infer platform
-			if pm == "scoop" || pm == "chocolatey" || pm == "winget" {
+			if contains(windowsPackageManagers, pm) {
 				if !contains(compat.Mentions, "win32") {
 					compat.Mentions = append(compat.Mentions, "win32")
 				}
-			} else if pm == "homebrew" || pm == "brew" {
+			} else if contains(darwinPackageManagers, pm) {
 				if !contains(compat.Mentions, "darwin") {
 					compat.Mentions = append(compat.Mentions, "darwin")
 				}
@@ -344,9 +525,53 @@ func analyzeFileCompatibility(path, content string) FileCompatibility {
 	compat.Mentions = deduplicate(compat.Mentions)
 	compat.Patterns = deduplicate(compat.Patterns)
 
+	compat.Recommendations = recommendFixes(compat)
+
 	return compat
 }
 
+// recommendFixes suggests fixes for the platform patterns detected in a file
+func recommendFixes(compat FileCompatibility) []string {
+	recs := []string{}
+
+	for _, pattern := range compat.Patterns {
+		if rec, ok := patternRecommendations[pattern]; ok {
+			recs = append(recs, rec)
+			continue
+		}
+
+		pm, ok := strings.CutPrefix(pattern, "package manager: ")
+		if !ok {
+			continue
+		}
+		switch {
+		case contains(windowsPackageManagers, pm):
+			recs = append(recs, fmt.Sprintf("installs with %s — add brew/apt instructions for macOS and Linux", pm))
+		case contains(darwinPackageManagers, pm):
+			recs = append(recs, fmt.Sprintf("installs with %s — add apt/scoop instructions for Linux and Windows", pm))
+		default:
+			recs = append(recs, fmt.Sprintf("installs with %s — add brew/scoop instructions for macOS and Windows", pm))
+		}
+	}
+
+	if len(compat.Breaks) > 0 {
+		recs = append(recs, fmt.Sprintf("breaks on %s — guard the failing path or document a workaround", strings.Join(compat.Breaks, ", ")))
+	}
+
+	return recs
+}
+
+// printRecommendations prints the suggested fixes for a file
+func printRecommendations(f FileCompatibility) {
+	if len(f.Recommendations) == 0 {
+		return
+	}
+	fmt.Println("    Recommendations:")
+	for _, rec := range f.Recommendations {
+		fmt.Printf("      → %s\n", rec)
+	}
+}
+
 // extractPlatformList extracts comma-separated platforms from a marker line
 func extractPlatformList(line string) []string {
 	// Find the part after the colon
@@ -395,6 +620,42 @@ func isPlatformTextFile(filename string) bool {
 	return false
 }
 
+// shouldSkipPMDir returns true if directory should be skipped
+func shouldSkipPMDir(name string) bool {
+	skipDirs := map[string]bool{
+		".git":         true,
+		"node_modules": true,
+		"vendor":       true,
+		"target":       true,
+		"build":        true,
+		"dist":         true,
+		"__pycache__":  true,
+		"venv":         true,
+		".venv":        true,
+	}
+	return skipDirs[name]
+}
+
+// binarySniffSize is how much of a file isBinaryFile inspects
+const binarySniffSize = 1024
+
+// isBinaryFile reports whether the first KB of a file contains a NUL byte,
+// which text files never do. Unreadable files are treated as binary.
+func isBinaryFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return true
+	}
+	defer f.Close()
+
+	buf := make([]byte, binarySniffSize)
+	n, err := f.Read(buf)
+	if err != nil && err != io.EOF {
+		return true
+	}
+	return bytes.IndexByte(buf[:n], 0) >= 0
+}
+
 // printPlatformMap prints human-readable output
 func printPlatformMap(results *PlatformMapOutput, issuesOnly bool) {
 	output.Success("🗺️  Platform Map")
@@ -415,6 +676,7 @@ func printPlatformMap(results *PlatformMapOutput, issuesOnly bool) {
 			if len(f.Patterns) > 0 {
 				fmt.Printf("    Patterns: %s\n", output.Dim+strings.Join(f.Patterns, ", ")+output.Reset)
 			}
+			printRecommendations(f)
 			fmt.Println("")
 		}
 	}
@@ -430,6 +692,7 @@ func printPlatformMap(results *PlatformMapOutput, issuesOnly bool) {
 			if len(f.Patterns) > 0 {
 				fmt.Printf("    Patterns: %s\n", output.Dim+strings.Join(f.Patterns, ", ")+output.Reset)
 			}
+			printRecommendations(f)
 			fmt.Println("")
 		}
 	}
@@ -445,6 +708,7 @@ func printPlatformMap(results *PlatformMapOutput, issuesOnly bool) {
 			if len(f.Patterns) > 0 {
 				fmt.Printf("    Patterns: %s\n", output.Dim+strings.Join(f.Patterns, ", ")+output.Reset)
 			}
+			printRecommendations(f)
 			fmt.Println("")
 		}
 	}
@@ -460,6 +724,7 @@ func printPlatformMap(results *PlatformMapOutput, issuesOnly bool) {
 			if len(f.Patterns) > 0 {
 				fmt.Printf("    Patterns: %s\n", strings.Join(f.Patterns, ", ")+output.Reset)
 			}
+			printRecommendations(f)
 			fmt.Println("")
 		}
 	}
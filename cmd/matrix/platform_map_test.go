@@ -0,0 +1,119 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeFileCompatibilityRecommendations(t *testing.T) {
+	content := `#!/bin/bash
+# BREAKS: win32
+powershell -Command "Get-Item C:\Users"
+scoop install jq
+`
+
+	compat := analyzeFileCompatibility("/tmp/setup.sh", content)
+
+	wantPrefixes := []string{
+		"breaks on win32",
+		"calls powershell",
+		"installs with scoop",
+		"uses C:\\",
+	}
+	for _, prefix := range wantPrefixes {
+		found := false
+		for _, rec := range compat.Recommendations {
+			if strings.HasPrefix(rec, prefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected recommendation starting with %q, got %v", prefix, compat.Recommendations)
+		}
+	}
+}
+
+func TestAnalyzeFileCompatibilityNoRecommendations(t *testing.T) {
+	compat := analyzeFileCompatibility("/tmp/notes.md", "# TESTED: linux, darwin\nplain notes\n")
+
+	if len(compat.Recommendations) != 0 {
+		t.Errorf("Expected no recommendations, got %v", compat.Recommendations)
+	}
+}
+
+func TestScanForPlatformCompatibilitySkipsBinaryAndIgnoredDirs(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"install":                  "#!/bin/sh\n# TESTED: linux, darwin\necho ok\n",
+		"tool":                     "\x7fELF\x00\x00# TESTED: linux\n",
+		".git/hooks/pre-commit":    "#!/bin/sh\n# TESTED: linux\n",
+		"node_modules/pkg/run.sh":  "# TESTED: win32\n",
+		"scripts/build/release.sh": "# TESTED: darwin\n",
+	})
+
+	results, err := scanForPlatformCompatibility(tmpDir)
+	if err != nil {
+		t.Fatalf("scanForPlatformCompatibility() failed: %v", err)
+	}
+
+	var scanned []string
+	for _, group := range [][]FileCompatibility{results.CrossPlatform, results.Specific, results.Unknown, results.Issues} {
+		for _, f := range group {
+			rel, _ := filepath.Rel(tmpDir, f.FilePath)
+			scanned = append(scanned, rel)
+		}
+	}
+
+	if len(scanned) != 1 || scanned[0] != "install" {
+		t.Errorf("Expected only the extensionless script to be scanned, got %v", scanned)
+	}
+}
+
+func TestPlatformBaselineReportsOnlyNewIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"setup.sh":   "#!/bin/bash\n# BREAKS: win32\nbrew install jq\n",
+		"install.sh": "apt-get install jq\n",
+	})
+
+	before, err := scanForPlatformCompatibility(tmpDir)
+	if err != nil {
+		t.Fatalf("scanForPlatformCompatibility() failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := savePlatformBaseline(path, buildPlatformBaseline(tmpDir, before)); err != nil {
+		t.Fatalf("savePlatformBaseline() failed: %v", err)
+	}
+	baseline, err := loadPlatformBaseline(path)
+	if err != nil {
+		t.Fatalf("loadPlatformBaseline() failed: %v", err)
+	}
+	if len(baseline.Entries) != 2 || baseline.Entries[0].File != "install.sh" {
+		t.Fatalf("Expected 2 relative entries, got %+v", baseline.Entries)
+	}
+
+	if fresh := newSinceBaseline(tmpDir, before.Issues, baseline); len(fresh) != 0 {
+		t.Errorf("Expected no new issues against its own baseline, got %d", len(fresh))
+	}
+
+	// A new break, plus a new pattern in a file the baseline already knows
+	writeFixture(t, tmpDir, map[string]string{
+		"deploy.sh":  "# BREAKS: darwin\n",
+		"install.sh": "apt-get install jq\npowershell -Command ls\n",
+	})
+	after, err := scanForPlatformCompatibility(tmpDir)
+	if err != nil {
+		t.Fatalf("scanForPlatformCompatibility() failed: %v", err)
+	}
+
+	issues := newSinceBaseline(tmpDir, after.Issues, baseline)
+	if len(issues) != 1 || !strings.HasSuffix(issues[0].FilePath, "deploy.sh") {
+		t.Errorf("Expected only deploy.sh as a new issue, got %+v", issues)
+	}
+	specific := newSinceBaseline(tmpDir, after.Specific, baseline)
+	if len(specific) != 1 || !strings.HasSuffix(specific[0].FilePath, "install.sh") {
+		t.Errorf("Expected install.sh as newly platform-specific, got %+v", specific)
+	}
+}
@@ -1,16 +1,22 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // ProjectInfo contains reconnaissance data about a codebase
@@ -22,6 +28,7 @@ type ProjectInfo struct {
 	TotalFiles     int
 	CodeFiles      int
 	TestFiles      int
+	Categories     map[string]int // file count per reconCategories bucket, including skipped assets
 	EntryPoints    []EntryPoint
 	Architecture   ArchitectureInfo
 	Dependencies   []Dependency
@@ -65,6 +72,49 @@ type DocInfo struct {
 	HasDocsDir     bool
 	InlineComments int // percentage
 	Examples       bool
+	CodeLines      int // primary language only
+	CommentLines   int
+	BlankLines     int
+	License        string // SPDX identifier, "Unknown" when unidentified, empty when no license file
+	LicenseFile    string
+}
+
+// licenseFilePattern matches top-level license file names
+var licenseFilePattern = regexp.MustCompile(`(?i)^(license|licence|copying|unlicense)([.-].*)?$`)
+
+// spdxIdentifierPattern extracts an explicit SPDX tag from a license file
+var spdxIdentifierPattern = regexp.MustCompile(`(?i)spdx-license-identifier:\s*([A-Za-z0-9.+-]+)`)
+
+// commentSyntax describes how a language marks comments
+type commentSyntax struct {
+	Line       []string // line comment prefixes
+	BlockStart string
+	BlockEnd   string
+}
+
+var (
+	cStyleComments = commentSyntax{Line: []string{"//"}, BlockStart: "/*", BlockEnd: "*/"}
+	hashComments   = commentSyntax{Line: []string{"#"}}
+)
+
+// languageComments maps primary languages to their comment syntax.
+// Python docstrings are counted as comments.
+var languageComments = map[string]commentSyntax{
+	"Go":         cStyleComments,
+	"Rust":       cStyleComments,
+	"JavaScript": cStyleComments,
+	"TypeScript": cStyleComments,
+	"Java":       cStyleComments,
+	"C":          cStyleComments,
+	"C++":        cStyleComments,
+	"C#":         cStyleComments,
+	"Swift":      cStyleComments,
+	"Kotlin":     cStyleComments,
+	"PHP":        {Line: []string{"//", "#"}, BlockStart: "/*", BlockEnd: "*/"},
+	"Python":     {Line: []string{"#"}, BlockStart: `"""`, BlockEnd: `"""`},
+	"Ruby":       {Line: []string{"#"}, BlockStart: "=begin", BlockEnd: "=end"},
+	"Shell":      hashComments,
+	"Bash":       hashComments,
 }
 
 // HealthInfo tracks code health indicators
@@ -73,6 +123,28 @@ type HealthInfo struct {
 	FIXMEs          []CodeMarker
 	SecurityConcerns []CodeMarker
 	DeadCodeSignals []string
+	Coverage        CoverageInfo
+}
+
+// CoverageInfo describes a test coverage artifact found in the project
+type CoverageInfo struct {
+	File    string  // relative path, empty when no coverage file was found
+	Format  string  // go, lcov, cobertura, python, istanbul
+	Percent float64 // overall line/statement coverage
+	Parsed  bool    // false when the format is detected but not parsed
+}
+
+// coverageFiles maps known coverage artifact names to their format
+var coverageFiles = map[string]string{
+	"coverage.out":        "go",
+	"cover.out":           "go",
+	"coverage.txt":        "go",
+	"c.out":               "go",
+	"lcov.info":           "lcov",
+	"coverage.xml":        "cobertura",
+	"cobertura.xml":       "cobertura",
+	".coverage":           "python",
+	"coverage-final.json": "istanbul",
 }
 
 // CodeMarker represents a comment marker with location
@@ -82,12 +154,117 @@ type CodeMarker struct {
 	Content string
 }
 
+// ReconConfig holds configuration for a recon scan
+type ReconConfig struct {
+	Quick    bool
+	Focus    reconFocus
+	Excludes []string // Glob patterns matched against relative paths; always win over built-in skips
+	Cache    *reconCache // Per-file marker cache; nil disables caching
+	MaxDepth int         // Directory levels below the root to scan; 0 is unlimited
+}
+
+// reconFocusAspects are the report areas --focus can select, in display order
+var reconFocusAspects = []string{"security", "architecture", "docs"}
+
+// reconFocus is the set of aspects a scan focuses on. An empty set means no
+// focus: every section is reported.
+type reconFocus map[string]bool
+
+// parseReconFocus parses a comma-separated --focus value
+func parseReconFocus(value string) (reconFocus, error) {
+	focus := reconFocus{}
+	for _, token := range strings.Split(value, ",") {
+		token = strings.ToLower(strings.TrimSpace(token))
+		if token == "" {
+			continue
+		}
+		if !contains(reconFocusAspects, token) {
+			return nil, fmt.Errorf("invalid focus option: %s (valid: %s)", token, strings.Join(reconFocusAspects, ", "))
+		}
+		focus[token] = true
+	}
+	return focus, nil
+}
+
+// has reports whether aspect was explicitly selected
+func (f reconFocus) has(aspect string) bool {
+	return f[aspect]
+}
+
+// includes reports whether aspect should be reported: it was selected, or
+// there is no focus at all
+func (f reconFocus) includes(aspect string) bool {
+	return len(f) == 0 || f[aspect]
+}
+
+// String lists the selected aspects in display order
+func (f reconFocus) String() string {
+	var selected []string
+	for _, aspect := range reconFocusAspects {
+		if f[aspect] {
+			selected = append(selected, aspect)
+		}
+	}
+	return strings.Join(selected, ", ")
+}
+
+// reconCacheVersion invalidates cache files written with different marker patterns
+const reconCacheVersion = 2
+
+// Marker limits for the report. Cached entries hold at most this many per file.
+// Security concerns aren't capped, so their count matches breach-points.
+const (
+	maxTODOMarkers  = 20
+	maxFIXMEMarkers = 20
+)
+
+// reconCache stores per-file health markers keyed by path, reused while a
+// file's modification time and size are unchanged
+type reconCache struct {
+	Version int                        `json:"version"`
+	Root    string                     `json:"root"`
+	Entries map[string]reconCacheEntry `json:"entries"` // keyed by relative path
+
+	path  string
+	dirty bool
+}
+
+// reconCacheEntry holds the markers found in one file
+type reconCacheEntry struct {
+	ModTime  int64        `json:"mod_time"` // unix nanoseconds
+	Size     int64        `json:"size"`
+	TODOs    []CodeMarker `json:"todos,omitempty"`
+	FIXMEs   []CodeMarker `json:"fixmes,omitempty"`
+	Security []CodeMarker `json:"security,omitempty"`
+}
+
+// stringSliceFlag collects values from a repeatable flag
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 // runRecon implements the recon command
 func runRecon() error {
 	// Parse flags
 	fs := flag.NewFlagSet("recon", flag.ExitOnError)
 	quickFlag := fs.Bool("quick", false, "Fast overview, skip deep analysis")
-	focusFlag := fs.String("focus", "", "Focus on specific aspect: security, architecture, docs")
+	focusFlag := fs.String("focus", "", "Focus on aspects, comma-separated: security, architecture, docs")
+	noCacheFlag := fs.Bool("no-cache", false, "Don't read or write the per-file cache")
+	refreshFlag := fs.Bool("refresh", false, "Ignore cached results and rebuild the cache")
+	depthFlag := fs.Int("depth", 0, "Only scan N directory levels below the target (1 = top-level files only, 0 = unlimited)")
+	formatFlag := fs.String("format", "text", "Output format: text, markdown, or json")
+	outputFlag := fs.String("output", "", "Write the markdown report to a file (implies --format markdown)")
+	saveFlag := fs.String("save", "", "Also save the scan as a JSON report, for a later --compare")
+	compareFlag := fs.String("compare", "", "Show what changed since a saved JSON report instead of the full report")
+	var excludes stringSliceFlag
+	fs.Var(&excludes, "exclude", "Glob of paths to skip, relative to target (repeatable)")
 
 	// Parse remaining args (after "recon")
 	if len(os.Args) > 2 {
@@ -112,69 +289,183 @@ func runRecon() error {
 	}
 
 	// Validate focus flag
-	if *focusFlag != "" {
-		validFocus := map[string]bool{"security": true, "architecture": true, "docs": true}
-		if !validFocus[*focusFlag] {
-			return fmt.Errorf("invalid focus option: %s (valid: security, architecture, docs)", *focusFlag)
-		}
+	focus, err := parseReconFocus(*focusFlag)
+	if err != nil {
+		return err
 	}
 
-	// Run reconnaissance
-	output.Success("🔍 Reconnaissance Scanner")
-	fmt.Println("")
-	fmt.Printf("Target: %s\n", absPath)
+	if *depthFlag < 0 {
+		return fmt.Errorf("--depth must be 0 (unlimited) or more, got %d", *depthFlag)
+	}
 
-	scanType := "full"
-	if *quickFlag {
-		scanType = "quick"
+	formatSet := false
+	fs.Visit(func(f *flag.Flag) {
+		formatSet = formatSet || f.Name == "format"
+	})
+	switch {
+	case *formatFlag != "text" && *formatFlag != "markdown" && *formatFlag != "json":
+		return fmt.Errorf("unknown format: %s (use text, markdown, or json)", *formatFlag)
+	case *outputFlag != "" && formatSet && *formatFlag != "markdown":
+		return fmt.Errorf("--output writes a markdown report; use --format markdown")
+	case *outputFlag != "":
+		*formatFlag = "markdown"
 	}
-	if *focusFlag != "" {
-		scanType = fmt.Sprintf("focused (%s)", *focusFlag)
+	markdown := *formatFlag == "markdown"
+	jsonOut := *formatFlag == "json"
+	if *compareFlag != "" && markdown {
+		return fmt.Errorf("--compare prints text or json; drop --format markdown and --output")
+	}
+
+	// Load the old report up front so a bad path fails before the scan
+	var previous *reconJSON
+	if *compareFlag != "" {
+		if previous, err = loadReconJSON(util.ExpandPath(*compareFlag)); err != nil {
+			return err
+		}
+	}
+
+	// Run reconnaissance. Markdown and JSON on stdout stay clean of progress output.
+	if !markdown && !jsonOut {
+		output.Success("🔍 Reconnaissance Scanner")
+		fmt.Println("")
+		fmt.Printf("Target: %s\n", absPath)
+
+		scanType := "full"
+		if *quickFlag {
+			scanType = "quick"
+		}
+		if len(focus) > 0 {
+			scanType = fmt.Sprintf("focused (%s)", focus)
+		}
+		fmt.Printf("Scan Type: %s\n", scanType)
+		if *depthFlag > 0 {
+			fmt.Printf("Depth: %d\n", *depthFlag)
+		}
+		fmt.Println("")
+		fmt.Println("Scanning...")
+		fmt.Println("")
 	}
-	fmt.Printf("Scan Type: %s\n", scanType)
-	fmt.Println("")
-	fmt.Println("Scanning...")
-	fmt.Println("")
 
 	// Scan the target
-	info, err := scanDirectory(absPath, *quickFlag, *focusFlag)
+	config := ReconConfig{
+		Quick:    *quickFlag,
+		Focus:    focus,
+		Excludes: excludes,
+		MaxDepth: *depthFlag,
+	}
+	if !*noCacheFlag {
+		config.Cache = loadReconCache(absPath, *refreshFlag)
+	}
+	info, err := scanDirectory(absPath, config)
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
 	}
 
-	// Display report
-	displayReconReport(info, *focusFlag)
+	// Cache failures only cost speed on the next run
+	if config.Cache != nil {
+		if err := config.Cache.save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save recon cache: %v\n", err)
+		}
+	}
+
+	report := buildReconJSON(info)
+	if *saveFlag != "" {
+		if err := saveReconJSON(util.ExpandPath(*saveFlag), report); err != nil {
+			return err
+		}
+		if !jsonOut && !markdown {
+			fmt.Printf("Saved scan to %s\n\n", *saveFlag)
+		}
+	}
+
+	if previous != nil {
+		delta := compareReconReports(previous, &report)
+		if jsonOut {
+			return output.EmitJSON(delta)
+		}
+		displayReconDelta(delta, *compareFlag)
+		return nil
+	}
+	if jsonOut {
+		return output.EmitJSON(report)
+	}
+	if !markdown {
+		displayReconReport(info, focus)
+		return nil
+	}
+
+	markdownReport := renderReconMarkdown(info, focus)
+	if *outputFlag == "" {
+		fmt.Print(markdownReport)
+		return nil
+	}
 
+	outPath := util.ExpandPath(*outputFlag)
+	if err := util.WriteFileAtomic(outPath, []byte(markdownReport), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	output.Success(fmt.Sprintf("✓ Wrote recon report to %s", outPath))
 	return nil
 }
 
 // scanDirectory performs the reconnaissance scan
-func scanDirectory(path string, quick bool, focus string) (*ProjectInfo, error) {
+func scanDirectory(path string, config ReconConfig) (*ProjectInfo, error) {
+	quick := config.Quick
+	focus := config.Focus
+
 	info := &ProjectInfo{
-		Path:      path,
-		ScanType:  "full",
-		Timestamp: time.Now(),
+		Path:       path,
+		ScanType:   "full",
+		Timestamp:  time.Now(),
+		Categories: make(map[string]int),
 	}
 
 	if quick {
 		info.ScanType = "quick"
 	}
 
-	// Track file types
+	// Track file types, and lines of hand-written code per language
 	fileExtensions := make(map[string]int)
+	languageLines := make(map[string]int)
 	var allFiles []string
 
+	excludes := compileGlobs(config.Excludes)
+
 	// Walk the directory tree
+	progress := output.NewProgress("Scanning")
 	err := filepath.Walk(path, func(filePath string, fileInfo os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip files we can't read
 		}
+		if !fileInfo.IsDir() {
+			progress.Tick()
+		}
+
+		relPath, relErr := filepath.Rel(path, filePath)
+
+		// User excludes take precedence over everything else
+		if relErr == nil && relPath != "." && matchesGlobs(relPath, excludes) {
+			if fileInfo.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// A directory at the depth limit is not descended into
+		if config.MaxDepth > 0 && fileInfo.IsDir() && relErr == nil && relPath != "." &&
+			strings.Count(relPath, string(filepath.Separator))+1 >= config.MaxDepth {
+			return filepath.SkipDir
+		}
 
 		// Skip common ignore patterns
 		if shouldSkip(filePath, fileInfo) {
 			if fileInfo.IsDir() {
 				return filepath.SkipDir
 			}
+			// Images and media aren't analyzed but still show in the composition
+			if relErr == nil && classifyReconFile(relPath) == reconCategoryAssets {
+				info.Categories[reconCategoryAssets]++
+			}
 			return nil
 		}
 
@@ -187,18 +478,30 @@ func scanDirectory(path string, quick bool, focus string) (*ProjectInfo, error)
 			if ext != "" {
 				fileExtensions[ext]++
 			}
+			if lang, ok := languageMap[ext]; ok {
+				if lines, generated := sourceLineCount(filePath, fileInfo.Size()); !generated {
+					languageLines[lang] += lines
+				}
+			}
+
+			if relErr != nil {
+				relPath = filePath
+			}
+			info.Categories[classifyReconFile(relPath)]++
 		}
 
 		return nil
 	})
+	progress.Done()
 
 	if err != nil {
 		return nil, err
 	}
 
-	// Detect language from file extensions
-	info.Language = detectLanguage(fileExtensions)
+	// Detect language from lines of code, falling back to file extensions
+	info.Language = detectLanguage(fileExtensions, languageLines)
 	info.CodeFiles = countCodeFiles(fileExtensions)
+	info.TestFiles = info.Categories[reconCategoryTests]
 
 	// Detect framework and build system
 	info.Framework, info.BuildSystem = detectProjectType(path)
@@ -207,23 +510,26 @@ func scanDirectory(path string, quick bool, focus string) (*ProjectInfo, error)
 	info.EntryPoints = findEntryPoints(path, allFiles, info.Language)
 
 	// Analyze architecture (unless quick mode)
-	if !quick || focus == "architecture" {
+	if !quick || focus.has("architecture") {
 		info.Architecture = analyzeArchitecture(path, allFiles, info.Language)
 	}
 
 	// Find dependencies
-	if focus == "" || focus == "security" {
+	if focus.includes("security") {
 		info.Dependencies = findDependencies(path)
 	}
 
 	// Analyze documentation
-	if !quick || focus == "docs" {
-		info.Documentation = analyzeDocumentation(path, allFiles)
+	if !quick || focus.has("docs") {
+		info.Documentation = analyzeDocumentation(path, allFiles, info.Language)
 	}
 
+	// License detection only reads the top level, so quick scans get it too
+	info.Documentation.LicenseFile, info.Documentation.License = detectLicense(path)
+
 	// Health indicators
-	if !quick || focus == "security" {
-		info.HealthIndicators = analyzeHealth(path, allFiles, quick, focus)
+	if !quick || focus.has("security") {
+		info.HealthIndicators = analyzeHealth(path, allFiles, quick, focus, config.Cache)
 	}
 
 	return info, nil
@@ -280,27 +586,30 @@ func shouldSkip(path string, info os.FileInfo) bool {
 	return skipExts[ext]
 }
 
-// detectLanguage determines the primary language from file extensions
-func detectLanguage(extensions map[string]int) string {
-	// Map extensions to languages
-	languageMap := map[string]string{
-		".go":   "Go",
-		".rs":   "Rust",
-		".js":   "JavaScript",
-		".ts":   "TypeScript",
-		".py":   "Python",
-		".java": "Java",
-		".c":    "C",
-		".cpp":  "C++",
-		".cs":   "C#",
-		".rb":   "Ruby",
-		".php":  "PHP",
-		".swift": "Swift",
-		".kt":   "Kotlin",
-		".sh":   "Shell",
-		".bash": "Bash",
-	}
+// languageMap maps file extensions to languages
+var languageMap = map[string]string{
+	".go":    "Go",
+	".rs":    "Rust",
+	".js":    "JavaScript",
+	".ts":    "TypeScript",
+	".py":    "Python",
+	".java":  "Java",
+	".c":     "C",
+	".cpp":   "C++",
+	".cs":    "C#",
+	".rb":    "Ruby",
+	".php":   "PHP",
+	".swift": "Swift",
+	".kt":    "Kotlin",
+	".sh":    "Shell",
+	".bash":  "Bash",
+}
 
+// detectLanguage determines the primary language by lines of hand-written
+// code, so a handful of large source files outweigh many tiny ones. File
+// counts break ties and decide when no lines were counted (e.g. every file
+// was generated).
+func detectLanguage(extensions map[string]int, lines map[string]int) string {
 	// Count by language
 	languageCounts := make(map[string]int)
 	for ext, count := range extensions {
@@ -309,12 +618,24 @@ func detectLanguage(extensions map[string]int) string {
 		}
 	}
 
-	// Find most common
-	maxCount := 0
+	// Find the heaviest, deterministically on ties
 	primaryLang := "Unknown"
 	for lang, count := range languageCounts {
-		if count > maxCount {
-			maxCount = count
+		if primaryLang == "Unknown" {
+			primaryLang = lang
+			continue
+		}
+		best, bestCount := lines[primaryLang], languageCounts[primaryLang]
+		switch {
+		case lines[lang] != best:
+			if lines[lang] > best {
+				primaryLang = lang
+			}
+		case count != bestCount:
+			if count > bestCount {
+				primaryLang = lang
+			}
+		case lang < primaryLang:
 			primaryLang = lang
 		}
 	}
@@ -322,6 +643,41 @@ func detectLanguage(extensions map[string]int) string {
 	return primaryLang
 }
 
+// reconGeneratedNamePattern matches file names of generated or minified code
+var reconGeneratedNamePattern = regexp.MustCompile(`(?:\.min\.js|\.pb\.go|\.pb\.\w+|_pb2\.py|[._]generated\.\w+|\.gen\.\w+|_gen\.go)$`)
+
+// reconGeneratedHeader matches the markers code generators leave near the
+// top of a file ("Code generated ... DO NOT EDIT.", "@generated",
+// "This file is auto-generated")
+var reconGeneratedHeader = regexp.MustCompile(`(?i)code generated .* do not edit|@generated|this file (?:is|was) (?:auto-?)?generated`)
+
+// reconGeneratedHeaderBytes is how much of a file is checked for a header
+const reconGeneratedHeaderBytes = 1024
+
+// sourceLineCount counts a source file's lines and reports whether it looks
+// generated, by name or header. Files over 5MB are treated as generated.
+func sourceLineCount(filePath string, size int64) (lines int, generated bool) {
+	if reconGeneratedNamePattern.MatchString(strings.ToLower(filepath.Base(filePath))) || size > 5*1024*1024 {
+		return 0, true
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, false
+	}
+
+	header := content[:min(len(content), reconGeneratedHeaderBytes)]
+	if reconGeneratedHeader.Match(header) {
+		return 0, true
+	}
+
+	lines = bytes.Count(content, []byte("\n"))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines, false
+}
+
 // countCodeFiles counts files likely to be source code
 func countCodeFiles(extensions map[string]int) int {
 	codeExts := map[string]bool{
@@ -339,6 +695,92 @@ func countCodeFiles(extensions map[string]int) int {
 	return count
 }
 
+// File categories for the Overview composition breakdown
+const (
+	reconCategoryCode   = "code"
+	reconCategoryTests  = "tests"
+	reconCategoryConfig = "config"
+	reconCategoryDocs   = "docs"
+	reconCategoryAssets = "assets"
+	reconCategoryOther  = "other"
+)
+
+// reconCategories lists the categories in display order
+var reconCategories = []string{
+	reconCategoryCode, reconCategoryTests, reconCategoryConfig,
+	reconCategoryDocs, reconCategoryAssets, reconCategoryOther,
+}
+
+// reconCategoryExts maps extensions outside languageMap to a category
+var reconCategoryExts = map[string]string{
+	// Code that doesn't count toward the primary language
+	".h": reconCategoryCode, ".hpp": reconCategoryCode, ".jsx": reconCategoryCode, ".tsx": reconCategoryCode,
+	".vue": reconCategoryCode, ".html": reconCategoryCode, ".css": reconCategoryCode, ".scss": reconCategoryCode,
+	".sql": reconCategoryCode, ".ps1": reconCategoryCode,
+
+	".json": reconCategoryConfig, ".yaml": reconCategoryConfig, ".yml": reconCategoryConfig,
+	".toml": reconCategoryConfig, ".ini": reconCategoryConfig, ".cfg": reconCategoryConfig,
+	".conf": reconCategoryConfig, ".xml": reconCategoryConfig, ".env": reconCategoryConfig,
+	".properties": reconCategoryConfig, ".mod": reconCategoryConfig, ".sum": reconCategoryConfig,
+	".lock": reconCategoryConfig,
+
+	".md": reconCategoryDocs, ".rst": reconCategoryDocs, ".txt": reconCategoryDocs,
+	".adoc": reconCategoryDocs, ".org": reconCategoryDocs,
+
+	".png": reconCategoryAssets, ".jpg": reconCategoryAssets, ".jpeg": reconCategoryAssets,
+	".gif": reconCategoryAssets, ".svg": reconCategoryAssets, ".ico": reconCategoryAssets,
+	".webp": reconCategoryAssets, ".woff": reconCategoryAssets, ".woff2": reconCategoryAssets,
+	".ttf": reconCategoryAssets, ".otf": reconCategoryAssets, ".mp3": reconCategoryAssets,
+	".mp4": reconCategoryAssets, ".wav": reconCategoryAssets, ".pdf": reconCategoryAssets,
+}
+
+// reconCategoryNames maps extensionless files to a category
+var reconCategoryNames = map[string]string{
+	"makefile": reconCategoryConfig, "dockerfile": reconCategoryConfig, "procfile": reconCategoryConfig,
+	"readme": reconCategoryDocs, "license": reconCategoryDocs, "changelog": reconCategoryDocs,
+	"authors": reconCategoryDocs, "contributing": reconCategoryDocs,
+}
+
+// reconTestNamePattern matches test file naming conventions across languages:
+// foo_test.go, test_foo.py, foo.test.ts, foo.spec.js, FooTest.java, foo_spec.rb
+var reconTestNamePattern = regexp.MustCompile(`(?:_test\.\w+|^test_.+\.py|\.(?:test|spec)\.\w+|_spec\.rb|[a-z0-9]Tests?\.(?:java|kt|cs|swift))$`)
+
+// reconTestDirs are directories whose code files are tests
+var reconTestDirs = map[string]bool{
+	"test": true, "tests": true, "__tests__": true, "spec": true, "specs": true,
+}
+
+// classifyReconFile sorts a file into one of reconCategories. Tests are code
+// files named or placed like tests; everything else goes by extension.
+func classifyReconFile(relPath string) string {
+	base := filepath.Base(relPath)
+	ext := strings.ToLower(filepath.Ext(base))
+
+	category, known := reconCategoryExts[ext]
+	if _, isLanguage := languageMap[ext]; isLanguage {
+		category, known = reconCategoryCode, true
+	}
+	if !known {
+		if category, ok := reconCategoryNames[strings.ToLower(base)]; ok {
+			return category
+		}
+		return reconCategoryOther
+	}
+
+	if category == reconCategoryCode {
+		if reconTestNamePattern.MatchString(base) {
+			return reconCategoryTests
+		}
+		for _, dir := range strings.Split(filepath.Dir(relPath), string(filepath.Separator)) {
+			if reconTestDirs[strings.ToLower(dir)] {
+				return reconCategoryTests
+			}
+		}
+	}
+
+	return category
+}
+
 // detectProjectType detects framework and build system
 func detectProjectType(path string) (framework, buildSystem string) {
 	framework = "None detected"
@@ -594,12 +1036,8 @@ func parseDepsFromGoMod(content, source string) []Dependency {
 	for _, line := range strings.Split(content, "\n") {
 		line = strings.TrimSpace(line)
 
-		if strings.HasPrefix(line, "require") {
+		if strings.HasPrefix(line, "require") && strings.HasSuffix(line, "(") {
 			inRequire = true
-			// Handle single-line require
-			if strings.Contains(line, ")") {
-				inRequire = false
-			}
 			continue
 		}
 		if inRequire && strings.HasPrefix(line, ")") {
@@ -607,6 +1045,8 @@ func parseDepsFromGoMod(content, source string) []Dependency {
 			continue
 		}
 		if inRequire || strings.HasPrefix(line, "require ") {
+			// Handle single-line require
+			line = strings.TrimPrefix(line, "require ")
 			if matches := requirePattern.FindStringSubmatch(line); len(matches) == 3 {
 				deps = append(deps, Dependency{
 					Name:    matches[1],
@@ -620,9 +1060,11 @@ func parseDepsFromGoMod(content, source string) []Dependency {
 	return deps
 }
 
-// analyzeDocumentation checks for documentation presence
-func analyzeDocumentation(path string, files []string) DocInfo {
+// analyzeDocumentation checks for documentation presence and measures
+// comment density across files of the primary language
+func analyzeDocumentation(path string, files []string, language string) DocInfo {
 	info := DocInfo{}
+	syntax, hasSyntax := languageComments[language]
 
 	for _, filePath := range files {
 		name := strings.ToLower(filepath.Base(filePath))
@@ -646,13 +1088,81 @@ func analyzeDocumentation(path string, files []string) DocInfo {
 			strings.Contains(strings.ToLower(filePath), "example") {
 			info.Examples = true
 		}
+
+		// Count lines for the primary language
+		if hasSyntax && languageMap[strings.ToLower(filepath.Ext(filePath))] == language {
+			if content, err := os.ReadFile(filePath); err == nil {
+				code, comment, blank := countLines(string(content), syntax)
+				info.CodeLines += code
+				info.CommentLines += comment
+				info.BlankLines += blank
+			}
+		}
+	}
+
+	if total := info.CodeLines + info.CommentLines; total > 0 {
+		info.InlineComments = info.CommentLines * 100 / total
 	}
 
 	return info
 }
 
+// countLines classifies each line as code, comment, or blank.
+// Lines mixing code and a trailing comment count as code.
+func countLines(content string, syntax commentSyntax) (code, comment, blank int) {
+	inBlock := false
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if inBlock {
+			comment++
+			if strings.Contains(trimmed, syntax.BlockEnd) {
+				inBlock = false
+			}
+			continue
+		}
+
+		if trimmed == "" {
+			blank++
+			continue
+		}
+
+		if syntax.BlockStart != "" && strings.HasPrefix(trimmed, syntax.BlockStart) {
+			comment++
+			rest := trimmed[len(syntax.BlockStart):]
+			if !strings.Contains(rest, syntax.BlockEnd) {
+				inBlock = true
+			}
+			continue
+		}
+
+		isComment := false
+		for _, prefix := range syntax.Line {
+			if strings.HasPrefix(trimmed, prefix) {
+				isComment = true
+				break
+			}
+		}
+		if isComment {
+			comment++
+		} else {
+			code++
+		}
+	}
+
+	return code, comment, blank
+}
+
+// Patterns for health markers
+var (
+	todoPattern  = regexp.MustCompile(`(?i)\bTODO\b:?\s*(.*)`)
+	fixmePattern = regexp.MustCompile(`(?i)\b(FIXME|HACK|XXX)\b:?\s*(.*)`)
+)
+
 // analyzeHealth finds code health indicators
-func analyzeHealth(path string, files []string, quick bool, focus string) HealthInfo {
+func analyzeHealth(path string, files []string, quick bool, focus reconFocus, cache *reconCache) HealthInfo {
 	health := HealthInfo{
 		TODOs:           []CodeMarker{},
 		FIXMEs:          []CodeMarker{},
@@ -660,19 +1170,11 @@ func analyzeHealth(path string, files []string, quick bool, focus string) Health
 		DeadCodeSignals: []string{},
 	}
 
-	// Patterns to search for
-	todoPattern := regexp.MustCompile(`(?i)\bTODO\b:?\s*(.*)`)
-	fixmePattern := regexp.MustCompile(`(?i)\b(FIXME|HACK|XXX)\b:?\s*(.*)`)
-	securityPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)password\s*=\s*["'][^"']+["']`),
-		regexp.MustCompile(`(?i)secret\s*=\s*["'][^"']+["']`),
-		regexp.MustCompile(`(?i)api[_-]?key\s*=\s*["'][^"']+["']`),
-		regexp.MustCompile(`(?i)hardcoded`),
-	}
+	health.Coverage = findCoverage(path, files)
 
 	// Limit files scanned in quick mode
 	scanLimit := len(files)
-	if quick && focus != "security" {
+	if quick && !focus.has("security") {
 		scanLimit = 50
 	}
 
@@ -687,54 +1189,336 @@ func analyzeHealth(path string, files []string, quick bool, focus string) Health
 			continue
 		}
 
-		content, err := os.ReadFile(filePath)
-		if err != nil {
+		relPath, _ := filepath.Rel(path, filePath)
+		markers, ok := fileMarkers(filePath, relPath, cache)
+		if !ok {
 			continue
 		}
 
-		relPath, _ := filepath.Rel(path, filePath)
-		lines := strings.Split(string(content), "\n")
-
-		for lineNum, line := range lines {
-			// TODO markers
-			if !quick && len(health.TODOs) < 20 {
-				if match := todoPattern.FindStringSubmatch(line); len(match) > 1 {
-					health.TODOs = append(health.TODOs, CodeMarker{
-						File:    relPath,
-						Line:    lineNum + 1,
-						Content: strings.TrimSpace(match[1]),
-					})
-				}
+		// TODO and FIXME markers
+		if !quick {
+			health.TODOs = appendMarkers(health.TODOs, markers.TODOs, maxTODOMarkers)
+			health.FIXMEs = appendMarkers(health.FIXMEs, markers.FIXMEs, maxFIXMEMarkers)
+		}
+
+		// Security concerns
+		if focus.includes("security") {
+			health.SecurityConcerns = append(health.SecurityConcerns, markers.Security...)
+		}
+	}
+
+	return health
+}
+
+// appendMarkers appends markers to dst without growing it past limit
+func appendMarkers(dst, markers []CodeMarker, limit int) []CodeMarker {
+	for _, marker := range markers {
+		if len(dst) >= limit {
+			break
+		}
+		dst = append(dst, marker)
+	}
+	return dst
+}
+
+// fileMarkers returns the health markers in a file, from the cache when the
+// file's modification time and size match. Returns false if unreadable.
+func fileMarkers(filePath, relPath string, cache *reconCache) (reconCacheEntry, bool) {
+	var stat os.FileInfo
+	if cache != nil {
+		var err error
+		if stat, err = os.Stat(filePath); err != nil {
+			return reconCacheEntry{}, false
+		}
+		if entry, ok := cache.lookup(relPath, stat); ok {
+			return entry, true
+		}
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return reconCacheEntry{}, false
+	}
+
+	entry := scanMarkers(string(content), relPath)
+	if cache != nil {
+		cache.store(relPath, stat, entry)
+	}
+	return entry, true
+}
+
+// scanMarkers finds TODO, FIXME and security markers in file content
+func scanMarkers(content, relPath string) reconCacheEntry {
+	var entry reconCacheEntry
+	lines := strings.Split(content, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+
+	for lineNum, line := range lines {
+		// TODO markers
+		if len(entry.TODOs) < maxTODOMarkers {
+			if match := todoPattern.FindStringSubmatch(line); len(match) > 1 {
+				entry.TODOs = append(entry.TODOs, CodeMarker{
+					File:    relPath,
+					Line:    lineNum + 1,
+					Content: strings.TrimSpace(match[1]),
+				})
 			}
+		}
 
-			// FIXME markers
-			if !quick && len(health.FIXMEs) < 20 {
-				if match := fixmePattern.FindStringSubmatch(line); len(match) > 2 {
-					health.FIXMEs = append(health.FIXMEs, CodeMarker{
-						File:    relPath,
-						Line:    lineNum + 1,
-						Content: strings.TrimSpace(match[2]),
-					})
-				}
+		// FIXME markers
+		if len(entry.FIXMEs) < maxFIXMEMarkers {
+			if match := fixmePattern.FindStringSubmatch(line); len(match) > 2 {
+				entry.FIXMEs = append(entry.FIXMEs, CodeMarker{
+					File:    relPath,
+					Line:    lineNum + 1,
+					Content: strings.TrimSpace(match[2]),
+				})
 			}
+		}
+	}
 
-			// Security concerns
-			if (focus == "security" || focus == "") && len(health.SecurityConcerns) < 10 {
-				for _, pattern := range securityPatterns {
-					if pattern.MatchString(line) {
-						health.SecurityConcerns = append(health.SecurityConcerns, CodeMarker{
-							File:    relPath,
-							Line:    lineNum + 1,
-							Content: strings.TrimSpace(line),
-						})
-						break
-					}
-				}
+	// Security concerns use the breach-points credential scanner, with its
+	// text file types, test directory policy and breach-ignore comments
+	ext := strings.ToLower(filepath.Ext(relPath))
+	if isBPTextFile(ext) && !inBPTestDir(relPath) {
+		findings, _ := suppressInlineIgnores(scanCredentials(relPath, ext, []byte(content), lines, nil), lines)
+		for _, f := range findings {
+			entry.Security = append(entry.Security, CodeMarker{
+				File:    relPath,
+				Line:    f.Line,
+				Content: f.Description + ": " + f.MatchedContent, // secret already redacted
+			})
+		}
+	}
+
+	return entry
+}
+
+// reconCachePath returns the cache file for a scanned root under Tank's RAM
+func reconCachePath(root string) (string, error) {
+	ramDir, err := ram.DefaultRAMDir()
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(root))
+	return filepath.Join(ramDir, "tank", "recon-cache", fmt.Sprintf("%x.json", hash[:8])), nil
+}
+
+// loadReconCache loads the cache for root. A missing, unreadable or
+// outdated cache file yields an empty cache, as does refresh.
+// Returns nil if no cache location is available.
+func loadReconCache(root string, refresh bool) *reconCache {
+	path, err := reconCachePath(root)
+	if err != nil {
+		return nil
+	}
+
+	cache := &reconCache{
+		Version: reconCacheVersion,
+		Root:    root,
+		Entries: make(map[string]reconCacheEntry),
+		path:    path,
+	}
+	if refresh {
+		cache.dirty = true
+		return cache
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+
+	var stored reconCache
+	if err := json.Unmarshal(data, &stored); err != nil ||
+		stored.Version != reconCacheVersion || stored.Root != root || stored.Entries == nil {
+		cache.dirty = true
+		return cache
+	}
+	cache.Entries = stored.Entries
+	return cache
+}
+
+// lookup returns the cached entry for relPath if the file is unchanged
+func (c *reconCache) lookup(relPath string, stat os.FileInfo) (reconCacheEntry, bool) {
+	entry, ok := c.Entries[relPath]
+	if !ok || entry.ModTime != stat.ModTime().UnixNano() || entry.Size != stat.Size() {
+		return reconCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store records the markers found in relPath
+func (c *reconCache) store(relPath string, stat os.FileInfo, entry reconCacheEntry) {
+	entry.ModTime = stat.ModTime().UnixNano()
+	entry.Size = stat.Size()
+	c.Entries[relPath] = entry
+	c.dirty = true
+}
+
+// save writes the cache if anything changed, dropping entries for files
+// that no longer exist
+func (c *reconCache) save() error {
+	for relPath := range c.Entries {
+		if _, err := os.Stat(filepath.Join(c.Root, relPath)); err != nil {
+			delete(c.Entries, relPath)
+			c.dirty = true
+		}
+	}
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// findCoverage looks for coverage artifacts and parses the overall percentage
+// from the first one in a supported format. Hidden files like .coverage are
+// skipped by the walk, so the project root is checked for them directly.
+func findCoverage(basePath string, files []string) CoverageInfo {
+	var candidates []string
+	for _, filePath := range files {
+		if _, ok := coverageFiles[filepath.Base(filePath)]; ok {
+			candidates = append(candidates, filePath)
+		}
+	}
+	for name := range coverageFiles {
+		if strings.HasPrefix(name, ".") {
+			if _, err := os.Stat(filepath.Join(basePath, name)); err == nil {
+				candidates = append(candidates, filepath.Join(basePath, name))
 			}
 		}
 	}
+	sort.Strings(candidates)
 
-	return health
+	var found CoverageInfo
+	for _, filePath := range candidates {
+		relPath, _ := filepath.Rel(basePath, filePath)
+		info := CoverageInfo{
+			File:   relPath,
+			Format: coverageFiles[filepath.Base(filePath)],
+		}
+
+		if content, err := os.ReadFile(filePath); err == nil {
+			info.Percent, info.Parsed = parseCoverage(info.Format, string(content))
+		}
+
+		if info.Parsed {
+			return info
+		}
+		if found.File == "" {
+			found = info
+		}
+	}
+
+	return found
+}
+
+// parseCoverage extracts an overall percentage from simple coverage formats
+func parseCoverage(format, content string) (float64, bool) {
+	switch format {
+	case "go":
+		return parseGoCoverProfile(content)
+	case "lcov":
+		return parseLcov(content)
+	case "cobertura":
+		match := regexp.MustCompile(`<coverage[^>]*\sline-rate="([0-9.]+)"`).FindStringSubmatch(content)
+		if len(match) < 2 {
+			return 0, false
+		}
+		rate, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		return rate * 100, true
+	}
+	return 0, false
+}
+
+// parseGoCoverProfile computes statement coverage from a Go coverprofile.
+// Blocks repeated across test binaries are counted once.
+func parseGoCoverProfile(content string) (float64, bool) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "mode:") {
+		return 0, false
+	}
+
+	type block struct {
+		statements int
+		covered    bool
+	}
+	blocks := make(map[string]*block)
+
+	for _, line := range lines[1:] {
+		// file.go:10.2,12.3 numStatements count
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		statements, err1 := strconv.Atoi(fields[1])
+		count, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+
+		b, exists := blocks[fields[0]]
+		if !exists {
+			b = &block{statements: statements}
+			blocks[fields[0]] = b
+		}
+		if count > 0 {
+			b.covered = true
+		}
+	}
+
+	total, covered := 0, 0
+	for _, b := range blocks {
+		total += b.statements
+		if b.covered {
+			covered += b.statements
+		}
+	}
+
+	if total == 0 {
+		return 0, false
+	}
+	return float64(covered) * 100 / float64(total), true
+}
+
+// parseLcov sums LF (lines found) and LH (lines hit) across all records
+func parseLcov(content string) (float64, bool) {
+	found, hit := 0, 0
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "LF:"):
+			if n, err := strconv.Atoi(line[3:]); err == nil {
+				found += n
+			}
+		case strings.HasPrefix(line, "LH:"):
+			if n, err := strconv.Atoi(line[3:]); err == nil {
+				hit += n
+			}
+		}
+	}
+
+	if found == 0 {
+		return 0, false
+	}
+	return float64(hit) * 100 / float64(found), true
 }
 
 // isTextFile returns true if the extension is likely a text file
@@ -749,7 +1533,390 @@ func isTextFile(ext string) bool {
 }
 
 // displayReconReport outputs the reconnaissance report
-func displayReconReport(info *ProjectInfo, focus string) {
+// reconJSON is the --format json shape of a recon report
+type reconJSON struct {
+	Path          string           `json:"path"`
+	ScanType      string           `json:"scan_type"`
+	Timestamp     time.Time        `json:"timestamp"`
+	Language      string           `json:"language"`
+	Framework     string           `json:"framework"`
+	BuildSystem   string           `json:"build_system"`
+	TotalFiles    int              `json:"total_files"`
+	CodeFiles     int              `json:"code_files"`
+	TestFiles     int              `json:"test_files"`
+	Categories    map[string]int   `json:"categories"`
+	Architecture  string           `json:"architecture,omitempty"`
+	EntryPoints   []reconJSONEntry `json:"entry_points"`
+	Dependencies  []reconJSONDep   `json:"dependencies"`
+	Documentation reconJSONDocs    `json:"documentation"`
+	Health        reconJSONHealth  `json:"health"`
+}
+
+type reconJSONEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+type reconJSONDep struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Source  string `json:"source"`
+}
+
+type reconJSONDocs struct {
+	HasReadme      bool   `json:"has_readme"`
+	ReadmeLines    int    `json:"readme_lines"`
+	HasDocsDir     bool   `json:"has_docs_dir"`
+	Examples       bool   `json:"examples"`
+	InlineComments int    `json:"inline_comments_percent"`
+	License        string `json:"license"` // empty when no license file was found
+	LicenseFile    string `json:"license_file,omitempty"`
+}
+
+type reconJSONHealth struct {
+	TODOs            int               `json:"todos"`
+	FIXMEs           int               `json:"fixmes"`
+	SecurityConcerns int               `json:"security_concerns"`
+	TODOMarkers      []reconJSONMarker `json:"todo_markers,omitempty"`
+	FIXMEMarkers     []reconJSONMarker `json:"fixme_markers,omitempty"`
+	SecurityMarkers  []reconJSONMarker `json:"security_markers,omitempty"`
+	DeadCodeSignals  []string          `json:"dead_code_signals,omitempty"`
+	CoverageFile     string            `json:"coverage_file,omitempty"`
+	CoveragePercent  float64           `json:"coverage_percent,omitempty"`
+}
+
+type reconJSONMarker struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Content string `json:"content"`
+}
+
+// buildReconJSON flattens a scan into its JSON report
+func buildReconJSON(info *ProjectInfo) reconJSON {
+	doc := info.Documentation
+	health := info.HealthIndicators
+	report := reconJSON{
+		Path:         info.Path,
+		ScanType:     info.ScanType,
+		Timestamp:    info.Timestamp,
+		Language:     info.Language,
+		Framework:    info.Framework,
+		BuildSystem:  info.BuildSystem,
+		TotalFiles:   info.TotalFiles,
+		CodeFiles:    info.CodeFiles,
+		TestFiles:    info.TestFiles,
+		Categories:   info.Categories,
+		Architecture: info.Architecture.Pattern,
+		EntryPoints:  []reconJSONEntry{},
+		Dependencies: []reconJSONDep{},
+		Documentation: reconJSONDocs{
+			HasReadme:      doc.HasReadme,
+			ReadmeLines:    doc.ReadmeLines,
+			HasDocsDir:     doc.HasDocsDir,
+			Examples:       doc.Examples,
+			InlineComments: doc.InlineComments,
+			License:        doc.License,
+			LicenseFile:    doc.LicenseFile,
+		},
+		Health: reconJSONHealth{
+			TODOs:            len(health.TODOs),
+			FIXMEs:           len(health.FIXMEs),
+			SecurityConcerns: len(health.SecurityConcerns),
+			TODOMarkers:      reconJSONMarkers(health.TODOs),
+			FIXMEMarkers:     reconJSONMarkers(health.FIXMEs),
+			SecurityMarkers:  reconJSONMarkers(health.SecurityConcerns),
+			DeadCodeSignals:  health.DeadCodeSignals,
+			CoverageFile:     health.Coverage.File,
+			CoveragePercent:  health.Coverage.Percent,
+		},
+	}
+	for _, ep := range info.EntryPoints {
+		report.EntryPoints = append(report.EntryPoints, reconJSONEntry{Path: ep.Path, Type: ep.Type})
+	}
+	for _, dep := range info.Dependencies {
+		report.Dependencies = append(report.Dependencies, reconJSONDep{Name: dep.Name, Version: dep.Version, Source: dep.Source})
+	}
+	return report
+}
+
+// reconJSONMarkers converts code markers to their JSON form
+func reconJSONMarkers(markers []CodeMarker) []reconJSONMarker {
+	var out []reconJSONMarker
+	for _, m := range markers {
+		out = append(out, reconJSONMarker{File: m.File, Line: m.Line, Content: m.Content})
+	}
+	return out
+}
+
+// saveReconJSON writes a JSON report for a later --compare
+func saveReconJSON(path string, report reconJSON) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recon report: %w", err)
+	}
+	if err := util.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save recon report: %w", err)
+	}
+	return nil
+}
+
+// loadReconJSON reads a report written by --save or --format json
+func loadReconJSON(path string) (*reconJSON, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recon report: %w", err)
+	}
+	var report reconJSON
+	if err := json.Unmarshal(data, &report); err != nil || report.Path == "" {
+		return nil, fmt.Errorf("%s is not a recon JSON report (save one with --save or --format json)", path)
+	}
+	return &report, nil
+}
+
+// reconCountChange is a count that moved between two scans
+type reconCountChange struct {
+	Name string `json:"name"`
+	Old  int    `json:"old"`
+	New  int    `json:"new"`
+}
+
+// reconDepChange is a dependency whose version moved between two scans
+type reconDepChange struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	OldVersion string `json:"old_version"`
+	NewVersion string `json:"new_version"`
+}
+
+// reconDelta is what changed between a saved recon report and a new scan
+type reconDelta struct {
+	Path                string             `json:"path"`
+	PreviousPath        string             `json:"previous_path,omitempty"` // set when the saved scan was of another path
+	Since               time.Time          `json:"since"`
+	Counts              []reconCountChange `json:"counts"`
+	AddedDependencies   []reconJSONDep     `json:"added_dependencies"`
+	RemovedDependencies []reconJSONDep     `json:"removed_dependencies"`
+	ChangedDependencies []reconDepChange   `json:"changed_dependencies"`
+	NewTODOs            []reconJSONMarker  `json:"new_todos"`
+	NewFIXMEs           []reconJSONMarker  `json:"new_fixmes"`
+	NewSecurityConcerns []reconJSONMarker  `json:"new_security_concerns"`
+}
+
+// empty reports whether nothing changed between the scans
+func (d reconDelta) empty() bool {
+	return len(d.Counts) == 0 && len(d.AddedDependencies) == 0 && len(d.RemovedDependencies) == 0 &&
+		len(d.ChangedDependencies) == 0 && len(d.NewTODOs) == 0 && len(d.NewFIXMEs) == 0 && len(d.NewSecurityConcerns) == 0
+}
+
+// compareReconReports diffs a new scan against an old one. Dependencies are
+// matched by name and manifest; markers by file and text, so a TODO that only
+// moved lines isn't new. Only the markers recon reports (capped per file and
+// per scan) are compared; the counts show the overall movement.
+func compareReconReports(old, current *reconJSON) reconDelta {
+	delta := reconDelta{
+		Path:                current.Path,
+		Since:               old.Timestamp,
+		Counts:              []reconCountChange{},
+		AddedDependencies:   []reconJSONDep{},
+		RemovedDependencies: []reconJSONDep{},
+		ChangedDependencies: []reconDepChange{},
+		NewTODOs:            newReconMarkers(old.Health.TODOMarkers, current.Health.TODOMarkers),
+		NewFIXMEs:           newReconMarkers(old.Health.FIXMEMarkers, current.Health.FIXMEMarkers),
+		NewSecurityConcerns: newReconMarkers(old.Health.SecurityMarkers, current.Health.SecurityMarkers),
+	}
+	if old.Path != current.Path {
+		delta.PreviousPath = old.Path
+	}
+
+	addCount := func(name string, before, after int) {
+		if before != after {
+			delta.Counts = append(delta.Counts, reconCountChange{Name: name, Old: before, New: after})
+		}
+	}
+	addCount("total files", old.TotalFiles, current.TotalFiles)
+	addCount("code files", old.CodeFiles, current.CodeFiles)
+	addCount("test files", old.TestFiles, current.TestFiles)
+	for _, category := range reconCategories {
+		addCount("files in "+category, old.Categories[category], current.Categories[category])
+	}
+	addCount("TODOs", old.Health.TODOs, current.Health.TODOs)
+	addCount("FIXMEs", old.Health.FIXMEs, current.Health.FIXMEs)
+	addCount("security concerns", old.Health.SecurityConcerns, current.Health.SecurityConcerns)
+
+	depKey := func(dep reconJSONDep) string { return dep.Source + "\x00" + dep.Name }
+	oldDeps := make(map[string]reconJSONDep)
+	for _, dep := range old.Dependencies {
+		oldDeps[depKey(dep)] = dep
+	}
+	currentDeps := make(map[string]bool)
+	for _, dep := range current.Dependencies {
+		currentDeps[depKey(dep)] = true
+		previous, existed := oldDeps[depKey(dep)]
+		switch {
+		case !existed:
+			delta.AddedDependencies = append(delta.AddedDependencies, dep)
+		case previous.Version != dep.Version:
+			delta.ChangedDependencies = append(delta.ChangedDependencies, reconDepChange{
+				Name: dep.Name, Source: dep.Source, OldVersion: previous.Version, NewVersion: dep.Version,
+			})
+		}
+	}
+	for _, dep := range old.Dependencies {
+		if !currentDeps[depKey(dep)] {
+			delta.RemovedDependencies = append(delta.RemovedDependencies, dep)
+		}
+	}
+
+	return delta
+}
+
+// newReconMarkers returns the markers in current with no match in old by
+// file and text
+func newReconMarkers(old, current []reconJSONMarker) []reconJSONMarker {
+	seen := make(map[string]int)
+	for _, m := range old {
+		seen[m.File+"\x00"+m.Content]++
+	}
+	added := []reconJSONMarker{}
+	for _, m := range current {
+		key := m.File + "\x00" + m.Content
+		if seen[key] > 0 {
+			seen[key]--
+			continue
+		}
+		added = append(added, m)
+	}
+	return added
+}
+
+// displayReconDelta prints what changed since a saved report
+func displayReconDelta(delta reconDelta, savedFile string) {
+	output.Success("📋 Reconnaissance Changes")
+	fmt.Println("")
+	fmt.Printf("Location: %s\n", delta.Path)
+	fmt.Printf("Since: %s (%s)\n", delta.Since.Format("2006-01-02 15:04:05"), savedFile)
+	if delta.PreviousPath != "" {
+		fmt.Printf("%sNote: the saved scan was of %s%s\n", output.Yellow, delta.PreviousPath, output.Reset)
+	}
+	fmt.Println("")
+
+	if delta.empty() {
+		output.Success("✓ No changes since the saved scan")
+		return
+	}
+
+	if len(delta.Counts) > 0 {
+		output.Header("Counts")
+		fmt.Println("")
+		for _, c := range delta.Counts {
+			fmt.Printf("  %-18s %5d → %-5d (%+d)\n", c.Name, c.Old, c.New, c.New-c.Old)
+		}
+		fmt.Println("")
+	}
+
+	if len(delta.AddedDependencies)+len(delta.RemovedDependencies)+len(delta.ChangedDependencies) > 0 {
+		output.Header("Dependencies")
+		fmt.Println("")
+		for _, dep := range delta.AddedDependencies {
+			fmt.Printf("  %s+ %s %s%s (%s)\n", output.Green, dep.Name, dep.Version, output.Reset, dep.Source)
+		}
+		for _, dep := range delta.RemovedDependencies {
+			fmt.Printf("  %s- %s %s%s (%s)\n", output.Red, dep.Name, dep.Version, output.Reset, dep.Source)
+		}
+		for _, dep := range delta.ChangedDependencies {
+			fmt.Printf("  %s~ %s %s → %s%s (%s)\n", output.Yellow, dep.Name, dep.OldVersion, dep.NewVersion, output.Reset, dep.Source)
+		}
+		fmt.Println("")
+	}
+
+	displayNewReconMarkers("New TODOs", delta.NewTODOs, output.Yellow)
+	displayNewReconMarkers("New FIXMEs", delta.NewFIXMEs, output.Yellow)
+	displayNewReconMarkers("New Security Concerns", delta.NewSecurityConcerns, output.Red)
+}
+
+// displayNewReconMarkers prints one section of new markers, if any
+func displayNewReconMarkers(title string, markers []reconJSONMarker, color string) {
+	if len(markers) == 0 {
+		return
+	}
+	output.Header(fmt.Sprintf("%s (%d)", title, len(markers)))
+	fmt.Println("")
+	for _, m := range markers {
+		fmt.Printf("  %s%s:%d%s %s\n", color, m.File, m.Line, output.Reset, m.Content)
+	}
+	fmt.Println("")
+}
+
+// detectLicense finds a top-level license file and identifies its license.
+// Returns empty strings when the project has no license file.
+func detectLicense(root string) (file, license string) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", ""
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !licenseFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(root, entry.Name()))
+		if err != nil {
+			continue
+		}
+		if id := identifyLicense(string(content)); id != "Unknown" || file == "" {
+			file, license = entry.Name(), id
+		}
+		if license != "Unknown" {
+			break
+		}
+	}
+	return file, license
+}
+
+// identifyLicense matches well-known license headers and returns an SPDX
+// identifier, or "Unknown" when the text doesn't match any of them
+func identifyLicense(content string) string {
+	if m := spdxIdentifierPattern.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+
+	text := strings.ToLower(strings.Join(strings.Fields(content), " "))
+	switch {
+	case strings.Contains(text, "gnu affero general public license"):
+		return "AGPL-3.0"
+	case strings.Contains(text, "gnu lesser general public license"):
+		if strings.Contains(text, "version 3") {
+			return "LGPL-3.0"
+		}
+		return "LGPL-2.1"
+	case strings.Contains(text, "gnu general public license"):
+		if strings.Contains(text, "version 3") {
+			return "GPL-3.0"
+		}
+		if strings.Contains(text, "version 2") {
+			return "GPL-2.0"
+		}
+		return "GPL"
+	case strings.Contains(text, "apache license") && strings.Contains(text, "version 2.0"):
+		return "Apache-2.0"
+	case strings.Contains(text, "mozilla public license") && strings.Contains(text, "2.0"):
+		return "MPL-2.0"
+	case strings.Contains(text, "free and unencumbered software released into the public domain"):
+		return "Unlicense"
+	case strings.Contains(text, "permission is hereby granted, free of charge"):
+		return "MIT"
+	case strings.Contains(text, "permission to use, copy, modify, and/or distribute this software"):
+		return "ISC"
+	case strings.Contains(text, "redistribution and use in source and binary forms"):
+		if strings.Contains(text, "neither the name") || strings.Contains(text, "endorse or promote") {
+			return "BSD-3-Clause"
+		}
+		return "BSD-2-Clause"
+	}
+	return "Unknown"
+}
+
+func displayReconReport(info *ProjectInfo, focus reconFocus) {
 	output.Success("📋 Reconnaissance Report")
 	fmt.Println("")
 
@@ -759,7 +1926,7 @@ func displayReconReport(info *ProjectInfo, focus string) {
 	fmt.Println("")
 
 	// Overview section
-	if focus == "" || focus == "architecture" {
+	if focus.includes("architecture") {
 		output.Header("Overview")
 		fmt.Println("")
 		output.Item("Language", info.Language)
@@ -767,11 +1934,26 @@ func displayReconReport(info *ProjectInfo, focus string) {
 		output.Item("Build System", info.BuildSystem)
 		output.Item("Total Files", fmt.Sprintf("%d", info.TotalFiles))
 		output.Item("Code Files", fmt.Sprintf("%d", info.CodeFiles))
+		categorized := 0
+		for _, count := range info.Categories {
+			categorized += count
+		}
+		if categorized > 0 {
+			fmt.Println("")
+			fmt.Println("  Composition:")
+			for _, category := range reconCategories {
+				count := info.Categories[category]
+				if count == 0 {
+					continue
+				}
+				fmt.Printf("    %-7s %5d  %5.1f%%\n", category, count, float64(count)*100/float64(categorized))
+			}
+		}
 		fmt.Println("")
 	}
 
 	// Entry points
-	if (focus == "" || focus == "architecture") && len(info.EntryPoints) > 0 {
+	if focus.includes("architecture") && len(info.EntryPoints) > 0 {
 		output.Header("Entry Points")
 		fmt.Println("")
 		for i, ep := range info.EntryPoints {
@@ -784,7 +1966,7 @@ func displayReconReport(info *ProjectInfo, focus string) {
 	}
 
 	// Architecture
-	if focus == "" || focus == "architecture" {
+	if focus.includes("architecture") {
 		output.Header("Architecture")
 		fmt.Println("")
 		output.Item("Pattern", info.Architecture.Pattern)
@@ -799,7 +1981,7 @@ func displayReconReport(info *ProjectInfo, focus string) {
 	}
 
 	// Dependencies
-	if (focus == "" || focus == "security") && len(info.Dependencies) > 0 {
+	if focus.includes("security") && len(info.Dependencies) > 0 {
 		output.Header("Dependencies")
 		fmt.Println("")
 		fmt.Printf("  Found %d dependencies\n", len(info.Dependencies))
@@ -827,7 +2009,7 @@ func displayReconReport(info *ProjectInfo, focus string) {
 	}
 
 	// Documentation
-	if focus == "" || focus == "docs" {
+	if focus.includes("docs") {
 		output.Header("Documentation")
 		fmt.Println("")
 		if info.Documentation.HasReadme {
@@ -835,17 +2017,30 @@ func displayReconReport(info *ProjectInfo, focus string) {
 		} else {
 			fmt.Println("  ✗ No README found")
 		}
+		switch doc := info.Documentation; {
+		case doc.LicenseFile == "":
+			fmt.Printf("  %s✗ No license found%s - others can't legally use or contribute to this code\n", output.Red, output.Reset)
+		case doc.License == "Unknown":
+			fmt.Printf("  %s? License file %s found, but the license wasn't recognized%s\n", output.Yellow, doc.LicenseFile, output.Reset)
+		default:
+			fmt.Printf("  ✓ License: %s (%s)\n", doc.License, doc.LicenseFile)
+		}
 		if info.Documentation.HasDocsDir {
 			fmt.Println("  ✓ Documentation directory found")
 		}
 		if info.Documentation.Examples {
 			fmt.Println("  ✓ Examples found")
 		}
+		if doc := info.Documentation; doc.CodeLines+doc.CommentLines > 0 {
+			fmt.Printf("  Code density (%s): %d code, %d comment, %d blank lines\n",
+				info.Language, doc.CodeLines, doc.CommentLines, doc.BlankLines)
+			fmt.Printf("  Inline comments: %d%%\n", doc.InlineComments)
+		}
 		fmt.Println("")
 	}
 
 	// Health indicators
-	if focus == "" || focus == "security" {
+	if focus.includes("security") {
 		output.Header("Health Indicators")
 		fmt.Println("")
 
@@ -880,11 +2075,22 @@ func displayReconReport(info *ProjectInfo, focus string) {
 					fmt.Printf("  ... and %d more\n", len(info.HealthIndicators.SecurityConcerns)-5)
 					break
 				}
-				fmt.Printf("    - %s:%d\n", concern.File, concern.Line)
+				fmt.Printf("    - %s:%d - %s\n", concern.File, concern.Line, concern.Content)
 			}
 			fmt.Println("")
 		}
 
+		coverage := info.HealthIndicators.Coverage
+		switch {
+		case coverage.Parsed:
+			fmt.Printf("  Coverage: %.1f%% (%s, %s)\n", coverage.Percent, coverage.File, coverage.Format)
+		case coverage.File != "":
+			fmt.Printf("  Coverage: %s found (%s format not parsed)\n", coverage.File, coverage.Format)
+		default:
+			fmt.Println("  Coverage: no coverage file found")
+		}
+		fmt.Println("")
+
 		if len(info.HealthIndicators.TODOs) == 0 &&
 			len(info.HealthIndicators.FIXMEs) == 0 &&
 			len(info.HealthIndicators.SecurityConcerns) == 0 {
@@ -895,3 +2101,170 @@ func displayReconReport(info *ProjectInfo, focus string) {
 
 	output.Success("🔍 Reconnaissance complete")
 }
+
+// renderReconMarkdown renders the recon report as plain markdown, suitable for
+// committing as RECON.md. Unlike the terminal report nothing is truncated, and
+// the project is named by its directory rather than its absolute path.
+func renderReconMarkdown(info *ProjectInfo, focus reconFocus) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Recon: %s\n\n", filepath.Base(info.Path))
+	fmt.Fprintf(&b, "- **Scanned:** %s\n", info.Timestamp.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "- **Scan type:** %s\n", info.ScanType)
+
+	if focus.includes("architecture") {
+		b.WriteString("\n## Overview\n\n")
+		b.WriteString("| Property | Value |\n")
+		b.WriteString("|----------|-------|\n")
+		for _, row := range [][2]string{
+			{"Language", info.Language},
+			{"Framework", info.Framework},
+			{"Build System", info.BuildSystem},
+			{"Total Files", fmt.Sprintf("%d", info.TotalFiles)},
+			{"Code Files", fmt.Sprintf("%d", info.CodeFiles)},
+			{"Test Files", fmt.Sprintf("%d", info.TestFiles)},
+		} {
+			fmt.Fprintf(&b, "| %s | %s |\n", row[0], reconMarkdownCell(row[1]))
+		}
+
+		categorized := 0
+		for _, count := range info.Categories {
+			categorized += count
+		}
+		if categorized > 0 {
+			b.WriteString("\n### Composition\n\n")
+			b.WriteString("| Category | Files | Share |\n")
+			b.WriteString("|----------|------:|------:|\n")
+			for _, category := range reconCategories {
+				if count := info.Categories[category]; count > 0 {
+					fmt.Fprintf(&b, "| %s | %d | %.1f%% |\n", category, count, float64(count)*100/float64(categorized))
+				}
+			}
+		}
+
+		if len(info.EntryPoints) > 0 {
+			b.WriteString("\n## Entry Points\n\n")
+			b.WriteString("| Path | Type | Description |\n")
+			b.WriteString("|------|------|-------------|\n")
+			for _, ep := range info.EntryPoints {
+				fmt.Fprintf(&b, "| `%s` | %s | %s |\n", reconMarkdownCell(ep.Path), ep.Type, reconMarkdownCell(ep.Description))
+			}
+		}
+
+		b.WriteString("\n## Architecture\n\n")
+		fmt.Fprintf(&b, "**Pattern:** %s\n", info.Architecture.Pattern)
+		if len(info.Architecture.KeyModules) > 0 {
+			b.WriteString("\n| Module | Files |\n")
+			b.WriteString("|--------|------:|\n")
+			for _, mod := range info.Architecture.KeyModules {
+				fmt.Fprintf(&b, "| `%s` | %d |\n", reconMarkdownCell(mod.Path), mod.FileCount)
+			}
+		}
+	}
+
+	if focus.includes("security") && len(info.Dependencies) > 0 {
+		fmt.Fprintf(&b, "\n## Dependencies\n\nFound %d dependencies.\n", len(info.Dependencies))
+
+		var sources []string
+		bySource := make(map[string][]Dependency)
+		for _, dep := range info.Dependencies {
+			if bySource[dep.Source] == nil {
+				sources = append(sources, dep.Source)
+			}
+			bySource[dep.Source] = append(bySource[dep.Source], dep)
+		}
+		for _, source := range sources {
+			fmt.Fprintf(&b, "\n### %s\n\n", source)
+			b.WriteString("| Name | Version |\n")
+			b.WriteString("|------|---------|\n")
+			for _, dep := range bySource[source] {
+				fmt.Fprintf(&b, "| %s | %s |\n", reconMarkdownCell(dep.Name), reconMarkdownCell(dep.Version))
+			}
+		}
+	}
+
+	if focus.includes("docs") {
+		doc := info.Documentation
+		b.WriteString("\n## Documentation\n\n")
+		if doc.HasReadme {
+			fmt.Fprintf(&b, "- README: found (%d lines)\n", doc.ReadmeLines)
+		} else {
+			b.WriteString("- README: missing\n")
+		}
+		switch {
+		case doc.LicenseFile == "":
+			b.WriteString("- **License: none found**\n")
+		case doc.License == "Unknown":
+			fmt.Fprintf(&b, "- License: unrecognized (%s)\n", doc.LicenseFile)
+		default:
+			fmt.Fprintf(&b, "- License: %s (%s)\n", doc.License, doc.LicenseFile)
+		}
+		fmt.Fprintf(&b, "- Docs directory: %s\n", reconYesNo(doc.HasDocsDir))
+		fmt.Fprintf(&b, "- Examples: %s\n", reconYesNo(doc.Examples))
+		if doc.CodeLines+doc.CommentLines > 0 {
+			fmt.Fprintf(&b, "- Code density (%s): %d code, %d comment, %d blank lines\n",
+				info.Language, doc.CodeLines, doc.CommentLines, doc.BlankLines)
+			fmt.Fprintf(&b, "- Inline comments: %d%%\n", doc.InlineComments)
+		}
+	}
+
+	if focus.includes("security") {
+		health := info.HealthIndicators
+		b.WriteString("\n## Health\n\n")
+		b.WriteString("| Indicator | Value |\n")
+		b.WriteString("|-----------|-------|\n")
+		fmt.Fprintf(&b, "| TODOs | %d |\n", len(health.TODOs))
+		fmt.Fprintf(&b, "| FIXMEs | %d |\n", len(health.FIXMEs))
+		fmt.Fprintf(&b, "| Security concerns | %d |\n", len(health.SecurityConcerns))
+		switch coverage := health.Coverage; {
+		case coverage.Parsed:
+			fmt.Fprintf(&b, "| Coverage | %.1f%% (`%s`, %s) |\n", coverage.Percent, coverage.File, coverage.Format)
+		case coverage.File != "":
+			fmt.Fprintf(&b, "| Coverage | `%s` (%s format not parsed) |\n", coverage.File, coverage.Format)
+		default:
+			b.WriteString("| Coverage | no coverage file found |\n")
+		}
+
+		for _, section := range []struct {
+			title   string
+			markers []CodeMarker
+		}{
+			{"TODOs", health.TODOs},
+			{"FIXMEs", health.FIXMEs},
+		} {
+			if len(section.markers) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n", section.title)
+			b.WriteString("| Location | Note |\n")
+			b.WriteString("|----------|------|\n")
+			for _, marker := range section.markers {
+				fmt.Fprintf(&b, "| `%s:%d` | %s |\n", reconMarkdownCell(marker.File), marker.Line, reconMarkdownCell(marker.Content))
+			}
+		}
+
+		// Matches are redacted by the breach-points scanner
+		if len(health.SecurityConcerns) > 0 {
+			b.WriteString("\n### Security Concerns\n\n")
+			for _, concern := range health.SecurityConcerns {
+				fmt.Fprintf(&b, "- `%s:%d` %s\n", concern.File, concern.Line, reconMarkdownCell(concern.Content))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// reconMarkdownCell makes text safe to place in a markdown table cell
+func reconMarkdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// reconYesNo renders a boolean for the markdown report
+func reconYesNo(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,669 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestScanDirectoryExcludeDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":                "package main\n",
+		"generated/api.go":       "package generated\n",
+		"generated/deep/more.go": "package deep\n",
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{Quick: true, Excludes: []string{"generated"}})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	if info.TotalFiles != 1 {
+		t.Errorf("Expected 1 file with generated/ excluded, got %d", info.TotalFiles)
+	}
+	for _, ep := range info.EntryPoints {
+		if strings.HasPrefix(ep.Path, "generated") {
+			t.Errorf("Excluded path reported as entry point: %s", ep.Path)
+		}
+	}
+}
+
+func TestScanDirectoryExcludeFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":           "package main\n",
+		"api/service.go":    "package api\n",
+		"api/api.pb.go":     "package api\n",
+		"proto/types.pb.go": "package proto\n",
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{Quick: true, Excludes: []string{"*.pb.go"}})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	if info.TotalFiles != 2 {
+		t.Errorf("Expected 2 files with *.pb.go excluded, got %d", info.TotalFiles)
+	}
+	if info.CodeFiles != 2 {
+		t.Errorf("Expected 2 code files with *.pb.go excluded, got %d", info.CodeFiles)
+	}
+}
+
+func TestScanDirectoryMaxDepth(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":           "package main\n",
+		"README.md":         "# demo\n",
+		"api/service.go":    "package api\n",
+		"api/v1/handler.go": "package v1\n",
+		"api/v1/deep/x.go":  "package deep\n",
+	})
+
+	tests := []struct {
+		depth int
+		want  int
+	}{
+		{0, 5},
+		{1, 2},
+		{2, 3},
+		{3, 4},
+	}
+	for _, tt := range tests {
+		info, err := scanDirectory(tmpDir, ReconConfig{Quick: true, MaxDepth: tt.depth})
+		if err != nil {
+			t.Fatalf("scanDirectory() failed: %v", err)
+		}
+		if info.TotalFiles != tt.want {
+			t.Errorf("depth %d: expected %d files, got %d", tt.depth, tt.want, info.TotalFiles)
+		}
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	tests := []struct {
+		name                 string
+		content              string
+		syntax               commentSyntax
+		code, comment, blank int
+	}{
+		{
+			name:    "go line and block comments",
+			content: "// Package x\npackage x\n\n/*\nblock\n*/\nfunc f() {} // trailing\n",
+			syntax:  languageComments["Go"],
+			code:    2, comment: 4, blank: 1,
+		},
+		{
+			name:    "single-line block comment",
+			content: "/* one */\nlet x = 1;\n",
+			syntax:  languageComments["JavaScript"],
+			code:    1, comment: 1, blank: 0,
+		},
+		{
+			name:    "python hash and docstrings",
+			content: "\"\"\"Module doc.\"\"\"\n# comment\ndef f():\n    \"\"\"\n    Multi-line.\n    \"\"\"\n\n    return 1\n",
+			syntax:  languageComments["Python"],
+			code:    2, comment: 5, blank: 1,
+		},
+		{
+			name:    "rust doc comments",
+			content: "/// Docs\nfn main() {\n}\n",
+			syntax:  languageComments["Rust"],
+			code:    2, comment: 1, blank: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, comment, blank := countLines(tt.content, tt.syntax)
+			if code != tt.code || comment != tt.comment || blank != tt.blank {
+				t.Errorf("countLines() = (%d, %d, %d), want (%d, %d, %d)",
+					code, comment, blank, tt.code, tt.comment, tt.blank)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDocumentationCommentDensity(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":   "// main runs\npackage main\n\nfunc main() {}\n",
+		"util.go":   "package main\n",
+		"script.py": "# not the primary language\n",
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	doc := info.Documentation
+	if doc.CodeLines != 3 || doc.CommentLines != 1 || doc.BlankLines != 1 {
+		t.Errorf("Expected 3 code, 1 comment, 1 blank; got %d, %d, %d",
+			doc.CodeLines, doc.CommentLines, doc.BlankLines)
+	}
+	if doc.InlineComments != 25 {
+		t.Errorf("Expected 25%% inline comments, got %d", doc.InlineComments)
+	}
+}
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		percent float64
+		parsed  bool
+	}{
+		{
+			name:    "go coverprofile",
+			format:  "go",
+			content: "mode: set\na.go:1.1,3.2 3 1\na.go:5.1,6.2 1 0\n",
+			percent: 75,
+			parsed:  true,
+		},
+		{
+			name:    "go coverprofile repeated blocks",
+			format:  "go",
+			content: "mode: count\na.go:1.1,3.2 2 0\na.go:1.1,3.2 2 4\nb.go:1.1,2.2 2 0\n",
+			percent: 50,
+			parsed:  true,
+		},
+		{
+			name:    "lcov",
+			format:  "lcov",
+			content: "SF:a.js\nLF:10\nLH:8\nend_of_record\nSF:b.js\nLF:10\nLH:2\nend_of_record\n",
+			percent: 50,
+			parsed:  true,
+		},
+		{
+			name:    "cobertura",
+			format:  "cobertura",
+			content: `<?xml version="1.0" ?><coverage branch-rate="0" line-rate="0.825" version="7.2">`,
+			percent: 82.5,
+			parsed:  true,
+		},
+		{
+			name:    "not a coverprofile",
+			format:  "go",
+			content: "hello\n",
+		},
+		{
+			name:    "unsupported format",
+			format:  "python",
+			content: "SQLite format 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percent, parsed := parseCoverage(tt.format, tt.content)
+			if parsed != tt.parsed || percent != tt.percent {
+				t.Errorf("parseCoverage() = (%v, %v), want (%v, %v)", percent, parsed, tt.percent, tt.parsed)
+			}
+		})
+	}
+}
+
+func TestFindCoveragePrefersParseable(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":            "package main\n",
+		".coverage":          "SQLite format 3",
+		"coverage/lcov.info": "LF:4\nLH:3\n",
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	coverage := info.HealthIndicators.Coverage
+	if !coverage.Parsed || coverage.Format != "lcov" || coverage.Percent != 75 {
+		t.Errorf("Expected parsed lcov coverage of 75%%, got %+v", coverage)
+	}
+	if coverage.File != filepath.Join("coverage", "lcov.info") {
+		t.Errorf("Expected relative coverage path, got %s", coverage.File)
+	}
+}
+
+func TestReconCacheReusesUnchangedFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go": "package main\n// TODO: first\n",
+		"util.go": "package main\n// FIXME: broken\n",
+	})
+	config := ReconConfig{Cache: loadReconCache(tmpDir, false)}
+
+	info, err := scanDirectory(tmpDir, config)
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+	if len(info.HealthIndicators.TODOs) != 1 || len(info.HealthIndicators.FIXMEs) != 1 {
+		t.Fatalf("Expected 1 TODO and 1 FIXME, got %+v", info.HealthIndicators)
+	}
+	if err := config.Cache.save(); err != nil {
+		t.Fatalf("save() failed: %v", err)
+	}
+
+	// Tamper with the stored entry for the unchanged file to prove it is reused
+	cache := loadReconCache(tmpDir, false)
+	entry, ok := cache.Entries["util.go"]
+	if !ok {
+		t.Fatalf("Expected util.go in saved cache, got %v", cache.Entries)
+	}
+	entry.FIXMEs[0].Content = "from cache"
+	cache.Entries["util.go"] = entry
+
+	// A changed file is re-scanned
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go": "package main\n// TODO: second one\n",
+	})
+
+	info, err = scanDirectory(tmpDir, ReconConfig{Cache: cache})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+	if got := info.HealthIndicators.FIXMEs[0].Content; got != "from cache" {
+		t.Errorf("Expected unchanged file served from cache, got %q", got)
+	}
+	if got := info.HealthIndicators.TODOs[0].Content; got != "second one" {
+		t.Errorf("Expected modified file to be re-scanned, got %q", got)
+	}
+
+	// Refresh ignores stored entries
+	info, err = scanDirectory(tmpDir, ReconConfig{Cache: loadReconCache(tmpDir, true)})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+	if got := info.HealthIndicators.FIXMEs[0].Content; got != "broken" {
+		t.Errorf("Expected --refresh to re-scan, got %q", got)
+	}
+}
+
+func TestReconCacheDropsDeletedFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go": "package main\n",
+		"old.go":  "package main\n// TODO: gone soon\n",
+	})
+
+	config := ReconConfig{Cache: loadReconCache(tmpDir, false)}
+	if _, err := scanDirectory(tmpDir, config); err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "old.go")); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Cache.save(); err != nil {
+		t.Fatalf("save() failed: %v", err)
+	}
+
+	if _, ok := loadReconCache(tmpDir, false).Entries["old.go"]; ok {
+		t.Error("Expected deleted file to be dropped from the cache")
+	}
+}
+
+func TestParseReconFocus(t *testing.T) {
+	focus, err := parseReconFocus("security, Architecture")
+	if err != nil {
+		t.Fatalf("parseReconFocus() failed: %v", err)
+	}
+	if !focus.has("security") || !focus.has("architecture") || focus.has("docs") {
+		t.Errorf("Unexpected focus set: %v", focus)
+	}
+	if focus.includes("docs") {
+		t.Error("docs should be excluded when other aspects are focused")
+	}
+	if got := focus.String(); got != "security, architecture" {
+		t.Errorf("String() = %q", got)
+	}
+
+	single, err := parseReconFocus("docs")
+	if err != nil || !single.has("docs") || len(single) != 1 {
+		t.Errorf("Single focus value should still work, got %v (%v)", single, err)
+	}
+
+	none, err := parseReconFocus("")
+	if err != nil || len(none) != 0 || !none.includes("security") {
+		t.Errorf("Empty focus should include everything, got %v (%v)", none, err)
+	}
+
+	if _, err := parseReconFocus("security,perf"); err == nil || !strings.Contains(err.Error(), "perf") {
+		t.Errorf("Expected an error naming the invalid token, got %v", err)
+	}
+}
+
+func TestScanDirectoryMultipleFocus(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"README.md":         "# Project\n",
+		"cmd/app/main.go":   "package main\n",
+		"internal/db/db.go": "package db\n// FIXME: password = \"hunter22\"\n",
+		"internal/api/h.go": "package api\n",
+		"docs/guide.md":     "guide\n",
+	})
+
+	focus, _ := parseReconFocus("security,architecture")
+	info, err := scanDirectory(tmpDir, ReconConfig{Quick: true, Focus: focus})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	if len(info.Architecture.Directories) == 0 {
+		t.Error("Expected architecture analysis with architecture in focus")
+	}
+	if len(info.HealthIndicators.SecurityConcerns) == 0 {
+		t.Error("Expected security concerns with security in focus")
+	}
+	if info.Documentation.HasReadme {
+		t.Error("Expected documentation analysis skipped without docs in focus")
+	}
+}
+
+func TestClassifyReconFile(t *testing.T) {
+	tests := map[string]string{
+		"main.go":                  reconCategoryCode,
+		"web/app.tsx":              reconCategoryCode,
+		"pkg/scan_test.go":         reconCategoryTests,
+		"test_models.py":           reconCategoryTests,
+		"src/cart.spec.ts":         reconCategoryTests,
+		"src/FooTest.java":         reconCategoryTests,
+		"src/Latest.java":          reconCategoryCode,
+		"tests/helpers.py":         reconCategoryTests,
+		"tests/fixtures/user.json": reconCategoryConfig,
+		"config/app.yaml":          reconCategoryConfig,
+		"go.mod":                   reconCategoryConfig,
+		"Dockerfile":               reconCategoryConfig,
+		"docs/guide.md":            reconCategoryDocs,
+		"LICENSE":                  reconCategoryDocs,
+		"assets/logo.svg":          reconCategoryAssets,
+		"bin/tool":                 reconCategoryOther,
+		"data/archive.tar.gz":      reconCategoryOther,
+	}
+	for path, want := range tests {
+		if got := classifyReconFile(filepath.FromSlash(path)); got != want {
+			t.Errorf("classifyReconFile(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestScanDirectoryCategories(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":        "package main\n",
+		"main_test.go":   "package main\n",
+		"go.mod":         "module example\n",
+		"README.md":      "# Example\n",
+		"static/app.png": "png",
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{Quick: true})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	want := map[string]int{
+		reconCategoryCode: 1, reconCategoryTests: 1, reconCategoryConfig: 1,
+		reconCategoryDocs: 1, reconCategoryAssets: 1,
+	}
+	if !reflect.DeepEqual(info.Categories, want) {
+		t.Errorf("Categories = %v, want %v", info.Categories, want)
+	}
+	if info.TestFiles != 1 {
+		t.Errorf("Expected TestFiles 1, got %d", info.TestFiles)
+	}
+}
+
+func TestScanDirectoryLanguageByLines(t *testing.T) {
+	tmpDir := t.TempDir()
+	goSource := "package main\n\nfunc main() {\n" + strings.Repeat("\tstep()\n", 40) + "}\n"
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":           goSource,
+		"server.go":         goSource,
+		"scripts/a.sh":      "echo a\n",
+		"scripts/b.sh":      "echo b\n",
+		"scripts/c.sh":      "echo c\n",
+		"web/bundle.min.js": strings.Repeat("var x=1;\n", 500),
+		"web/api.js":        "// Code generated by openapi. DO NOT EDIT.\n" + strings.Repeat("x();\n", 500),
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{Quick: true})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	// Shell has the most files and JavaScript the most (generated) lines,
+	// but Go is the most hand-written code
+	if info.Language != "Go" {
+		t.Errorf("Expected Go by lines of code, got %s", info.Language)
+	}
+}
+
+func TestDetectLanguageFallsBackToFileCount(t *testing.T) {
+	extensions := map[string]int{".go": 1, ".py": 3}
+	if got := detectLanguage(extensions, map[string]int{}); got != "Python" {
+		t.Errorf("Expected Python by file count with no lines, got %s", got)
+	}
+	if got := detectLanguage(extensions, map[string]int{"Go": 10, "Python": 10}); got != "Python" {
+		t.Errorf("Expected file count to break a line tie, got %s", got)
+	}
+	if got := detectLanguage(map[string]int{".md": 2}, nil); got != "Unknown" {
+		t.Errorf("Expected Unknown without source files, got %s", got)
+	}
+}
+
+func TestIdentifyLicense(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"mit", "MIT License\n\nPermission is hereby granted, free of charge, to any person\nobtaining a copy", "MIT"},
+		{"apache", "                                 Apache License\n                           Version 2.0, January 2004", "Apache-2.0"},
+		{"gpl3", "GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", "GPL-3.0"},
+		{"gpl2", "GNU GENERAL PUBLIC LICENSE\nVersion 2, June 1991", "GPL-2.0"},
+		{"lgpl", "GNU LESSER GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", "LGPL-3.0"},
+		{"bsd3", "Redistribution and use in source and binary forms, with or without\nmodification... Neither the name of the copyright holder", "BSD-3-Clause"},
+		{"bsd2", "Redistribution and use in source and binary forms, with or without\nmodification, are permitted", "BSD-2-Clause"},
+		{"spdx", "SPDX-License-Identifier: MPL-2.0\n", "MPL-2.0"},
+		{"custom", "All rights reserved. Ask before using.", "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identifyLicense(tt.content); got != tt.want {
+				t.Errorf("identifyLicense() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanDirectoryLicense(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":          "package main\n",
+		"COPYING":          "GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007\n",
+		"vendor/x/LICENSE": "Permission is hereby granted, free of charge\n",
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{Quick: true})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+	if doc := info.Documentation; doc.License != "GPL-3.0" || doc.LicenseFile != "COPYING" {
+		t.Errorf("Expected GPL-3.0 from COPYING, got %q from %q", doc.License, doc.LicenseFile)
+	}
+
+	bare := t.TempDir()
+	writeFixture(t, bare, map[string]string{"main.go": "package main\n"})
+	info, err = scanDirectory(bare, ReconConfig{})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+	if info.Documentation.LicenseFile != "" || info.Documentation.License != "" {
+		t.Errorf("Expected no license, got %+v", info.Documentation)
+	}
+	if report := buildReconJSON(info); report.Documentation.License != "" {
+		t.Errorf("Expected empty license in JSON, got %q", report.Documentation.License)
+	}
+}
+
+func TestRenderReconMarkdown(t *testing.T) {
+	tmpDir := filepath.Join(t.TempDir(), "shop")
+	writeFixture(t, tmpDir, map[string]string{
+		"README.md":              "# Shop\n",
+		"go.mod":                 "module shop\n\nrequire github.com/lib/pq v1.10.0\n",
+		"cmd/shop/main.go":       "package main\n// TODO: handle a|b flags\n",
+		"internal/db/db.go":      "package db\n\nvar password = \"hunter22\"\n",
+		"internal/db/db_test.go": "package db\n",
+	})
+
+	info, err := scanDirectory(tmpDir, ReconConfig{})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+
+	report := renderReconMarkdown(info, nil)
+	for _, want := range []string{
+		"# Recon: shop\n",
+		"| Language | Go |",
+		"| Test Files | 1 |",
+		"## Entry Points",
+		"`cmd/shop/main.go`",
+		"## Architecture",
+		"| github.com/lib/pq | v1.10.0 |",
+		"- README: found (",
+		"- **License: none found**",
+		"| TODOs | 1 |",
+		`handle a\|b flags`,
+		"### Security Concerns",
+		"- `internal/db/db.go:3`",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "\x1b[") || strings.Contains(report, tmpDir) || strings.Contains(report, "hunter22") {
+		t.Errorf("Report should have no ANSI codes, absolute paths or secrets:\n%s", report)
+	}
+
+	focus, _ := parseReconFocus("docs")
+	docsOnly := renderReconMarkdown(info, focus)
+	if strings.Contains(docsOnly, "## Overview") || !strings.Contains(docsOnly, "## Documentation") {
+		t.Errorf("Expected only the documentation section with --focus docs:\n%s", docsOnly)
+	}
+}
+
+func TestReconSecurityMatchesBreachPoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, tmpDir, map[string]string{
+		"main.go":           "package main\n\nvar password = \"hunter2hunter2\"\n// hardcoded retry count\n",
+		"config.yml":        "db:\n  credentials:\n    password: Xk9$mQ2vLp7#Rt4w\n",
+		"deploy.sh":         "export GITHUB_TOKEN=ghp_" + strings.Repeat("a", 36) + "\n",
+		"safe.go":           "package main\n\nvar apiKey = \"0123456789abcdef0123\" // breach-ignore\n",
+		"testdata/fixt.go":  "package testdata\n\nvar password = \"fixture-password\"\n",
+		"notes/nothing.txt": "secret = \"short\"\n",
+	})
+
+	focus, _ := parseReconFocus("security")
+	info, err := scanDirectory(tmpDir, ReconConfig{Focus: focus})
+	if err != nil {
+		t.Fatalf("scanDirectory() failed: %v", err)
+	}
+	var reconHits []string
+	for _, concern := range info.HealthIndicators.SecurityConcerns {
+		reconHits = append(reconHits, fmt.Sprintf("%s:%d", filepath.ToSlash(concern.File), concern.Line))
+		if strings.Contains(concern.Content, "hunter2hunter2") {
+			t.Errorf("Expected the secret to be redacted, got %q", concern.Content)
+		}
+	}
+
+	findings, _ := scanTree(tmpDir, ScanConfig{ScanCredentials: true, Workers: 1})
+	findings, _ = suppressTestDirFindings(findings)
+	var breachHits []string
+	for _, f := range findings {
+		breachHits = append(breachHits, fmt.Sprintf("%s:%d", filepath.ToSlash(f.FilePath), f.Line))
+	}
+
+	sort.Strings(reconHits)
+	sort.Strings(breachHits)
+	if len(reconHits) != 3 || !reflect.DeepEqual(reconHits, breachHits) {
+		t.Errorf("recon and breach-points disagree:\nrecon  %v\nbreach %v", reconHits, breachHits)
+	}
+}
+
+func TestCompareReconReports(t *testing.T) {
+	old := &reconJSON{
+		Path:       "/src/app",
+		TotalFiles: 10,
+		CodeFiles:  6,
+		Categories: map[string]int{"code": 6},
+		Dependencies: []reconJSONDep{
+			{Name: "left-pad", Version: "1.0.0", Source: "package.json"},
+			{Name: "express", Version: "4.17.0", Source: "package.json"},
+		},
+		Health: reconJSONHealth{
+			TODOs:       1,
+			TODOMarkers: []reconJSONMarker{{File: "app.js", Line: 3, Content: "handle retries"}},
+		},
+	}
+	current := &reconJSON{
+		Path:       "/src/app",
+		TotalFiles: 12,
+		CodeFiles:  6,
+		Categories: map[string]int{"code": 6, "tests": 2},
+		Dependencies: []reconJSONDep{
+			{Name: "express", Version: "4.18.2", Source: "package.json"},
+			{Name: "zod", Version: "3.22.0", Source: "package.json"},
+		},
+		Health: reconJSONHealth{
+			TODOs: 2,
+			// The old TODO moved down a few lines; only the second is new
+			TODOMarkers: []reconJSONMarker{
+				{File: "app.js", Line: 7, Content: "handle retries"},
+				{File: "app.js", Line: 20, Content: "paginate"},
+			},
+			SecurityConcerns: 1,
+			SecurityMarkers:  []reconJSONMarker{{File: "config.js", Line: 1, Content: "Hardcoded password: ***"}},
+		},
+	}
+
+	delta := compareReconReports(old, current)
+
+	var counts []string
+	for _, c := range delta.Counts {
+		counts = append(counts, fmt.Sprintf("%s %d→%d", c.Name, c.Old, c.New))
+	}
+	wantCounts := []string{"total files 10→12", "files in tests 0→2", "TODOs 1→2", "security concerns 0→1"}
+	if !reflect.DeepEqual(counts, wantCounts) {
+		t.Errorf("Counts = %v, want %v", counts, wantCounts)
+	}
+
+	if len(delta.AddedDependencies) != 1 || delta.AddedDependencies[0].Name != "zod" {
+		t.Errorf("AddedDependencies = %+v", delta.AddedDependencies)
+	}
+	if len(delta.RemovedDependencies) != 1 || delta.RemovedDependencies[0].Name != "left-pad" {
+		t.Errorf("RemovedDependencies = %+v", delta.RemovedDependencies)
+	}
+	if len(delta.ChangedDependencies) != 1 || delta.ChangedDependencies[0].NewVersion != "4.18.2" {
+		t.Errorf("ChangedDependencies = %+v", delta.ChangedDependencies)
+	}
+	if len(delta.NewTODOs) != 1 || delta.NewTODOs[0].Content != "paginate" {
+		t.Errorf("NewTODOs = %+v", delta.NewTODOs)
+	}
+	if len(delta.NewSecurityConcerns) != 1 || len(delta.NewFIXMEs) != 0 {
+		t.Errorf("Expected one new security concern and no FIXMEs, got %+v", delta)
+	}
+
+	if same := compareReconReports(current, current); !same.empty() {
+		t.Errorf("Expected no changes comparing a report to itself, got %+v", same)
+	}
+}
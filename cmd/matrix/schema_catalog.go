@@ -9,10 +9,13 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // SchemaSnapshot represents a cataloged database schema
@@ -24,14 +27,17 @@ type SchemaSnapshot struct {
 	Checksum     string            `json:"checksum"`
 	Tables       map[string]*Table `json:"tables"`
 	SourceFiles  []string          `json:"source_files"`
+	SourceRoots  []string          `json:"source_roots,omitempty"` // every scanned root when there is more than one
 }
 
 // Table represents a database table
 type Table struct {
 	Name        string       `json:"name"`
+	Schema      string       `json:"schema,omitempty"` // qualifier from CREATE TABLE schema.name, if any
 	Columns     []Column     `json:"columns"`
 	Indexes     []Index      `json:"indexes"`
 	ForeignKeys []ForeignKey `json:"foreign_keys"`
+	Comment     string       `json:"comment,omitempty"` // from SQL comments or Prisma /// docs
 }
 
 // Column represents a table column
@@ -42,6 +48,7 @@ type Column struct {
 	PrimaryKey bool   `json:"primary_key"`
 	Unique     bool   `json:"unique"`
 	Default    string `json:"default,omitempty"`
+	Comment    string `json:"comment,omitempty"`
 }
 
 // Index represents a table index
@@ -56,6 +63,7 @@ type ForeignKey struct {
 	Column          string `json:"column"`
 	ReferencedTable string `json:"referenced_table"`
 	ReferencedColumn string `json:"referenced_column"`
+	ReferencedSchema string `json:"referenced_schema,omitempty"` // qualifier from REFERENCES schema.table, if any
 }
 
 // SchemaDiff tracks changes between snapshots
@@ -63,6 +71,11 @@ type SchemaDiff struct {
 	Added    []string
 	Modified []string
 	Removed  []string
+	Renamed  []string
+	// Destructive maps Modified/Removed entries that can lose data (dropped
+	// tables and columns, narrowed types, new NOT NULL) to the reason.
+	// Everything else is safe.
+	Destructive map[string]string
 }
 
 // runSchemaCatalog implements the schema-catalog command
@@ -86,6 +99,12 @@ func runSchemaCatalog() error {
 		return runSchemaFind()
 	case "list":
 		return runSchemaList()
+	case "export":
+		return runSchemaExport()
+	case "stats":
+		return runSchemaStats()
+	case "lint":
+		return runSchemaLint()
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown subcommand: %s\n", subcommand)
 		printSchemaCatalogUsage()
@@ -98,88 +117,79 @@ func printSchemaCatalogUsage() {
 	fmt.Println("schema-catalog - Track database schemas across projects")
 	fmt.Println("")
 	fmt.Println("USAGE:")
-	fmt.Println("  matrix schema-catalog scan <path>     Discover and catalog schemas")
-	fmt.Println("  matrix schema-catalog diff <path>     Compare current vs last snapshot")
+	fmt.Println("  matrix schema-catalog scan <path>...  Discover and catalog schemas")
+	fmt.Println("                                        Several paths merge into one snapshot; --project names it")
+	fmt.Println("  matrix schema-catalog diff <path>...  Compare current vs last snapshot")
+	fmt.Println("  matrix schema-catalog diff --from <project[@time]> --to <project[@time]> [--table <name>]")
+	fmt.Println("                                        Compare two cataloged snapshots")
+	fmt.Println("                                        --fail-on-destructive exits non-zero on drops or narrowing")
 	fmt.Println("  matrix schema-catalog history <table> Show evolution of specific table")
 	fmt.Println("  matrix schema-catalog find <table>    Find table across all cataloged projects")
 	fmt.Println("  matrix schema-catalog list            List all cataloged projects")
+	fmt.Println("  matrix schema-catalog export <project> [--format dbml|mermaid]")
+	fmt.Println("                                        Render latest snapshot as DBML or Mermaid ER")
+	fmt.Println("  matrix schema-catalog stats [--stale-days N] [--top N] [--json]")
+	fmt.Println("                                        Summarize the whole catalog")
+	fmt.Println("  matrix schema-catalog lint <path>... [--max-columns N] [--json]")
+	fmt.Println("                                        Warn about missing primary keys, vague types,")
+	fmt.Println("                                        very wide tables and unindexed foreign keys")
 	fmt.Println("")
 	fmt.Println("EXAMPLES:")
 	fmt.Println("  matrix schema-catalog scan ~/projects/myapp")
+	fmt.Println("  matrix schema-catalog scan --project myapp ~/projects/myapp-migrations ~/projects/myapp")
 	fmt.Println("  matrix schema-catalog diff .")
+	fmt.Println("  matrix schema-catalog diff --from myapp@2024-01-15-093000 --to myapp")
+	fmt.Println("  matrix schema-catalog diff --from billing --to myapp --table users")
+	fmt.Println("  matrix schema-catalog diff --fail-on-destructive .")
 	fmt.Println("  matrix schema-catalog find users")
 	fmt.Println("  matrix schema-catalog history sessions")
+	fmt.Println("  matrix schema-catalog export myapp --format mermaid")
+	fmt.Println("  matrix schema-catalog stats --stale-days 14")
+	fmt.Println("  matrix schema-catalog lint --max-columns 25 .")
 }
 
 // runSchemaScan scans a directory for schemas and catalogs them
 func runSchemaScan() error {
 	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	project := fs.String("project", "", "Project name (default: name of the first path)")
 	if len(os.Args) > 3 {
 		fs.Parse(os.Args[3:])
 	}
 
-	targetPath := "."
-	if fs.NArg() > 0 {
-		targetPath = fs.Arg(0)
-	}
-
-	absPath, err := filepath.Abs(targetPath)
+	roots, err := resolveSchemaRoots(fs.Args())
 	if err != nil {
-		return fmt.Errorf("failed to resolve path: %w", err)
+		return err
 	}
-
-	if _, err := os.Stat(absPath); err != nil {
-		return fmt.Errorf("path does not exist: %s", absPath)
+	for _, root := range roots {
+		if _, err := os.Stat(root); err != nil {
+			return fmt.Errorf("path does not exist: %s", root)
+		}
 	}
 
 	output.Success("📚 Schema Catalog - Scan")
 	fmt.Println("")
-	fmt.Printf("Scanning: %s\n", absPath)
+	for _, root := range roots {
+		fmt.Printf("Scanning: %s\n", root)
+	}
 	fmt.Println("")
 
-	// Discover schema files
-	schemaFiles := discoverSchemaFiles(absPath)
+	snapshot := scanSchemaRoots(schemaProjectName(*project, roots), roots, true)
 
-	if len(schemaFiles) == 0 {
+	if len(snapshot.SourceFiles) == 0 {
 		fmt.Println("No schema files found.")
 		fmt.Println("")
 		fmt.Println("Looking for: *.sql, migrations/, *.prisma, models.py, schema.rb")
 		return nil
 	}
 
-	fmt.Printf("Found %d schema files:\n", len(schemaFiles))
-	for _, f := range schemaFiles {
-		relPath, _ := filepath.Rel(absPath, f)
-		fmt.Printf("  - %s\n", relPath)
+	fmt.Printf("Found %d schema files:\n", len(snapshot.SourceFiles))
+	for _, f := range snapshot.SourceFiles {
+		fmt.Printf("  - %s\n", schemaDisplayPath(roots, f))
 	}
 	fmt.Println("")
 
-	// Parse schemas
-	snapshot := &SchemaSnapshot{
-		Project:      filepath.Base(absPath),
-		SnapshotTime: time.Now(),
-		Source:       absPath,
-		Tables:       make(map[string]*Table),
-		SourceFiles:  schemaFiles,
-	}
-
-	for _, file := range schemaFiles {
-		tables, err := parseSchemaFile(file)
-		if err != nil {
-			fmt.Printf("Warning: failed to parse %s: %v\n", file, err)
-			continue
-		}
-
-		for _, table := range tables {
-			snapshot.Tables[table.Name] = table
-		}
-	}
-
-	// Calculate checksum
-	snapshot.Checksum = calculateChecksum(snapshot)
-
 	// Try to get git commit
-	snapshot.GitCommit = getGitCommit(absPath)
+	snapshot.GitCommit = getGitCommit(roots[0])
 
 	// Display results
 	displaySchemaSnapshot(snapshot)
@@ -195,28 +205,130 @@ func runSchemaScan() error {
 	return nil
 }
 
-// runSchemaDiff compares current schema against last snapshot
+// resolveSchemaRoots turns scan/diff path arguments into absolute roots,
+// defaulting to the current directory
+func resolveSchemaRoots(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	var roots []string
+	for _, arg := range args {
+		absPath, err := filepath.Abs(util.ExpandPath(arg))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path: %w", err)
+		}
+		if !contains(roots, absPath) {
+			roots = append(roots, absPath)
+		}
+	}
+	return roots, nil
+}
+
+// schemaProjectName returns the --project override, or the name of the
+// first root
+func schemaProjectName(override string, roots []string) string {
+	if override != "" {
+		return override
+	}
+	return filepath.Base(roots[0])
+}
+
+// scanSchemaRoots parses the schema files under every root into a single
+// snapshot. A table defined under several roots keeps the last definition.
+// Files reached through more than one root, e.g. "." and "./migrations",
+// are only parsed once.
+func scanSchemaRoots(project string, roots []string, warn bool) *SchemaSnapshot {
+	snapshot := &SchemaSnapshot{
+		Project:      project,
+		SnapshotTime: time.Now(),
+		Source:       roots[0],
+		Tables:       make(map[string]*Table),
+		SourceFiles:  []string{},
+	}
+	if len(roots) > 1 {
+		snapshot.SourceRoots = roots
+	}
+
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		for _, file := range discoverSchemaFiles(root) {
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+			snapshot.SourceFiles = append(snapshot.SourceFiles, file)
+
+			tables, err := parseSchemaFile(file)
+			if err != nil {
+				if warn {
+					fmt.Printf("Warning: failed to parse %s: %v\n", file, err)
+				}
+				continue
+			}
+			for _, table := range tables {
+				snapshot.Tables[schemaTableKey(table)] = table
+			}
+		}
+	}
+
+	snapshot.Checksum = calculateChecksum(snapshot)
+	return snapshot
+}
+
+// schemaSources lists every root a snapshot was scanned from
+func schemaSources(snapshot *SchemaSnapshot) string {
+	if len(snapshot.SourceRoots) > 0 {
+		return strings.Join(snapshot.SourceRoots, ", ")
+	}
+	return snapshot.Source
+}
+
+// schemaDisplayPath shows a schema file relative to its root, prefixed with
+// the root's name when several roots were scanned
+func schemaDisplayPath(roots []string, file string) string {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if len(roots) > 1 {
+			return filepath.Join(filepath.Base(root), rel)
+		}
+		return rel
+	}
+	return file
+}
+
+// runSchemaDiff compares current schema against last snapshot, or two
+// stored snapshots when --from and --to are given
 func runSchemaDiff() error {
 	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	from := fs.String("from", "", "Base snapshot as project or project@timestamp")
+	to := fs.String("to", "", "Target snapshot as project or project@timestamp")
+	tableName := fs.String("table", "", "Only compare this table")
+	failOnDestructive := fs.Bool("fail-on-destructive", false, "Exit non-zero if any change can lose data")
+	project := fs.String("project", "", "Project name (default: name of the first path)")
 	if len(os.Args) > 3 {
 		fs.Parse(os.Args[3:])
 	}
 
-	targetPath := "."
-	if fs.NArg() > 0 {
-		targetPath = fs.Arg(0)
+	if *from != "" || *to != "" {
+		if *from == "" || *to == "" {
+			return fmt.Errorf("--from and --to must be used together")
+		}
+		return runSchemaSnapshotDiff(*from, *to, *tableName, *failOnDestructive)
 	}
 
-	absPath, err := filepath.Abs(targetPath)
+	roots, err := resolveSchemaRoots(fs.Args())
 	if err != nil {
-		return fmt.Errorf("failed to resolve path: %w", err)
+		return err
 	}
 
 	output.Success("📚 Schema Catalog - Diff")
 	fmt.Println("")
 
 	// Load last snapshot
-	projectName := filepath.Base(absPath)
+	projectName := schemaProjectName(*project, roots)
 	lastSnapshot, err := loadLatestSnapshot(projectName)
 	if err != nil {
 		return fmt.Errorf("no previous snapshot found for project '%s': %w", projectName, err)
@@ -227,34 +339,69 @@ func runSchemaDiff() error {
 	fmt.Println("")
 
 	// Scan current schema
-	schemaFiles := discoverSchemaFiles(absPath)
-	currentSnapshot := &SchemaSnapshot{
-		Project:      projectName,
-		SnapshotTime: time.Now(),
-		Source:       absPath,
-		Tables:       make(map[string]*Table),
-		SourceFiles:  schemaFiles,
-	}
+	currentSnapshot := scanSchemaRoots(projectName, roots, false)
 
-	for _, file := range schemaFiles {
-		tables, err := parseSchemaFile(file)
-		if err != nil {
-			continue
-		}
-		for _, table := range tables {
-			currentSnapshot.Tables[table.Name] = table
+	if *tableName != "" {
+		if lastSnapshot, currentSnapshot, err = filterSnapshotsToTable(lastSnapshot, currentSnapshot, *tableName); err != nil {
+			return err
 		}
 	}
 
-	currentSnapshot.Checksum = calculateChecksum(currentSnapshot)
-
 	// Compare snapshots
 	diff := compareSnapshots(lastSnapshot, currentSnapshot)
+	displaySchemaDiff(diff)
+
+	return destructiveDriftError(diff, *failOnDestructive)
+}
+
+// runSchemaSnapshotDiff compares two cataloged snapshots, which may belong
+// to different projects
+func runSchemaSnapshotDiff(fromRef, toRef, tableName string, failOnDestructive bool) error {
+	fromSnapshot, err := loadSnapshotRef(fromRef)
+	if err != nil {
+		return err
+	}
+	toSnapshot, err := loadSnapshotRef(toRef)
+	if err != nil {
+		return err
+	}
+
+	if tableName != "" {
+		if fromSnapshot, toSnapshot, err = filterSnapshotsToTable(fromSnapshot, toSnapshot, tableName); err != nil {
+			return err
+		}
+	}
+
+	output.Success("📚 Schema Catalog - Diff")
+	fmt.Println("")
+	fmt.Printf("From: %s @ %s\n", fromSnapshot.Project, fromSnapshot.SnapshotTime.Format("2006-01-02 15:04:05"))
+	fmt.Printf("To:   %s @ %s\n", toSnapshot.Project, toSnapshot.SnapshotTime.Format("2006-01-02 15:04:05"))
+	if tableName != "" {
+		fmt.Printf("Table: %s\n", tableName)
+	}
+	fmt.Println("")
+
+	diff := compareSnapshots(fromSnapshot, toSnapshot)
+	displaySchemaDiff(diff)
+
+	return destructiveDriftError(diff, failOnDestructive)
+}
+
+// destructiveDriftError fails a diff with destructive changes when
+// --fail-on-destructive is set, so CI can block risky migrations
+func destructiveDriftError(diff SchemaDiff, failOnDestructive bool) error {
+	if failOnDestructive && len(diff.Destructive) > 0 {
+		return fmt.Errorf("%d destructive schema change(s) detected", len(diff.Destructive))
+	}
+	return nil
+}
 
+// displaySchemaDiff prints the changes between two snapshots
+func displaySchemaDiff(diff SchemaDiff) {
 	// Display drift
-	if len(diff.Added) == 0 && len(diff.Modified) == 0 && len(diff.Removed) == 0 {
+	if len(diff.Added) == 0 && len(diff.Modified) == 0 && len(diff.Removed) == 0 && len(diff.Renamed) == 0 {
 		output.Success("✓ No drift detected - schemas match")
-		return nil
+		return
 	}
 
 	output.Header("DRIFT DETECTED:")
@@ -268,10 +415,18 @@ func runSchemaDiff() error {
 		fmt.Println("")
 	}
 
+	if len(diff.Renamed) > 0 {
+		fmt.Printf("%sRENAMED:%s\n", output.Cyan, output.Reset)
+		for _, item := range diff.Renamed {
+			fmt.Printf("  → %s\n", item)
+		}
+		fmt.Println("")
+	}
+
 	if len(diff.Modified) > 0 {
 		fmt.Printf("%sMODIFIED:%s\n", output.Yellow, output.Reset)
 		for _, item := range diff.Modified {
-			fmt.Printf("  ~ %s\n", item)
+			fmt.Printf("  ~ %s%s\n", item, destructiveMarker(diff, item))
 		}
 		fmt.Println("")
 	}
@@ -279,12 +434,50 @@ func runSchemaDiff() error {
 	if len(diff.Removed) > 0 {
 		fmt.Printf("%sREMOVED:%s\n", output.Red, output.Reset)
 		for _, item := range diff.Removed {
-			fmt.Printf("  - %s\n", item)
+			fmt.Printf("  - %s%s\n", item, destructiveMarker(diff, item))
 		}
 		fmt.Println("")
 	}
 
-	return nil
+	if n := len(diff.Destructive); n > 0 {
+		fmt.Printf("%s⚠ %d destructive change(s): existing data can be lost or the migration can fail%s\n", output.Red, n, output.Reset)
+	} else {
+		output.Success("✓ All changes are safe (nothing dropped or narrowed)")
+	}
+}
+
+// destructiveMarker labels a destructive diff entry with its reason
+func destructiveMarker(diff SchemaDiff, item string) string {
+	reason, ok := diff.Destructive[item]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("  %s⚠ DESTRUCTIVE: %s%s", output.Red, reason, output.Reset)
+}
+
+// filterSnapshotsToTable narrows both snapshots to a single table so its
+// shape can be compared on its own. Table names match case-insensitively.
+func filterSnapshotsToTable(a, b *SchemaSnapshot, tableName string) (*SchemaSnapshot, *SchemaSnapshot, error) {
+	filter := func(snapshot *SchemaSnapshot) (*SchemaSnapshot, bool) {
+		filtered := *snapshot
+		filtered.Tables = make(map[string]*Table)
+		for name, table := range snapshot.Tables {
+			if strings.EqualFold(name, tableName) || strings.EqualFold(table.Name, tableName) {
+				// Key by the requested name so the same table in two
+				// projects is compared rather than reported as a rename
+				filtered.Tables[strings.ToLower(tableName)] = table
+				return &filtered, true
+			}
+		}
+		return &filtered, false
+	}
+
+	filteredA, foundA := filter(a)
+	filteredB, foundB := filter(b)
+	if !foundA && !foundB {
+		return nil, nil, fmt.Errorf("table '%s' not found in either snapshot", tableName)
+	}
+	return filteredA, filteredB, nil
 }
 
 // runSchemaHistory shows evolution of a specific table
@@ -319,9 +512,12 @@ func runSchemaHistory() error {
 		}
 
 		for _, snapshot := range snapshots {
-			if table, exists := snapshot.Tables[tableName]; exists {
+			if table := findSchemaTable(snapshot, tableName); table != nil {
 				found = true
 				fmt.Printf("%s (%s)\n", snapshot.SnapshotTime.Format("2006-01-02 15:04:05"), snapshot.Project)
+				if table.Comment != "" {
+					fmt.Printf("  %s%s%s\n", output.Dim, table.Comment, output.Reset)
+				}
 				fmt.Printf("  Columns: %d\n", len(table.Columns))
 				for _, col := range table.Columns {
 					markers := ""
@@ -334,7 +530,7 @@ func runSchemaHistory() error {
 					if !col.Nullable {
 						markers += " NOT NULL"
 					}
-					fmt.Printf("    - %s: %s%s\n", col.Name, col.Type, markers)
+					fmt.Printf("    - %s: %s%s%s\n", col.Name, col.Type, markers, columnCommentSuffix(col))
 				}
 				fmt.Println("")
 			}
@@ -377,11 +573,14 @@ func runSchemaFind() error {
 			continue
 		}
 
-		if table, exists := snapshot.Tables[tableName]; exists {
+		if table := findSchemaTable(snapshot, tableName); table != nil {
 			found = true
 			fmt.Printf("Project: %s%s%s\n", output.Yellow, snapshot.Project, output.Reset)
-			fmt.Printf("Source: %s\n", snapshot.Source)
+			fmt.Printf("Source: %s\n", schemaSources(snapshot))
 			fmt.Printf("Last Updated: %s\n", snapshot.SnapshotTime.Format("2006-01-02 15:04:05"))
+			if table.Comment != "" {
+				fmt.Printf("Description: %s\n", table.Comment)
+			}
 			fmt.Printf("Columns: %d\n", len(table.Columns))
 			fmt.Println("")
 
@@ -390,7 +589,7 @@ func runSchemaFind() error {
 				if col.PrimaryKey {
 					fmt.Printf(" (PK)")
 				}
-				fmt.Println("")
+				fmt.Println(columnCommentSuffix(col))
 			}
 			fmt.Println("")
 		}
@@ -403,6 +602,14 @@ func runSchemaFind() error {
 	return nil
 }
 
+// columnCommentSuffix renders a column's comment for find and history
+func columnCommentSuffix(col Column) string {
+	if col.Comment == "" {
+		return ""
+	}
+	return fmt.Sprintf("  %s-- %s%s", output.Dim, col.Comment, output.Reset)
+}
+
 // runSchemaList lists all cataloged projects
 func runSchemaList() error {
 	output.Success("📚 Cataloged Projects")
@@ -437,7 +644,7 @@ func runSchemaList() error {
 		}
 
 		fmt.Printf("%s%s%s\n", output.Yellow, snapshot.Project, output.Reset)
-		fmt.Printf("  Source: %s\n", snapshot.Source)
+		fmt.Printf("  Source: %s\n", schemaSources(snapshot))
 		fmt.Printf("  Tables: %d\n", len(snapshot.Tables))
 		fmt.Printf("  Last Cataloged: %s\n", snapshot.SnapshotTime.Format("2006-01-02 15:04:05"))
 		if snapshot.GitCommit != "" {
@@ -449,120 +656,771 @@ func runSchemaList() error {
 	return nil
 }
 
-// discoverSchemaFiles finds schema-related files
-func discoverSchemaFiles(path string) []string {
-	var files []string
+// CatalogStats is a portfolio-level summary of the latest snapshot of every
+// cataloged project
+type CatalogStats struct {
+	Projects      int              `json:"projects"`
+	Tables        int              `json:"tables"`
+	Columns       int              `json:"columns"`
+	AvgColumns    float64          `json:"avg_columns_per_table"`
+	CommonTables  []TableFrequency `json:"common_tables"`
+	StaleProjects []StaleProject   `json:"stale_projects"`
+	StaleDays     int              `json:"stale_days"`
+}
 
-	filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+// TableFrequency is a table name shared by several projects
+type TableFrequency struct {
+	Name     string   `json:"name"`
+	Projects []string `json:"projects"`
+}
+
+// StaleProject is a project whose latest snapshot is older than the cutoff
+type StaleProject struct {
+	Project     string    `json:"project"`
+	LastScanned time.Time `json:"last_scanned"`
+	DaysAgo     int       `json:"days_ago"`
+}
+
+// runSchemaStats summarizes every cataloged project's latest snapshot
+func runSchemaStats() error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	staleDays := fs.Int("stale-days", 30, "Flag projects not re-scanned in this many days")
+	top := fs.Int("top", 10, "Number of shared table names to show (0 = all)")
+	jsonFlag := fs.Bool("json", false, "Output as JSON")
+	fs.Parse(os.Args[3:])
+
+	snapshots, err := loadCatalogSnapshots()
+	if err != nil {
+		return err
+	}
+
+	stats := computeCatalogStats(snapshots, time.Now(), *staleDays, *top)
+	if *jsonFlag {
+		return output.EmitJSON(stats)
+	}
+
+	output.Success("📚 Schema Catalog Stats")
+	fmt.Println("")
+
+	if stats.Projects == 0 {
+		fmt.Println("No projects cataloged yet.")
+		fmt.Println("")
+		fmt.Println("Run 'matrix schema-catalog scan <path>' to catalog a project.")
+		return nil
+	}
+
+	fmt.Printf("Projects: %d\n", stats.Projects)
+	fmt.Printf("Tables: %d\n", stats.Tables)
+	fmt.Printf("Avg Columns/Table: %.1f\n", stats.AvgColumns)
+	fmt.Println("")
+
+	output.Header("Most Common Tables")
+	if len(stats.CommonTables) == 0 {
+		fmt.Println("  No table names shared across projects")
+	}
+	for _, freq := range stats.CommonTables {
+		fmt.Printf("  %s%-24s%s %d projects  %s(%s)%s\n", output.Yellow, freq.Name, output.Reset,
+			len(freq.Projects), output.Dim, strings.Join(freq.Projects, ", "), output.Reset)
+	}
+	fmt.Println("")
+
+	output.Header(fmt.Sprintf("Not Re-scanned in %d+ Days", stats.StaleDays))
+	if len(stats.StaleProjects) == 0 {
+		fmt.Println("  All projects scanned recently")
+	}
+	for _, stale := range stats.StaleProjects {
+		fmt.Printf("  %s%-24s%s last scanned %s (%d days ago)\n", output.Yellow, stale.Project, output.Reset,
+			stale.LastScanned.Format("2006-01-02"), stale.DaysAgo)
+	}
+
+	return nil
+}
+
+// loadCatalogSnapshots loads the latest snapshot of every cataloged project,
+// skipping projects whose snapshots can't be read
+func loadCatalogSnapshots() ([]*SchemaSnapshot, error) {
+	projects, err := os.ReadDir(getCatalogDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+
+	var snapshots []*SchemaSnapshot
+	for _, proj := range projects {
+		if !proj.IsDir() {
+			continue
+		}
+		snapshot, err := loadLatestSnapshot(proj.Name())
 		if err != nil {
-			return nil
+			continue
 		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
 
-		if info.IsDir() {
-			// Skip common ignore directories
-			name := info.Name()
-			if name == "node_modules" || name == "vendor" || name == ".git" ||
-				name == "target" || name == "build" || name == "dist" {
-				return filepath.SkipDir
+// computeCatalogStats aggregates snapshots into catalog-wide stats. Table
+// names are compared case-insensitively and only names found in more than
+// one project count as common; top limits them (0 = all). Projects whose
+// latest snapshot is at least staleDays old are listed oldest first.
+func computeCatalogStats(snapshots []*SchemaSnapshot, now time.Time, staleDays, top int) CatalogStats {
+	stats := CatalogStats{
+		Projects:      len(snapshots),
+		CommonTables:  []TableFrequency{},
+		StaleProjects: []StaleProject{},
+		StaleDays:     staleDays,
+	}
+
+	byName := make(map[string]*TableFrequency)
+	for _, snapshot := range snapshots {
+		stats.Tables += len(snapshot.Tables)
+		seen := make(map[string]bool)
+		for _, name := range sortedTableNames(snapshot) {
+			stats.Columns += len(snapshot.Tables[name].Columns)
+
+			key := strings.ToLower(snapshot.Tables[name].Name)
+			if seen[key] {
+				continue
 			}
-			return nil
+			seen[key] = true
+			if byName[key] == nil {
+				byName[key] = &TableFrequency{Name: snapshot.Tables[name].Name}
+			}
+			byName[key].Projects = append(byName[key].Projects, snapshot.Project)
 		}
 
-		name := strings.ToLower(info.Name())
-		dir := strings.ToLower(filepath.Base(filepath.Dir(filePath)))
+		daysAgo := int(now.Sub(snapshot.SnapshotTime).Hours() / 24)
+		if daysAgo >= staleDays {
+			stats.StaleProjects = append(stats.StaleProjects, StaleProject{
+				Project:     snapshot.Project,
+				LastScanned: snapshot.SnapshotTime,
+				DaysAgo:     daysAgo,
+			})
+		}
+	}
 
-		// Match schema files
-		if strings.HasSuffix(name, ".sql") ||
-			strings.HasSuffix(name, ".prisma") ||
-			name == "schema.rb" ||
-			name == "models.py" ||
-			dir == "migrations" || dir == "migrate" {
-			files = append(files, filePath)
+	if stats.Tables > 0 {
+		stats.AvgColumns = float64(stats.Columns) / float64(stats.Tables)
+	}
+
+	for _, freq := range byName {
+		if len(freq.Projects) > 1 {
+			sort.Strings(freq.Projects)
+			stats.CommonTables = append(stats.CommonTables, *freq)
+		}
+	}
+	sort.Slice(stats.CommonTables, func(i, j int) bool {
+		a, b := stats.CommonTables[i], stats.CommonTables[j]
+		if len(a.Projects) != len(b.Projects) {
+			return len(a.Projects) > len(b.Projects)
 		}
+		return a.Name < b.Name
+	})
+	if top > 0 && len(stats.CommonTables) > top {
+		stats.CommonTables = stats.CommonTables[:top]
+	}
 
-		return nil
+	sort.Slice(stats.StaleProjects, func(i, j int) bool {
+		return stats.StaleProjects[i].LastScanned.Before(stats.StaleProjects[j].LastScanned)
 	})
 
-	return files
+	return stats
 }
 
-// parseSchemaFile extracts table definitions from a schema file
-func parseSchemaFile(filePath string) ([]*Table, error) {
-	content, err := os.ReadFile(filePath)
+// runSchemaExport renders the latest snapshot of a project as DBML or Mermaid
+func runSchemaExport() error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	formatFlag := fs.String("format", "dbml", "Output format: dbml, mermaid")
+
+	// Allow the project name before or after flags
+	args := os.Args[3:]
+	projectName := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		projectName = args[0]
+		args = args[1:]
+	}
+	fs.Parse(args)
+	if projectName == "" && fs.NArg() > 0 {
+		projectName = fs.Arg(0)
+	}
+
+	if projectName == "" {
+		fmt.Println("Usage: matrix schema-catalog export <project> [--format dbml|mermaid]")
+		return fmt.Errorf("project name required")
+	}
+
+	snapshot, err := loadLatestSnapshot(projectName)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("no snapshot found for project '%s': %w", projectName, err)
 	}
 
-	contentStr := string(content)
+	switch *formatFlag {
+	case "dbml":
+		fmt.Print(renderSchemaDBML(snapshot))
+	case "mermaid":
+		fmt.Print(renderSchemaMermaid(snapshot))
+	default:
+		return fmt.Errorf("unknown format: %s (valid: dbml, mermaid)", *formatFlag)
+	}
 
-	// For now, focus on SQL CREATE TABLE statements
-	if strings.HasSuffix(strings.ToLower(filePath), ".sql") {
-		return parseSQLSchema(contentStr)
+	return nil
+}
+
+// schemaTableKey is the key a table is cataloged under: schema.name for
+// schema-qualified tables, so public.users and audit.users stay apart
+func schemaTableKey(table *Table) string {
+	if table.Schema != "" {
+		return table.Schema + "." + table.Name
 	}
+	return table.Name
+}
 
-	// TODO: Add parsers for .prisma, schema.rb, models.py
-	return nil, nil
+// keySchemaTables re-keys a loaded snapshot's tables by schemaTableKey, so
+// snapshots saved when tables were keyed by bare name compare cleanly
+func keySchemaTables(snapshot *SchemaSnapshot) {
+	tables := make(map[string]*Table, len(snapshot.Tables))
+	for _, table := range snapshot.Tables {
+		tables[schemaTableKey(table)] = table
+	}
+	snapshot.Tables = tables
 }
 
-// parseSQLSchema extracts CREATE TABLE statements from SQL
-func parseSQLSchema(content string) ([]*Table, error) {
-	var tables []*Table
+// findSchemaTable looks a table up by its catalog key, falling back to the
+// first table with that bare name so "users" still finds public.users
+func findSchemaTable(snapshot *SchemaSnapshot, name string) *Table {
+	if table, exists := snapshot.Tables[name]; exists {
+		return table
+	}
+	for _, key := range sortedTableNames(snapshot) {
+		if table := snapshot.Tables[key]; table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// sortedTableNames returns snapshot table names in stable order
+func sortedTableNames(snapshot *SchemaSnapshot) []string {
+	names := make([]string, 0, len(snapshot.Tables))
+	for name := range snapshot.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderSchemaDBML renders a snapshot in DBML (dbdiagram.io) syntax
+func renderSchemaDBML(snapshot *SchemaSnapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s - cataloged %s\n\n", snapshot.Project, snapshot.SnapshotTime.Format("2006-01-02 15:04:05"))
 
-	// Regex to match CREATE TABLE statements (with DOTALL flag for multiline)
-	createTablePattern := regexp.MustCompile(`(?si)CREATE\s+TABLE(?:\s+IF\s+NOT\s+EXISTS)?\s+` +
-		`(?:` + "`" + `?(\w+)` + "`" + `?|\"?(\w+)\"?)\s*\((.*?)\);`)
+	var refs []string
+	for _, name := range sortedTableNames(snapshot) {
+		table := snapshot.Tables[name]
+		fmt.Fprintf(&b, "Table %s {\n", name)
+
+		for _, col := range table.Columns {
+			var settings []string
+			if col.PrimaryKey {
+				settings = append(settings, "pk")
+			}
+			if col.Unique {
+				settings = append(settings, "unique")
+			}
+			if !col.Nullable && !col.PrimaryKey {
+				settings = append(settings, "not null")
+			}
+			if col.Default != "" {
+				settings = append(settings, fmt.Sprintf("default: `%s`", col.Default))
+			}
 
-	matches := createTablePattern.FindAllStringSubmatch(content, -1)
+			colType := col.Type
+			if strings.ContainsAny(colType, " ,") {
+				colType = `"` + colType + `"`
+			}
 
-	for _, match := range matches {
-		tableName := match[1]
-		if tableName == "" {
-			tableName = match[2]
+			if len(settings) > 0 {
+				fmt.Fprintf(&b, "  %s %s [%s]\n", col.Name, colType, strings.Join(settings, ", "))
+			} else {
+				fmt.Fprintf(&b, "  %s %s\n", col.Name, colType)
+			}
 		}
-		columnsStr := match[3]
 
-		table := &Table{
-			Name:        tableName,
-			Columns:     []Column{},
-			Indexes:     []Index{},
-			ForeignKeys: []ForeignKey{},
+		if len(table.Indexes) > 0 {
+			b.WriteString("\n  indexes {\n")
+			for _, idx := range table.Indexes {
+				var settings []string
+				if idx.Unique {
+					settings = append(settings, "unique")
+				}
+				if idx.Name != "" {
+					settings = append(settings, fmt.Sprintf("name: '%s'", idx.Name))
+				}
+				cols := "(" + strings.Join(idx.Columns, ", ") + ")"
+				if len(settings) > 0 {
+					fmt.Fprintf(&b, "    %s [%s]\n", cols, strings.Join(settings, ", "))
+				} else {
+					fmt.Fprintf(&b, "    %s\n", cols)
+				}
+			}
+			b.WriteString("  }\n")
 		}
 
-		// Parse columns
-		columns := parseColumns(columnsStr)
-		table.Columns = columns
+		b.WriteString("}\n\n")
 
-		tables = append(tables, table)
+		for _, fk := range table.ForeignKeys {
+			refs = append(refs, fmt.Sprintf("Ref: %s.%s > %s.%s", name, fk.Column, foreignKeyTarget(snapshot, table, fk), fk.ReferencedColumn))
+		}
 	}
 
-	return tables, nil
+	for _, ref := range refs {
+		b.WriteString(ref + "\n")
+	}
+
+	return b.String()
 }
 
-// parseColumns extracts column definitions from CREATE TABLE body
-func parseColumns(columnsStr string) []Column {
-	var columns []Column
+// renderSchemaMermaid renders a snapshot as a Mermaid erDiagram
+func renderSchemaMermaid(snapshot *SchemaSnapshot) string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
 
-	// Split by comma (naive approach - doesn't handle nested parens)
-	lines := strings.Split(columnsStr, ",")
+	names := sortedTableNames(snapshot)
+	ids := mermaidEntityIDs(names)
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+	var relations []string
+	for _, name := range names {
+		table := snapshot.Tables[name]
+		fmt.Fprintf(&b, "    %s {\n", ids[name])
 
-		// Skip constraints
-		if strings.HasPrefix(strings.ToUpper(line), "PRIMARY KEY") ||
-			strings.HasPrefix(strings.ToUpper(line), "FOREIGN KEY") ||
-			strings.HasPrefix(strings.ToUpper(line), "UNIQUE") ||
-			strings.HasPrefix(strings.ToUpper(line), "INDEX") ||
-			strings.HasPrefix(strings.ToUpper(line), "KEY") ||
-			strings.HasPrefix(strings.ToUpper(line), "CONSTRAINT") {
-			continue
+		fkCols := make(map[string]bool)
+		for _, fk := range table.ForeignKeys {
+			fkCols[fk.Column] = true
 		}
 
-		// Extract column name and type
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
-			continue
-		}
+		for _, col := range table.Columns {
+			var keys []string
+			if col.PrimaryKey {
+				keys = append(keys, "PK")
+			}
+			if fkCols[col.Name] {
+				keys = append(keys, "FK")
+			}
+			if col.Unique && !col.PrimaryKey {
+				keys = append(keys, "UK")
+			}
+
+			line := fmt.Sprintf("        %s %s", mermaidERType(col.Type), col.Name)
+			if len(keys) > 0 {
+				line += " " + strings.Join(keys, ",")
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("    }\n")
+
+		for _, fk := range table.ForeignKeys {
+			target := foreignKeyTarget(snapshot, table, fk)
+			if id, ok := ids[target]; ok {
+				target = id
+			} else {
+				target = mermaidEntityID(target)
+			}
+			relations = append(relations, fmt.Sprintf("    %s ||--o{ %s : \"%s\"", target, ids[name], fk.Column))
+		}
+	}
+
+	for _, rel := range relations {
+		b.WriteString(rel + "\n")
+	}
+
+	return b.String()
+}
+
+// foreignKeyTarget returns the catalog key of the table a foreign key points
+// at: its own schema if it names one, then a table of that name in the
+// referencing table's schema, then any table of that name
+func foreignKeyTarget(snapshot *SchemaSnapshot, table *Table, fk ForeignKey) string {
+	if fk.ReferencedSchema != "" {
+		return fk.ReferencedSchema + "." + fk.ReferencedTable
+	}
+	if table.Schema != "" {
+		if key := table.Schema + "." + fk.ReferencedTable; snapshot.Tables[key] != nil {
+			return key
+		}
+	}
+	if target := findSchemaTable(snapshot, fk.ReferencedTable); target != nil {
+		return schemaTableKey(target)
+	}
+	return fk.ReferencedTable
+}
+
+// mermaidEntityIDs gives each catalog key a Mermaid entity name, adding a
+// numeric suffix when two keys sanitize alike (audit.users, audit_users)
+func mermaidEntityIDs(keys []string) map[string]string {
+	ids := make(map[string]string, len(keys))
+	used := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		base := mermaidEntityID(key)
+		id := base
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[id] = true
+		ids[key] = id
+	}
+	return ids
+}
+
+// mermaidEntityID makes a table key safe for a Mermaid entity name
+func mermaidEntityID(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// mermaidERType makes a column type safe for Mermaid attribute syntax
+func mermaidERType(colType string) string {
+	var b strings.Builder
+	for _, r := range colType {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '_', r == '-', r == '(', r == ')', r == '[', r == ']':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "unknown"
+	}
+	return b.String()
+}
+
+// discoverSchemaFiles finds schema-related files
+func discoverSchemaFiles(path string) []string {
+	var files []string
+
+	progress := output.NewProgress("Scanning")
+	defer progress.Done()
+	filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if info.IsDir() {
+			// Skip common ignore directories
+			name := info.Name()
+			if name == "node_modules" || name == "vendor" || name == ".git" ||
+				name == "target" || name == "build" || name == "dist" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		progress.Tick()
+		name := strings.ToLower(info.Name())
+		dir := strings.ToLower(filepath.Base(filepath.Dir(filePath)))
+
+		// Match schema files
+		if strings.HasSuffix(name, ".sql") ||
+			strings.HasSuffix(name, ".prisma") ||
+			name == "schema.rb" ||
+			name == "models.py" ||
+			dir == "migrations" || dir == "migrate" {
+			files = append(files, filePath)
+		}
+
+		return nil
+	})
+
+	return files
+}
+
+// parseSchemaFile extracts table definitions from a schema file
+func parseSchemaFile(filePath string) ([]*Table, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	contentStr := string(content)
+
+	lowerPath := strings.ToLower(filePath)
+	if strings.HasSuffix(lowerPath, ".sql") {
+		return parseSQLSchema(contentStr)
+	}
+	if strings.HasSuffix(lowerPath, ".prisma") {
+		return parsePrismaSchema(contentStr)
+	}
+	switch filepath.Base(lowerPath) {
+	case "schema.rb":
+		return parseRailsSchema(contentStr)
+	case "models.py":
+		// Django prefixes default table names with the app label, which is
+		// the package directory holding models.py
+		return parseDjangoModels(contentStr, filepath.Base(filepath.Dir(filePath)))
+	}
+
+	return nil, nil
+}
+
+// sqlIdentifier matches a bare, backtick, double-quoted, or bracketed identifier
+const sqlIdentifier = "(?:`[^`]+`|\"[^\"]+\"|\\[[^\\]]+\\]|\\w+)"
+
+// SQL patterns used while parsing CREATE TABLE and CREATE INDEX statements
+var (
+	// CREATE TABLE statements, with an optional schema (or MySQL database) qualifier
+	sqlCreateTablePattern = regexp.MustCompile(`(?si)CREATE\s+TABLE(?:\s+IF\s+NOT\s+EXISTS)?\s+` +
+		`(` + sqlIdentifier + `(?:\s*\.\s*` + sqlIdentifier + `)*)\s*\((.*?)\);`)
+
+	sqlConstraintPattern  = regexp.MustCompile(`(?i)^(PRIMARY\s+KEY|FOREIGN\s+KEY|UNIQUE|CONSTRAINT|CHECK)\b`)
+	sqlForeignKeyPattern  = regexp.MustCompile(`(?is)^(?:CONSTRAINT\s+\S+\s+)?FOREIGN\s+KEY\s*\(([^)]*)\)\s*REFERENCES\s+([^\s(]+)\s*\(([^)]*)\)`)
+	sqlPrimaryKeyPattern  = regexp.MustCompile(`(?is)^(?:CONSTRAINT\s+\S+\s+)?PRIMARY\s+KEY\s*\(([^)]*)\)`)
+	sqlUniquePattern      = regexp.MustCompile(`(?is)^(?:CONSTRAINT\s+(\S+)\s+)?UNIQUE(?:\s+(?:KEY|INDEX))?\s*([^\s(]+)?\s*\(([^)]*)\)`)
+	sqlIndexPattern       = regexp.MustCompile(`(?is)^(?:INDEX|KEY)\s*(` + sqlIdentifier + `)?\s*\(([^)]*)\)`)
+	sqlInlineRefPattern   = regexp.MustCompile(`(?is)\bREFERENCES\s+([^\s(]+)\s*\(([^)]*)\)`)
+	sqlCreateIndexPattern = regexp.MustCompile(`(?is)CREATE\s+(UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?([^\s(]+)\s+ON\s+(?:ONLY\s+)?([^\s(]+)\s*(?:USING\s+\w+\s*)?\(([^;]*?)\)\s*;`)
+
+	// MySQL column attribute COMMENT 'text' and PostgreSQL COMMENT ON statements
+	sqlColumnCommentPattern = regexp.MustCompile(`(?i)\bCOMMENT\s+'((?:[^']|'')*)'`)
+	sqlCommentOnPattern     = regexp.MustCompile(`(?is)COMMENT\s+ON\s+(TABLE|COLUMN)\s+(\S+)\s+IS\s+'((?:[^']|'')*)'\s*;`)
+)
+
+// sqlSizedTypes are column types that take a length or precision, so
+// "key VARCHAR(50)" reads as a column named key rather than an index
+var sqlSizedTypes = map[string]bool{
+	"char": true, "character": true, "nchar": true, "varchar": true, "nvarchar": true, "varchar2": true,
+	"binary": true, "varbinary": true, "bit": true, "raw": true,
+	"tinyint": true, "smallint": true, "mediumint": true, "int": true, "integer": true, "bigint": true,
+	"decimal": true, "numeric": true, "number": true, "float": true, "double": true, "real": true,
+	"datetime": true, "datetime2": true, "timestamp": true, "time": true,
+	"enum": true, "set": true,
+}
+
+// matchSQLIndex matches a MySQL inline INDEX / KEY [name] (a, b) definition,
+// returning nil for a column named key or index
+func matchSQLIndex(line string) []string {
+	m := sqlIndexPattern.FindStringSubmatch(line)
+	if m == nil || sqlSizedTypes[strings.ToLower(m[1])] {
+		return nil
+	}
+	return m
+}
+
+// parseSQLSchema extracts CREATE TABLE and CREATE INDEX statements from SQL
+func parseSQLSchema(content string) ([]*Table, error) {
+	var tables []*Table
+	byName := make(map[string]*Table)
+
+	// Comments are blanked out before matching so they can't break
+	// statements, then attached to the tables and columns they describe
+	clean, comments := stripSQLComments(content)
+
+	for _, loc := range sqlCreateTablePattern.FindAllStringSubmatchIndex(clean, -1) {
+		schema, tableName := splitQualifiedName(clean[loc[2]:loc[3]])
+		columnsStr := clean[loc[4]:loc[5]]
+
+		table := &Table{
+			Name:        tableName,
+			Schema:      schema,
+			Columns:     []Column{},
+			Indexes:     []Index{},
+			ForeignKeys: []ForeignKey{},
+		}
+
+		// Parse columns, then table-level constraints
+		columns := parseColumns(columnsStr)
+		table.Columns = columns
+		parseTableConstraints(table, columnsStr)
+		attachSQLComments(table, clean, comments, loc[0], loc[4], loc[5])
+
+		tables = append(tables, table)
+		byName[tableName] = table
+	}
+
+	// Standalone CREATE [UNIQUE] INDEX statements
+	for _, match := range sqlCreateIndexPattern.FindAllStringSubmatch(clean, -1) {
+		_, tableName := splitQualifiedName(match[3])
+		table, exists := byName[tableName]
+		if !exists {
+			continue
+		}
+		table.Indexes = append(table.Indexes, Index{
+			Name:    unquoteIdentifier(match[2]),
+			Columns: parseIdentifierList(match[4]),
+			Unique:  strings.TrimSpace(match[1]) != "",
+		})
+	}
+
+	// PostgreSQL COMMENT ON TABLE/COLUMN statements override inline comments
+	for _, match := range sqlCommentOnPattern.FindAllStringSubmatch(clean, -1) {
+		text := strings.ReplaceAll(match[3], "''", "'")
+		target := match[2]
+		if strings.EqualFold(match[1], "TABLE") {
+			_, tableName := splitQualifiedName(target)
+			if table, exists := byName[tableName]; exists {
+				table.Comment = text
+			}
+			continue
+		}
+
+		dot := strings.LastIndex(target, ".")
+		if dot < 0 {
+			continue
+		}
+		_, tableName := splitQualifiedName(target[:dot])
+		columnName := unquoteIdentifier(target[dot+1:])
+		if table, exists := byName[tableName]; exists {
+			for i := range table.Columns {
+				if table.Columns[i].Name == columnName {
+					table.Columns[i].Comment = text
+				}
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+// sqlComment is a -- or /* */ comment removed from SQL before parsing
+type sqlComment struct {
+	Start int // byte offset in the original content
+	Text  string
+}
+
+// stripSQLComments blanks out comments with spaces, keeping newlines and
+// byte offsets intact so statement matches line up with the original, and
+// returns the removed comments. Quoted strings and identifiers are left
+// alone, so '--' inside a literal is not a comment.
+func stripSQLComments(content string) (string, []sqlComment) {
+	clean := []byte(content)
+	var comments []sqlComment
+	var quote byte
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '-' && i+1 < len(content) && content[i+1] == '-':
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			comments = append(comments, sqlComment{Start: i, Text: strings.TrimSpace(content[i+2 : i+end])})
+			blankSQLRange(clean, i, i+end)
+			i += end - 1
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				end = len(content) - i - 2
+			}
+			text := content[i+2 : i+2+end]
+			comments = append(comments, sqlComment{Start: i, Text: strings.Join(strings.Fields(text), " ")})
+			blankSQLRange(clean, i, min(i+2+end+2, len(content)))
+			i += end + 3
+		}
+	}
+
+	return string(clean), comments
+}
+
+// blankSQLRange replaces b[start:end] with spaces, keeping newlines
+func blankSQLRange(b []byte, start, end int) {
+	for i := start; i < end; i++ {
+		if b[i] != '\n' {
+			b[i] = ' '
+		}
+	}
+}
+
+// attachSQLComments assigns the comments of one CREATE TABLE statement:
+// comment lines directly above the statement, or trailing its opening
+// parenthesis, describe the table; a comment trailing a column definition,
+// or on the lines just above it, describes that column. Comments already
+// set (e.g. from a MySQL COMMENT attribute) are kept.
+func attachSQLComments(table *Table, clean string, comments []sqlComment, stmtStart, bodyStart, bodyEnd int) {
+	lineOf := func(offset int) int { return strings.Count(clean[:offset], "\n") }
+	lines := strings.Split(clean, "\n")
+
+	byLine := make(map[int][]string)
+	for _, c := range comments {
+		if c.Text != "" {
+			line := lineOf(c.Start)
+			byLine[line] = append(byLine[line], c.Text)
+		}
+	}
+
+	// Table comment: contiguous comment-only lines above the statement,
+	// plus a comment right after the opening parenthesis
+	var tableDoc []string
+	stmtLine := lineOf(stmtStart)
+	for line := stmtLine - 1; line >= 0 && strings.TrimSpace(lines[line]) == "" && len(byLine[line]) > 0; line-- {
+		tableDoc = append(byLine[line], tableDoc...)
+	}
+	bodyLine := lineOf(bodyStart)
+	lineEnd := strings.IndexByte(clean[bodyStart:], '\n')
+	if lineEnd >= 0 && strings.TrimSpace(clean[bodyStart:bodyStart+lineEnd]) == "" {
+		tableDoc = append(tableDoc, byLine[bodyLine]...)
+		bodyLine++
+	}
+	if table.Comment == "" && len(tableDoc) > 0 {
+		table.Comment = strings.Join(tableDoc, " ")
+	}
+
+	columns := make(map[string]*Column)
+	for i := range table.Columns {
+		columns[table.Columns[i].Name] = &table.Columns[i]
+	}
+
+	var pending []string
+	lastLine := lineOf(bodyEnd)
+	for line := bodyLine; line <= lastLine; line++ {
+		code := strings.TrimSpace(strings.Trim(strings.TrimSpace(lines[line]), ","))
+		if code == "" {
+			pending = append(pending, byLine[line]...)
+			continue
+		}
+
+		docs := append(pending, byLine[line]...)
+		pending = nil
+		fields := strings.Fields(code)
+		if col, ok := columns[unquoteIdentifier(fields[0])]; ok && col.Comment == "" && len(docs) > 0 {
+			col.Comment = strings.Join(docs, " ")
+		}
+	}
+}
+
+// parseColumns extracts column definitions from CREATE TABLE body
+func parseColumns(columnsStr string) []Column {
+	var columns []Column
+
+	for _, line := range splitSQLDefinitions(columnsStr) {
+		line = strings.TrimSpace(line)
+
+		// Skip constraints
+		if sqlConstraintPattern.MatchString(line) || matchSQLIndex(line) != nil {
+			continue
+		}
+
+		// Extract column name and type
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
 
 		colName := strings.Trim(parts[0], "`\"")
 		colType := parts[1]
@@ -573,29 +1431,1077 @@ func parseColumns(columnsStr string) []Column {
 			Nullable: true,
 		}
 
-		// Check for modifiers
-		lineUpper := strings.ToUpper(line)
-		if strings.Contains(lineUpper, "PRIMARY KEY") {
-			column.PrimaryKey = true
-			column.Nullable = false
+		// Check for modifiers
+		lineUpper := strings.ToUpper(line)
+		if strings.Contains(lineUpper, "PRIMARY KEY") {
+			column.PrimaryKey = true
+			column.Nullable = false
+		}
+		if strings.Contains(lineUpper, "NOT NULL") {
+			column.Nullable = false
+		}
+		if strings.Contains(lineUpper, "UNIQUE") {
+			column.Unique = true
+		}
+
+		// Extract default value
+		defaultPattern := regexp.MustCompile(`(?i)DEFAULT\s+([^,\s]+)`)
+		if matches := defaultPattern.FindStringSubmatch(line); len(matches) > 1 {
+			column.Default = matches[1]
+		}
+
+		if m := sqlColumnCommentPattern.FindStringSubmatch(line); m != nil {
+			column.Comment = strings.ReplaceAll(m[1], "''", "'")
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// parseTableConstraints populates foreign keys, indexes, and composite
+// primary keys from a CREATE TABLE body
+func parseTableConstraints(table *Table, columnsStr string) {
+	for _, line := range splitSQLDefinitions(columnsStr) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Table-level FOREIGN KEY (a, b) REFERENCES other (x, y)
+		if m := sqlForeignKeyPattern.FindStringSubmatch(line); m != nil {
+			table.ForeignKeys = append(table.ForeignKeys, pairForeignKeys(m[1], m[2], m[3])...)
+			continue
+		}
+
+		// Table-level PRIMARY KEY (a, b)
+		if m := sqlPrimaryKeyPattern.FindStringSubmatch(line); m != nil {
+			for _, name := range parseIdentifierList(m[1]) {
+				for i := range table.Columns {
+					if table.Columns[i].Name == name {
+						table.Columns[i].PrimaryKey = true
+						table.Columns[i].Nullable = false
+					}
+				}
+			}
+			continue
+		}
+
+		// UNIQUE [KEY|INDEX] [name] (a, b)
+		if m := sqlUniquePattern.FindStringSubmatch(line); m != nil {
+			name := unquoteIdentifier(m[1])
+			if name == "" {
+				name = unquoteIdentifier(m[2])
+			}
+			table.Indexes = append(table.Indexes, Index{
+				Name:    name,
+				Columns: parseIdentifierList(m[3]),
+				Unique:  true,
+			})
+			continue
+		}
+
+		// MySQL inline INDEX / KEY [name] (a, b)
+		if m := matchSQLIndex(line); m != nil {
+			table.Indexes = append(table.Indexes, Index{
+				Name:    unquoteIdentifier(m[1]),
+				Columns: parseIdentifierList(m[2]),
+			})
+			continue
+		}
+
+		// Inline column REFERENCES other (x)
+		if sqlConstraintPattern.MatchString(line) {
+			continue
+		}
+		if m := sqlInlineRefPattern.FindStringSubmatch(line); m != nil {
+			parts := strings.Fields(line)
+			table.ForeignKeys = append(table.ForeignKeys, pairForeignKeys(parts[0], m[1], m[2])...)
+		}
+	}
+}
+
+// pairForeignKeys zips local and referenced column lists into ForeignKeys
+func pairForeignKeys(localCols, refTable, refCols string) []ForeignKey {
+	locals := parseIdentifierList(localCols)
+	refs := parseIdentifierList(refCols)
+	refSchema, refTableName := splitQualifiedName(refTable)
+
+	var fks []ForeignKey
+	for i, local := range locals {
+		refCol := ""
+		if i < len(refs) {
+			refCol = refs[i]
+		}
+		fks = append(fks, ForeignKey{
+			Column:           local,
+			ReferencedTable:  refTableName,
+			ReferencedColumn: refCol,
+			ReferencedSchema: refSchema,
+		})
+	}
+	return fks
+}
+
+// splitSQLDefinitions splits a CREATE TABLE body on top-level commas,
+// keeping commas inside parentheses (e.g. DECIMAL(10,2), KEY (a,b)) and
+// string literals (DEFAULT 'a,b', COMMENT 'x, y') intact
+func splitSQLDefinitions(body string) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	inString := false
+
+	for i, r := range body {
+		if inString {
+			if r == '\'' {
+				inString = false
+			}
+			continue
+		}
+		switch r {
+		case '\'':
+			inString = true
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, body[start:])
+
+	return parts
+}
+
+// parseIdentifierList splits "a, `b`, \"c\"" into unquoted identifiers,
+// dropping ordering and length suffixes like "name DESC" or "title(20)"
+func parseIdentifierList(list string) []string {
+	var names []string
+	for _, part := range strings.Split(list, ",") {
+		fields := strings.Fields(strings.TrimSpace(part))
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[0]
+		if idx := strings.Index(name, "("); idx > 0 {
+			name = name[:idx]
+		}
+		names = append(names, unquoteIdentifier(name))
+	}
+	return names
+}
+
+// splitQualifiedName splits a possibly qualified name like public.users,
+// `db`.`tbl` or "my.schema"."users" into its schema and unquoted table name.
+// Dots inside quotes are part of the identifier. For three-part names the
+// part before the table is returned as the schema.
+func splitQualifiedName(name string) (schema, table string) {
+	var parts []string
+	var quote rune
+	start := 0
+
+	for i, r := range name {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '`' || r == '"':
+			quote = r
+		case r == '[':
+			quote = ']'
+		case r == '.':
+			parts = append(parts, name[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, name[start:])
+
+	table = unquoteIdentifier(parts[len(parts)-1])
+	if len(parts) > 1 {
+		schema = unquoteIdentifier(parts[len(parts)-2])
+	}
+	return schema, table
+}
+
+// unquoteIdentifier strips SQL identifier quoting
+func unquoteIdentifier(name string) string {
+	return strings.Trim(strings.TrimSpace(name), "`\"[]")
+}
+
+// Prisma patterns used while parsing schema.prisma models
+var (
+	prismaBlockPattern     = regexp.MustCompile(`^(model|enum|view|type)\s+(\w+)\s*\{`)
+	prismaFieldPattern     = regexp.MustCompile(`^(\w+)\s+(\w+(?:\([^)]*\))?)(\[\])?(\?)?\s*(.*)$`)
+	prismaMapPattern       = regexp.MustCompile(`@map\(\s*(?:name:\s*)?"([^"]+)"`)
+	prismaTableMapPattern  = regexp.MustCompile(`^@@map\(\s*(?:name:\s*)?"([^"]+)"`)
+	prismaBlockAttrPattern = regexp.MustCompile(`^@@(id|unique|index)\(\s*(?:fields:\s*)?\[([^\]]*)\]`)
+	prismaRelationFields   = regexp.MustCompile(`fields:\s*\[([^\]]*)\]`)
+	prismaRelationRefs     = regexp.MustCompile(`references:\s*\[([^\]]*)\]`)
+)
+
+// prismaModel is a parsed model before relations are resolved to tables
+type prismaModel struct {
+	table     *Table
+	columnFor map[string]string // field name -> column name (after @map)
+	relations []prismaRelation
+	keys      []prismaBlockAttr
+}
+
+// prismaBlockAttr is an @@id, @@unique, or @@index model attribute
+type prismaBlockAttr struct {
+	kind   string
+	fields []string
+}
+
+// prismaRelation is a @relation(fields: [...], references: [...]) field
+type prismaRelation struct {
+	fields     []string
+	model      string
+	references []string
+}
+
+// parsePrismaSchema extracts models from a Prisma schema as tables. Scalar
+// and enum fields become columns, relation fields with fields/references
+// become foreign keys, and /// doc comments become table and column comments.
+func parsePrismaSchema(content string) ([]*Table, error) {
+	lines := strings.Split(content, "\n")
+
+	// Model names first, so relation fields can be told apart from columns
+	models := make(map[string]*prismaModel)
+	var order []string
+	for _, line := range lines {
+		if m := prismaBlockPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil && m[1] == "model" {
+			models[m[2]] = nil
+			order = append(order, m[2])
+		}
+	}
+
+	var current *prismaModel
+	var docs []string
+	for _, line := range lines {
+		code, doc := splitPrismaComment(line)
+		if doc != "" && code == "" {
+			docs = append(docs, doc)
+			continue
+		}
+
+		if current == nil {
+			if m := prismaBlockPattern.FindStringSubmatch(code); m != nil && m[1] == "model" {
+				current = &prismaModel{
+					table: &Table{
+						Name:        m[2],
+						Comment:     strings.Join(docs, " "),
+						Columns:     []Column{},
+						Indexes:     []Index{},
+						ForeignKeys: []ForeignKey{},
+					},
+					columnFor: make(map[string]string),
+				}
+				models[m[2]] = current
+			}
+			docs = nil
+			continue
+		}
+
+		if code == "}" {
+			current = nil
+			docs = nil
+			continue
+		}
+		if code == "" {
+			continue
+		}
+
+		if m := prismaTableMapPattern.FindStringSubmatch(code); m != nil {
+			current.table.Name = m[1]
+			continue
+		}
+		if m := prismaBlockAttrPattern.FindStringSubmatch(code); m != nil {
+			// Resolved to column names once every field is known
+			current.keys = append(current.keys, prismaBlockAttr{kind: m[1], fields: parseIdentifierList(m[2])})
+			continue
+		}
+
+		m := prismaFieldPattern.FindStringSubmatch(code)
+		if m == nil {
+			docs = nil
+			continue
+		}
+		name, fieldType, list, optional, attrs := m[1], m[2], m[3] != "", m[4] != "", m[5]
+
+		if _, isModel := models[fieldType]; isModel {
+			fields := prismaRelationFields.FindStringSubmatch(attrs)
+			refs := prismaRelationRefs.FindStringSubmatch(attrs)
+			if fields != nil && refs != nil {
+				current.relations = append(current.relations, prismaRelation{
+					fields:     parseIdentifierList(fields[1]),
+					model:      fieldType,
+					references: parseIdentifierList(refs[1]),
+				})
+			}
+			docs = nil
+			continue
+		}
+
+		if doc != "" {
+			docs = append(docs, doc)
+		}
+		column := Column{
+			Name:       name,
+			Type:       fieldType,
+			Nullable:   optional,
+			PrimaryKey: strings.Contains(attrs, "@id"),
+			Unique:     strings.Contains(attrs, "@unique"),
+			Default:    prismaAttrArg(attrs, "@default"),
+			Comment:    strings.Join(docs, " "),
+		}
+		if list {
+			column.Type += "[]"
+		}
+		if mapped := prismaMapPattern.FindStringSubmatch(attrs); mapped != nil {
+			column.Name = mapped[1]
+		}
+		current.columnFor[name] = column.Name
+		current.table.Columns = append(current.table.Columns, column)
+		docs = nil
+	}
+
+	var tables []*Table
+	for _, modelName := range order {
+		model := models[modelName]
+		if model == nil {
+			continue
+		}
+		resolvePrismaRelations(model, models)
+		tables = append(tables, model.table)
+	}
+
+	return tables, nil
+}
+
+// resolvePrismaRelations turns a model's @@id/@@unique/@@index attributes
+// into keys and indexes and its relation fields into foreign keys, using
+// mapped table and column names
+func resolvePrismaRelations(model *prismaModel, models map[string]*prismaModel) {
+	columnNames := func(m *prismaModel, fields []string) []string {
+		names := make([]string, len(fields))
+		for i, field := range fields {
+			names[i] = field
+			if mapped, ok := m.columnFor[field]; ok {
+				names[i] = mapped
+			}
+		}
+		return names
+	}
+
+	table := model.table
+	for _, key := range model.keys {
+		columns := columnNames(model, key.fields)
+		if key.kind != "id" {
+			table.Indexes = append(table.Indexes, Index{Columns: columns, Unique: key.kind == "unique"})
+			continue
+		}
+		for i := range table.Columns {
+			for _, name := range columns {
+				if table.Columns[i].Name == name {
+					table.Columns[i].PrimaryKey = true
+				}
+			}
+		}
+	}
+
+	for _, rel := range model.relations {
+		target := models[rel.model]
+		if target == nil {
+			continue
+		}
+		refs := columnNames(target, rel.references)
+		for i, column := range columnNames(model, rel.fields) {
+			refColumn := ""
+			if i < len(refs) {
+				refColumn = refs[i]
+			}
+			table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+				Column:           column,
+				ReferencedTable:  target.table.Name,
+				ReferencedColumn: refColumn,
+			})
+		}
+	}
+}
+
+// splitPrismaComment splits a schema line into trimmed code and the text of
+// a /// doc comment. Plain // comments are dropped; // inside strings is kept.
+func splitPrismaComment(line string) (code, doc string) {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '"' && (i == 0 || line[i-1] != '\\'):
+			inString = !inString
+		case !inString && strings.HasPrefix(line[i:], "//"):
+			if strings.HasPrefix(line[i:], "///") {
+				doc = strings.TrimSpace(line[i+3:])
+			}
+			return strings.TrimSpace(line[:i]), doc
+		}
+	}
+	return strings.TrimSpace(line), ""
+}
+
+// prismaAttrArg returns the argument of a field attribute like
+// @default(autoincrement()), honoring nested parentheses
+func prismaAttrArg(attrs, name string) string {
+	start := strings.Index(attrs, name+"(")
+	if start < 0 {
+		return ""
+	}
+	start += len(name) + 1
+	depth := 1
+	for i := start; i < len(attrs); i++ {
+		switch attrs[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return attrs[start:i]
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	railsCreateTablePattern = regexp.MustCompile(`^create_table\s+["':]([\w.]+)"?\s*,?\s*(.*?)\s*do\s*\|(\w+)\|$`)
+	railsStatementPattern   = regexp.MustCompile(`^(\w+)\.(\w+)\b\s*(.*)$`)
+	railsTopLevelPattern    = regexp.MustCompile(`^(add_foreign_key|add_index)\s+(.*)$`)
+	rubyKeywordArgPattern   = regexp.MustCompile(`^(\w+):\s*(.*)$`)
+	rubyLambdaPattern       = regexp.MustCompile(`^->\s*\{\s*(.*?)\s*\}$`)
+)
+
+// railsDeferred is an add_foreign_key or add_index call, applied once every
+// table is known
+type railsDeferred struct {
+	call string
+	args []string
+}
+
+// parseRailsSchema parses a Rails db/schema.rb: create_table blocks with
+// t.<type> columns and t.index, plus top-level add_foreign_key and add_index.
+// Tables get the implicit "id" primary key unless created with id: false.
+func parseRailsSchema(content string) ([]*Table, error) {
+	var tables []*Table
+	byName := make(map[string]*Table)
+	var deferred []railsDeferred
+
+	var current *Table
+	var blockVar string
+	var compositeKey []string
+	for _, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if current == nil {
+			if m := railsCreateTablePattern.FindStringSubmatch(line); m != nil {
+				current, compositeKey = newRailsTable(m[1], splitCallArgs(m[2]))
+				blockVar = m[3]
+				tables = append(tables, current)
+				byName[current.Name] = current
+			} else if m := railsTopLevelPattern.FindStringSubmatch(line); m != nil {
+				deferred = append(deferred, railsDeferred{call: m[1], args: splitCallArgs(m[2])})
+			}
+			continue
+		}
+
+		if line == "end" {
+			for i := range current.Columns {
+				for _, key := range compositeKey {
+					if current.Columns[i].Name == key {
+						current.Columns[i].PrimaryKey = true
+					}
+				}
+			}
+			current = nil
+			continue
+		}
+
+		m := railsStatementPattern.FindStringSubmatch(line)
+		if m == nil || m[1] != blockVar {
+			continue
+		}
+		method, args := m[2], splitCallArgs(m[3])
+		positional, options := rubyCallArgs(args)
+
+		switch method {
+		case "index":
+			if len(positional) > 0 {
+				current.Indexes = append(current.Indexes, railsIndex(positional[0], options))
+			}
+		case "timestamps":
+			for _, name := range []string{"created_at", "updated_at"} {
+				current.Columns = append(current.Columns, Column{Name: name, Type: "datetime", Nullable: options["null"] != "false"})
+			}
+		case "references", "belongs_to":
+			if len(positional) == 0 {
+				continue
+			}
+			ref := unquoteArg(positional[0])
+			colType := "bigint"
+			if t, ok := options["type"]; ok {
+				colType = unquoteArg(t)
+			}
+			current.Columns = append(current.Columns, Column{Name: ref + "_id", Type: colType, Nullable: options["null"] != "false"})
+			if options["polymorphic"] == "true" {
+				current.Columns = append(current.Columns, Column{Name: ref + "_type", Type: "string", Nullable: options["null"] != "false"})
+			}
+			if options["foreign_key"] == "true" {
+				current.ForeignKeys = append(current.ForeignKeys, ForeignKey{Column: ref + "_id", ReferencedTable: ref + "s", ReferencedColumn: "id"})
+			}
+		case "check_constraint", "foreign_key", "remove", "rename":
+			// Not columns
+		default:
+			if len(positional) == 0 {
+				continue
+			}
+			current.Columns = append(current.Columns, railsColumn(method, unquoteArg(positional[0]), options))
+		}
+	}
+
+	for _, d := range deferred {
+		positional, options := rubyCallArgs(d.args)
+		if len(positional) < 2 {
+			continue
+		}
+		table := byName[unquoteArg(positional[0])]
+		if table == nil {
+			continue
+		}
+		switch d.call {
+		case "add_foreign_key":
+			refTable := unquoteArg(positional[1])
+			fk := ForeignKey{
+				Column:           singularize(refTable) + "_id",
+				ReferencedTable:  refTable,
+				ReferencedColumn: "id",
+			}
+			if col, ok := options["column"]; ok {
+				fk.Column = unquoteArg(col)
+			}
+			if pk, ok := options["primary_key"]; ok {
+				fk.ReferencedColumn = unquoteArg(pk)
+			}
+			table.ForeignKeys = append(table.ForeignKeys, fk)
+		case "add_index":
+			table.Indexes = append(table.Indexes, railsIndex(positional[1], options))
+		}
+	}
+
+	return tables, nil
+}
+
+// newRailsTable starts a table from create_table options, adding the implicit
+// primary key column. A composite primary_key list is returned so its columns
+// can be marked once they are defined.
+func newRailsTable(name string, args []string) (*Table, []string) {
+	_, options := rubyCallArgs(args)
+	table := &Table{
+		Name:        name,
+		Comment:     unquoteArg(options["comment"]),
+		Columns:     []Column{},
+		Indexes:     []Index{},
+		ForeignKeys: []ForeignKey{},
+	}
+
+	pkName, pkType := "id", "bigint"
+	if pk, ok := options["primary_key"]; ok {
+		if strings.HasPrefix(pk, "[") {
+			return table, rubyStringList(pk)
+		}
+		pkName = unquoteArg(pk)
+	}
+	if id, ok := options["id"]; ok {
+		if id == "false" {
+			return table, nil
+		}
+		pkType = unquoteArg(id)
+	}
+	table.Columns = append(table.Columns, Column{Name: pkName, Type: pkType, PrimaryKey: true})
+	return table, nil
+}
+
+// railsColumn builds a column from a t.<type> "name", options... call
+func railsColumn(colType, name string, options map[string]string) Column {
+	switch {
+	case options["precision"] != "" && options["scale"] != "":
+		colType = fmt.Sprintf("%s(%s,%s)", colType, options["precision"], options["scale"])
+	case options["limit"] != "":
+		colType = fmt.Sprintf("%s(%s)", colType, options["limit"])
+	}
+	if options["array"] == "true" {
+		colType += "[]"
+	}
+
+	column := Column{
+		Name:     name,
+		Type:     colType,
+		Nullable: options["null"] != "false",
+		Comment:  unquoteArg(options["comment"]),
+	}
+	// Defaults stay literal like SQL and Prisma ones, except SQL
+	// expressions wrapped in a lambda
+	if def, ok := options["default"]; ok {
+		if m := rubyLambdaPattern.FindStringSubmatch(def); m != nil {
+			def = unquoteArg(m[1])
+		}
+		column.Default = def
+	}
+	return column
+}
+
+// railsIndex builds an index from t.index / add_index columns and options
+func railsIndex(columns string, options map[string]string) Index {
+	return Index{
+		Name:    unquoteArg(options["name"]),
+		Columns: rubyStringList(columns),
+		Unique:  options["unique"] == "true",
+	}
+}
+
+// rubyCallArgs separates positional arguments from key: value options
+func rubyCallArgs(args []string) ([]string, map[string]string) {
+	var positional []string
+	options := make(map[string]string)
+	for _, arg := range args {
+		if m := rubyKeywordArgPattern.FindStringSubmatch(arg); m != nil {
+			options[m[1]] = m[2]
+		} else {
+			positional = append(positional, arg)
+		}
+	}
+	return positional, options
+}
+
+// rubyStringList parses ["a", "b"] (or a single "a") into names
+func rubyStringList(value string) []string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") {
+		return []string{unquoteArg(value)}
+	}
+	var names []string
+	for _, item := range splitCallArgs(strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")) {
+		names = append(names, unquoteArg(item))
+	}
+	return names
+}
+
+// singularize makes a rough singular of a plural table name, as Rails does
+// when deriving a foreign key column
+func singularize(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+var (
+	djangoClassPattern      = regexp.MustCompile(`^class\s+(\w+)\s*(?:\(([^)]*)\))?\s*:`)
+	djangoFieldPattern      = regexp.MustCompile(`^(\w+)\s*=\s*(?:models\.)?(\w*Field|ForeignKey)\s*\((.*)\)$`)
+	djangoMetaPattern       = regexp.MustCompile(`^(\w+)\s*=\s*(.+)$`)
+	djangoMetaIndexPattern  = regexp.MustCompile(`(?:models\.)?(Index|UniqueConstraint)\s*\(([^()]*)\)`)
+	pythonKeywordArgPattern = regexp.MustCompile(`^(\w+)\s*=\s*([^=].*)$`)
+)
+
+// djangoModel is a Django model being parsed
+type djangoModel struct {
+	name     string
+	table    *Table
+	abstract  bool
+	fks       []djangoForeignKey
+	columnFor map[string]string // field name -> column name, for Meta indexes
+}
+
+// djangoForeignKey is a ForeignKey/OneToOneField, resolved to a table once
+// every model in the file is known
+type djangoForeignKey struct {
+	column  string
+	target  string
+	toField string
+}
+
+// pythonLine is a logical Python line with its indentation
+type pythonLine struct {
+	indent int
+	text   string
+}
+
+// parseDjangoModels parses Django models.py classes deriving from
+// models.Model. Fields map to columns (ForeignKey/OneToOneField become
+// <name>_id), Meta supplies db_table, indexes and abstract, and models without
+// a primary key field get Django's implicit "id". Default table names are
+// <app>_<model>, all lowercase.
+func parseDjangoModels(content, appLabel string) ([]*Table, error) {
+	var models []*djangoModel
+	var current *djangoModel
+	metaIndent := -1
+
+	for _, line := range pythonLogicalLines(content) {
+		if line.indent == 0 {
+			current = nil
+			metaIndent = -1
+			if m := djangoClassPattern.FindStringSubmatch(line.text); m != nil && strings.Contains(m[2], "Model") {
+				current = &djangoModel{
+					name:      m[1],
+					columnFor: make(map[string]string),
+					table: &Table{
+						Name:        djangoTableName(appLabel, m[1]),
+						Columns:     []Column{},
+						Indexes:     []Index{},
+						ForeignKeys: []ForeignKey{},
+					},
+				}
+				models = append(models, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if metaIndent >= 0 && line.indent > metaIndent {
+			applyDjangoMeta(current, line.text)
+			continue
+		}
+		metaIndent = -1
+
+		if m := djangoClassPattern.FindStringSubmatch(line.text); m != nil {
+			if m[1] == "Meta" {
+				metaIndent = line.indent
+			}
+			continue
+		}
+
+		if m := djangoFieldPattern.FindStringSubmatch(line.text); m != nil {
+			addDjangoField(current, m[1], m[2], splitCallArgs(m[3]))
+		}
+	}
+
+	byName := make(map[string]*djangoModel)
+	for _, model := range models {
+		byName[model.name] = model
+	}
+
+	var tables []*Table
+	for _, model := range models {
+		if model.abstract {
+			continue
+		}
+		hasPK := false
+		for _, col := range model.table.Columns {
+			hasPK = hasPK || col.PrimaryKey
+		}
+		if !hasPK {
+			id := Column{Name: "id", Type: "BigAutoField", PrimaryKey: true}
+			model.table.Columns = append([]Column{id}, model.table.Columns...)
+		}
+		for _, index := range model.table.Indexes {
+			for i, field := range index.Columns {
+				if column, ok := model.columnFor[field]; ok {
+					index.Columns[i] = column
+				}
+			}
+		}
+		tables = append(tables, model.table)
+	}
+
+	// Resolve relations now that every table name and primary key is known
+	for _, model := range models {
+		for _, fk := range model.fks {
+			refTable, refColumn := "", "id"
+			target := fk.target
+			if target == "self" {
+				target = model.name
+			}
+			app, name, qualified := strings.Cut(target, ".")
+			if !qualified {
+				app, name = appLabel, target
+			}
+			if ref := byName[name]; ref != nil && (!qualified || app == appLabel) {
+				refTable = ref.table.Name
+				for _, col := range ref.table.Columns {
+					if col.PrimaryKey {
+						refColumn = col.Name
+						break
+					}
+				}
+			} else {
+				refTable = djangoTableName(app, name)
+			}
+			if fk.toField != "" {
+				refColumn = fk.toField
+			}
+			model.table.ForeignKeys = append(model.table.ForeignKeys, ForeignKey{
+				Column:           fk.column,
+				ReferencedTable:  refTable,
+				ReferencedColumn: refColumn,
+			})
+		}
+	}
+
+	return tables, nil
+}
+
+// addDjangoField adds a model field's column. ManyToManyField lives in its
+// own join table and is skipped.
+func addDjangoField(model *djangoModel, name, fieldType string, args []string) {
+	if fieldType == "ManyToManyField" {
+		return
+	}
+
+	var positional []string
+	options := make(map[string]string)
+	for _, arg := range args {
+		if m := pythonKeywordArgPattern.FindStringSubmatch(arg); m != nil {
+			options[m[1]] = strings.TrimSpace(m[2])
+		} else {
+			positional = append(positional, arg)
+		}
+	}
+
+	column := Column{
+		Name:       name,
+		Type:       fieldType,
+		Nullable:   options["null"] == "True",
+		PrimaryKey: options["primary_key"] == "True",
+		Unique:     options["unique"] == "True" || fieldType == "OneToOneField",
+		Comment:    unquoteArg(options["db_comment"]),
+	}
+	if column.Comment == "" {
+		column.Comment = unquoteArg(options["help_text"])
+	}
+	switch {
+	case options["max_digits"] != "" && options["decimal_places"] != "":
+		column.Type = fmt.Sprintf("%s(%s,%s)", fieldType, options["max_digits"], options["decimal_places"])
+	case options["max_length"] != "":
+		column.Type = fmt.Sprintf("%s(%s)", fieldType, options["max_length"])
+	}
+	if def, ok := options["default"]; ok {
+		column.Default = def
+	}
+
+	if fieldType == "ForeignKey" || fieldType == "OneToOneField" {
+		target := options["to"]
+		if target == "" && len(positional) > 0 {
+			target = positional[0]
+		}
+		column.Name = name + "_id"
+		model.fks = append(model.fks, djangoForeignKey{
+			column:  name + "_id",
+			target:  unquoteArg(target),
+			toField: unquoteArg(options["to_field"]),
+		})
+	}
+	if col, ok := options["db_column"]; ok {
+		if len(model.fks) > 0 && model.fks[len(model.fks)-1].column == column.Name {
+			model.fks[len(model.fks)-1].column = unquoteArg(col)
+		}
+		column.Name = unquoteArg(col)
+	}
+
+	model.columnFor[name] = column.Name
+	model.table.Columns = append(model.table.Columns, column)
+}
+
+// applyDjangoMeta applies one assignment from a model's inner Meta class
+func applyDjangoMeta(model *djangoModel, text string) {
+	m := djangoMetaPattern.FindStringSubmatch(text)
+	if m == nil {
+		return
+	}
+	switch m[1] {
+	case "db_table":
+		model.table.Name = unquoteArg(m[2])
+	case "abstract":
+		model.abstract = m[2] == "True"
+	case "db_table_comment":
+		model.table.Comment = unquoteArg(m[2])
+	case "indexes", "constraints":
+		for _, idx := range djangoMetaIndexPattern.FindAllStringSubmatch(m[2], -1) {
+			options := make(map[string]string)
+			for _, arg := range splitCallArgs(idx[2]) {
+				if kw := pythonKeywordArgPattern.FindStringSubmatch(arg); kw != nil {
+					options[kw[1]] = strings.TrimSpace(kw[2])
+				}
+			}
+			if options["fields"] == "" {
+				continue
+			}
+			model.table.Indexes = append(model.table.Indexes, Index{
+				Name:    unquoteArg(options["name"]),
+				Columns: rubyStringList(strings.NewReplacer("(", "[", ")", "]").Replace(options["fields"])),
+				Unique:  idx[1] == "UniqueConstraint",
+			})
+		}
+	case "unique_together":
+		groups := strings.TrimSpace(m[2])
+		groups = strings.TrimSuffix(strings.TrimPrefix(groups, "("), ")")
+		groups = strings.TrimSuffix(strings.TrimPrefix(groups, "["), "]")
+		items := splitCallArgs(groups)
+		if len(items) > 0 && !strings.HasPrefix(items[0], "(") && !strings.HasPrefix(items[0], "[") {
+			items = []string{"[" + groups + "]"} // a single flat tuple
+		}
+		for _, item := range items {
+			model.table.Indexes = append(model.table.Indexes, Index{
+				Columns: rubyStringList(strings.NewReplacer("(", "[", ")", "]").Replace(item)),
+				Unique:  true,
+			})
+		}
+	}
+}
+
+// djangoTableName is Django's default table name for a model
+func djangoTableName(appLabel, model string) string {
+	if appLabel == "" {
+		return strings.ToLower(model)
+	}
+	return strings.ToLower(appLabel + "_" + model)
+}
+
+// pythonLogicalLines joins bracketed continuation lines and strips comments,
+// returning non-blank logical lines with their indentation
+func pythonLogicalLines(content string) []pythonLine {
+	var lines []pythonLine
+	var buf strings.Builder
+	depth, indent := 0, 0
+
+	for _, raw := range strings.Split(content, "\n") {
+		code := stripPythonComment(raw)
+		if depth == 0 {
+			if strings.TrimSpace(code) == "" {
+				continue
+			}
+			expanded := strings.ReplaceAll(code, "\t", "    ")
+			indent = len(expanded) - len(strings.TrimLeft(expanded, " "))
+		} else {
+			buf.WriteString(" ")
 		}
-		if strings.Contains(lineUpper, "NOT NULL") {
-			column.Nullable = false
+		buf.WriteString(strings.TrimSpace(code))
+		depth += bracketDepth(code)
+		if depth <= 0 {
+			lines = append(lines, pythonLine{indent: indent, text: buf.String()})
+			buf.Reset()
+			depth = 0
 		}
-		if strings.Contains(lineUpper, "UNIQUE") {
-			column.Unique = true
+	}
+	if buf.Len() > 0 {
+		lines = append(lines, pythonLine{indent: indent, text: buf.String()})
+	}
+	return lines
+}
+
+// stripPythonComment removes a trailing # comment outside of string literals
+func stripPythonComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote && (i == 0 || line[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '#':
+			return line[:i]
 		}
+	}
+	return line
+}
 
-		// Extract default value
-		defaultPattern := regexp.MustCompile(`(?i)DEFAULT\s+([^,\s]+)`)
-		if matches := defaultPattern.FindStringSubmatch(line); len(matches) > 1 {
-			column.Default = matches[1]
+// bracketDepth returns the net bracket nesting a line opens, ignoring
+// brackets inside string literals
+func bracketDepth(line string) int {
+	depth := 0
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote && (i == 0 || line[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
 		}
+	}
+	return depth
+}
 
-		columns = append(columns, column)
+// splitCallArgs splits Ruby or Python call arguments on top-level commas,
+// keeping brackets and string literals intact
+func splitCallArgs(args string) []string {
+	var parts []string
+	var current strings.Builder
+	depth := 0
+	var quote rune
+	for i, r := range args {
+		switch {
+		case quote != 0:
+			if r == quote && (i == 0 || args[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			if part := strings.TrimSpace(current.String()); part != "" {
+				parts = append(parts, part)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if part := strings.TrimSpace(current.String()); part != "" {
+		parts = append(parts, part)
 	}
+	return parts
+}
 
-	return columns
+// unquoteArg turns a Ruby or Python literal into its text: quotes are
+// removed, :symbols lose their colon and _("...") translations are unwrapped
+func unquoteArg(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "_(") && strings.HasSuffix(value, ")") {
+		value = strings.TrimSpace(value[2 : len(value)-1])
+	}
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && last == first {
+			return value[1 : len(value)-1]
+		}
+	}
+	return strings.TrimPrefix(value, ":")
 }
 
 // calculateChecksum generates a hash of the schema structure
@@ -613,8 +2519,8 @@ func getGitCommit(path string) string {
 
 // getCatalogDir returns the catalog directory path
 func getCatalogDir() string {
-	homeDir, _ := os.UserHomeDir()
-	return filepath.Join(homeDir, ".claude", "ram", "librarian", "catalog")
+	ramDir, _ := ram.DefaultRAMDir()
+	return filepath.Join(ramDir, "librarian", "catalog")
 }
 
 // saveSnapshot saves a schema snapshot to the catalog
@@ -628,7 +2534,7 @@ func saveSnapshot(snapshot *SchemaSnapshot) error {
 	}
 
 	// Save timestamped snapshot
-	timestamp := snapshot.SnapshotTime.Format("2006-01-02-150405")
+	timestamp := snapshot.SnapshotTime.Format(snapshotFileTimeFormat)
 	snapshotFile := filepath.Join(projectDir, fmt.Sprintf("schema-%s.json", timestamp))
 
 	data, err := json.MarshalIndent(snapshot, "", "  ")
@@ -636,38 +2542,99 @@ func saveSnapshot(snapshot *SchemaSnapshot) error {
 		return fmt.Errorf("failed to marshal snapshot: %w", err)
 	}
 
-	if err := os.WriteFile(snapshotFile, data, 0644); err != nil {
+	// Both files are written via temp file and rename so an interrupted
+	// save never leaves a truncated snapshot behind
+	if err := util.WriteFileAtomic(snapshotFile, data, 0644); err != nil {
 		return fmt.Errorf("failed to write snapshot file: %w", err)
 	}
 
-	// Update latest symlink
+	// schema-latest.json is a plain copy of the newest snapshot
 	latestFile := filepath.Join(projectDir, "schema-latest.json")
-	os.Remove(latestFile) // Remove old symlink if exists
-	if err := os.WriteFile(latestFile, data, 0644); err != nil {
-		// Fallback to copy if symlink fails
+	if err := util.WriteFileAtomic(latestFile, data, 0644); err != nil {
 		return fmt.Errorf("failed to update latest snapshot: %w", err)
 	}
 
 	return nil
 }
 
-// loadLatestSnapshot loads the most recent snapshot for a project
+// snapshotFileTimeFormat is the timestamp layout used in snapshot file names
+const snapshotFileTimeFormat = "2006-01-02-150405"
+
+// snapshotRefTimeFormats are the timestamp layouts accepted in project@timestamp
+// references: the file name stamp and the format shown by history and list
+var snapshotRefTimeFormats = []string{
+	snapshotFileTimeFormat,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// loadSnapshotRef loads a snapshot from a "project" (latest) or
+// "project@timestamp" reference
+func loadSnapshotRef(ref string) (*SchemaSnapshot, error) {
+	projectName, stamp, hasStamp := strings.Cut(ref, "@")
+	if projectName == "" {
+		return nil, fmt.Errorf("invalid snapshot reference '%s': missing project", ref)
+	}
+
+	if !hasStamp || stamp == "" {
+		snapshot, err := loadLatestSnapshot(projectName)
+		if err != nil {
+			return nil, fmt.Errorf("no snapshot found for project '%s': %w", projectName, err)
+		}
+		return snapshot, nil
+	}
+
+	var fileStamp string
+	for _, layout := range snapshotRefTimeFormats {
+		if t, err := time.ParseInLocation(layout, stamp, time.Local); err == nil {
+			fileStamp = t.Format(snapshotFileTimeFormat)
+			break
+		}
+	}
+	if fileStamp == "" {
+		return nil, fmt.Errorf("invalid snapshot timestamp '%s' (use %s)", stamp, snapshotFileTimeFormat)
+	}
+
+	snapshotFile := filepath.Join(getCatalogDir(), projectName, fmt.Sprintf("schema-%s.json", fileStamp))
+	data, err := os.ReadFile(snapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no snapshot of '%s' at %s (see: matrix schema-catalog history)", projectName, stamp)
+		}
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot SchemaSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", snapshotFile, err)
+	}
+	keySchemaTables(&snapshot)
+
+	return &snapshot, nil
+}
+
+// loadLatestSnapshot loads the most recent snapshot for a project. If
+// schema-latest.json is missing or unreadable (e.g. truncated by an older
+// interrupted save) it falls back to the newest timestamped snapshot.
 func loadLatestSnapshot(projectName string) (*SchemaSnapshot, error) {
 	catalogDir := getCatalogDir()
 	projectDir := filepath.Join(catalogDir, projectName)
 	latestFile := filepath.Join(projectDir, "schema-latest.json")
 
 	data, err := os.ReadFile(latestFile)
-	if err != nil {
-		return nil, err
+	if err == nil {
+		var snapshot SchemaSnapshot
+		if err = json.Unmarshal(data, &snapshot); err == nil {
+			keySchemaTables(&snapshot)
+			return &snapshot, nil
+		}
 	}
 
-	var snapshot SchemaSnapshot
-	if err := json.Unmarshal(data, &snapshot); err != nil {
+	snapshots, loadErr := loadAllSnapshots(projectDir)
+	if loadErr != nil || len(snapshots) == 0 {
 		return nil, err
 	}
-
-	return &snapshot, nil
+	return snapshots[len(snapshots)-1], nil
 }
 
 // loadAllSnapshots loads all snapshots for a project
@@ -679,7 +2646,7 @@ func loadAllSnapshots(projectDir string) ([]*SchemaSnapshot, error) {
 
 	var snapshots []*SchemaSnapshot
 	for _, file := range files {
-		// Skip latest symlink
+		// Skip the latest copy, it duplicates a timestamped snapshot
 		if strings.Contains(file, "latest") {
 			continue
 		}
@@ -693,6 +2660,7 @@ func loadAllSnapshots(projectDir string) ([]*SchemaSnapshot, error) {
 		if err := json.Unmarshal(data, &snapshot); err != nil {
 			continue
 		}
+		keySchemaTables(&snapshot)
 
 		snapshots = append(snapshots, &snapshot)
 	}
@@ -708,54 +2676,385 @@ func loadAllSnapshots(projectDir string) ([]*SchemaSnapshot, error) {
 // compareSnapshots generates a diff between two snapshots
 func compareSnapshots(old, new *SchemaSnapshot) SchemaDiff {
 	diff := SchemaDiff{
-		Added:    []string{},
-		Modified: []string{},
-		Removed:  []string{},
+		Added:       []string{},
+		Modified:    []string{},
+		Removed:     []string{},
+		Renamed:     []string{},
+		Destructive: make(map[string]string),
+	}
+
+	// Split tables into matched, added, and removed
+	var addedTables, removedTables []*Table
+	for _, tableName := range sortedTableNames(new) {
+		newTable := new.Tables[tableName]
+		if oldTable, exists := old.Tables[tableName]; exists {
+			compareTables(&diff, oldTable, newTable)
+		} else {
+			addedTables = append(addedTables, newTable)
+		}
+	}
+	for _, tableName := range sortedTableNames(old) {
+		if _, exists := new.Tables[tableName]; !exists {
+			removedTables = append(removedTables, old.Tables[tableName])
+		}
+	}
+
+	// Pair removed and added tables that look like renames
+	renamedTables := matchRenames(len(removedTables), len(addedTables), func(i, j int) float64 {
+		return tableRenameScore(removedTables[i], addedTables[j])
+	})
+	for _, i := range sortedRenameKeys(renamedTables) {
+		j := renamedTables[i]
+		diff.Renamed = append(diff.Renamed, fmt.Sprintf("table: %s -> %s", removedTables[i].Name, addedTables[j].Name))
+		compareTables(&diff, removedTables[i], addedTables[j])
+	}
+
+	for j, table := range addedTables {
+		if !containsValue(renamedTables, j) {
+			diff.Added = append(diff.Added, fmt.Sprintf("table: %s", table.Name))
+		}
+	}
+	for i, table := range removedTables {
+		if _, renamed := renamedTables[i]; !renamed {
+			label := fmt.Sprintf("table: %s", table.Name)
+			diff.Removed = append(diff.Removed, label)
+			diff.Destructive[label] = "drops the table and its data"
+		}
+	}
+
+	return diff
+}
+
+// compareTables records column, foreign key, and index changes between two
+// versions of a table. Labels use the new table name.
+func compareTables(diff *SchemaDiff, oldTable, newTable *Table) {
+	tableName := newTable.Name
+
+	oldCols := make(map[string]Column)
+	for _, col := range oldTable.Columns {
+		oldCols[col.Name] = col
+	}
+	newCols := make(map[string]bool)
+	for _, col := range newTable.Columns {
+		newCols[col.Name] = true
 	}
 
-	// Find added and modified tables
-	for tableName, newTable := range new.Tables {
-		oldTable, exists := old.Tables[tableName]
+	var addedCols, removedCols []Column
+	for _, newCol := range newTable.Columns {
+		oldCol, exists := oldCols[newCol.Name]
 		if !exists {
-			diff.Added = append(diff.Added, fmt.Sprintf("table: %s", tableName))
-			continue
+			addedCols = append(addedCols, newCol)
+		} else if oldCol.Type != newCol.Type || oldCol.Nullable != newCol.Nullable {
+			label := fmt.Sprintf("%s.%s (%s -> %s)", tableName, newCol.Name, oldCol.Type, newCol.Type)
+			if oldCol.Nullable && !newCol.Nullable {
+				label = fmt.Sprintf("%s.%s (%s -> %s NOT NULL)", tableName, newCol.Name, oldCol.Type, newCol.Type)
+			}
+			diff.Modified = append(diff.Modified, label)
+			if reason, destructive := columnChangeRisk(oldCol, newCol); destructive {
+				diff.Destructive[label] = reason
+			}
+		}
+	}
+	for _, oldCol := range oldTable.Columns {
+		if !newCols[oldCol.Name] {
+			removedCols = append(removedCols, oldCol)
+		}
+	}
+
+	// A removed and added column with the same type and a similar name is a rename
+	renamedCols := matchRenames(len(removedCols), len(addedCols), func(i, j int) float64 {
+		return columnRenameScore(removedCols[i], addedCols[j])
+	})
+	for _, i := range sortedRenameKeys(renamedCols) {
+		j := renamedCols[i]
+		diff.Renamed = append(diff.Renamed, fmt.Sprintf("%s.%s -> %s.%s (%s)",
+			tableName, removedCols[i].Name, tableName, addedCols[j].Name, addedCols[j].Type))
+	}
+
+	for j, col := range addedCols {
+		if !containsValue(renamedCols, j) {
+			diff.Added = append(diff.Added, fmt.Sprintf("%s.%s (%s)", tableName, col.Name, col.Type))
+		}
+	}
+	for i, col := range removedCols {
+		if _, renamed := renamedCols[i]; !renamed {
+			label := fmt.Sprintf("%s.%s", tableName, col.Name)
+			diff.Removed = append(diff.Removed, label)
+			diff.Destructive[label] = "drops the column and its data"
+		}
+	}
+
+	// Compare foreign keys and indexes
+	added, removed := diffStringSets(foreignKeyLabels(oldTable), foreignKeyLabels(newTable))
+	diff.Added = append(diff.Added, added...)
+	diff.Removed = append(diff.Removed, removed...)
+
+	added, removed = diffStringSets(indexLabels(oldTable), indexLabels(newTable))
+	diff.Added = append(diff.Added, added...)
+	diff.Removed = append(diff.Removed, removed...)
+}
+
+// columnTypeShape is a column type reduced to what decides whether a change
+// narrows it
+type columnTypeShape struct {
+	base   string // lowercased type name without arguments
+	family string // int, float, decimal, text, or "" for anything else
+	size   int    // integer width, text length, or decimal precision; 0 = unbounded
+	scale  int    // decimal scale
+}
+
+// columnTypeWidths ranks integer and float types by storage width, and
+// gives fixed text types their length
+var columnTypeWidths = map[string]struct {
+	family string
+	size   int
+}{
+	"tinyint": {"int", 1}, "smallint": {"int", 2}, "int2": {"int", 2}, "smallserial": {"int", 2},
+	"mediumint": {"int", 3}, "int": {"int", 4}, "integer": {"int", 4}, "int4": {"int", 4},
+	"serial": {"int", 4}, "bigint": {"int", 8}, "int8": {"int", 8}, "bigserial": {"int", 8},
+	"real": {"float", 4}, "float4": {"float", 4}, "float": {"float", 8}, "double": {"float", 8},
+	"double precision": {"float", 8}, "float8": {"float", 8},
+	"decimal": {"decimal", 0}, "numeric": {"decimal", 0},
+	"varchar": {"text", 0}, "character varying": {"text", 0}, "nvarchar": {"text", 0},
+	"char": {"text", 0}, "character": {"text", 0}, "nchar": {"text", 0}, "string": {"text", 0},
+	"text": {"text", 0}, "mediumtext": {"text", 0}, "longtext": {"text", 0}, "clob": {"text", 0},
+	"tinytext": {"text", 255},
+}
+
+// parseColumnTypeShape parses types like VARCHAR(255), decimal(10, 2) or
+// BIGINT UNSIGNED
+func parseColumnTypeShape(colType string) columnTypeShape {
+	t := strings.ToLower(strings.TrimSpace(colType))
+	t = strings.TrimSpace(strings.TrimSuffix(t, "unsigned"))
+
+	var args []int
+	if open := strings.Index(t, "("); open >= 0 {
+		if end := strings.Index(t[open:], ")"); end >= 0 {
+			for _, arg := range strings.Split(t[open+1:open+end], ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(arg))
+				if err != nil {
+					break
+				}
+				args = append(args, n)
+			}
+		}
+		t = strings.TrimSpace(t[:open])
+	}
+
+	shape := columnTypeShape{base: t}
+	if width, ok := columnTypeWidths[t]; ok {
+		shape.family, shape.size = width.family, width.size
+	}
+	switch shape.family {
+	case "text", "decimal":
+		if len(args) > 0 {
+			shape.size = args[0]
+		}
+		if shape.family == "decimal" && len(args) > 1 {
+			shape.scale = args[1]
 		}
+	}
+	return shape
+}
+
+// columnChangeRisk reports whether changing oldCol to newCol can lose data
+// or fail against existing rows, and why. Conversions it can't prove safe
+// (e.g. text to integer) count as destructive.
+func columnChangeRisk(oldCol, newCol Column) (string, bool) {
+	if oldCol.Nullable && !newCol.Nullable {
+		return "becomes NOT NULL; existing NULLs would fail", true
+	}
+	if strings.EqualFold(oldCol.Type, newCol.Type) {
+		return "", false
+	}
 
-		// Compare columns
-		oldCols := make(map[string]Column)
-		for _, col := range oldTable.Columns {
-			oldCols[col.Name] = col
+	from, to := parseColumnTypeShape(oldCol.Type), parseColumnTypeShape(newCol.Type)
+	switch {
+	case to.family == "text" && to.size == 0:
+		// Anything fits in unbounded text
+		return "", false
+	case from.family != "" && from.family == to.family:
+		if to.size != 0 && (from.size == 0 || to.size < from.size) {
+			return fmt.Sprintf("narrows %s to %s", oldCol.Type, newCol.Type), true
+		}
+		if from.family == "decimal" && (to.scale < from.scale || (to.size != 0 && to.size-to.scale < from.size-from.scale)) {
+			return fmt.Sprintf("narrows %s to %s", oldCol.Type, newCol.Type), true
 		}
+		return "", false
+	case from.family == "int" && (to.family == "float" || (to.family == "decimal" && to.size == 0)):
+		return "", false
+	case from.family == "" && from.base == to.base:
+		return "", false
+	}
+	return fmt.Sprintf("changes type %s to %s; existing values may not convert", oldCol.Type, newCol.Type), true
+}
+
+// sortedRenameKeys returns the removed-item indexes of matchRenames pairs in
+// order, so diffs list renames the same way on every run
+func sortedRenameKeys(pairs map[int]int) []int {
+	keys := make([]int, 0, len(pairs))
+	for i := range pairs {
+		keys = append(keys, i)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// renameThreshold is the minimum score for a removed/added pair to count as a rename
+const renameThreshold = 0.5
 
-		for _, newCol := range newTable.Columns {
-			oldCol, exists := oldCols[newCol.Name]
-			if !exists {
-				diff.Added = append(diff.Added, fmt.Sprintf("%s.%s (%s)", tableName, newCol.Name, newCol.Type))
-			} else if oldCol.Type != newCol.Type || oldCol.Nullable != newCol.Nullable {
-				diff.Modified = append(diff.Modified, fmt.Sprintf("%s.%s (%s -> %s)", tableName, newCol.Name, oldCol.Type, newCol.Type))
+// matchRenames greedily pairs removed items (i) with added items (j) by
+// descending score, ignoring pairs below renameThreshold. It returns i -> j.
+func matchRenames(removed, added int, score func(i, j int) float64) map[int]int {
+	type candidate struct {
+		i, j  int
+		score float64
+	}
+
+	var candidates []candidate
+	for i := 0; i < removed; i++ {
+		for j := 0; j < added; j++ {
+			if sc := score(i, j); sc >= renameThreshold {
+				candidates = append(candidates, candidate{i, j, sc})
 			}
 		}
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].score > candidates[b].score
+	})
+
+	pairs := make(map[int]int)
+	usedAdded := make(map[int]bool)
+	for _, c := range candidates {
+		if _, taken := pairs[c.i]; taken || usedAdded[c.j] {
+			continue
+		}
+		pairs[c.i] = c.j
+		usedAdded[c.j] = true
+	}
+	return pairs
+}
+
+// columnRenameScore rates how likely newCol is oldCol renamed: columns must
+// share a type, then names are compared by edit distance
+func columnRenameScore(oldCol, newCol Column) float64 {
+	if !strings.EqualFold(oldCol.Type, newCol.Type) {
+		return 0
+	}
+	return nameSimilarity(oldCol.Name, newCol.Name)
+}
+
+// tableRenameScore rates how likely newTable is oldTable renamed, based on
+// the overlap of their column name/type pairs
+func tableRenameScore(oldTable, newTable *Table) float64 {
+	oldSet := make(map[string]bool)
+	for _, col := range oldTable.Columns {
+		oldSet[strings.ToLower(col.Name+" "+col.Type)] = true
+	}
 
-		// Find removed columns
-		newCols := make(map[string]bool)
-		for _, col := range newTable.Columns {
-			newCols[col.Name] = true
+	shared := 0
+	union := len(oldSet)
+	for _, col := range newTable.Columns {
+		if oldSet[strings.ToLower(col.Name+" "+col.Type)] {
+			shared++
+		} else {
+			union++
 		}
-		for _, oldCol := range oldTable.Columns {
-			if !newCols[oldCol.Name] {
-				diff.Removed = append(diff.Removed, fmt.Sprintf("%s.%s", tableName, oldCol.Name))
+	}
+
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// nameSimilarity returns 1 - normalized Levenshtein distance, ignoring case
+func nameSimilarity(a, b string) float64 {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein computes the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
 			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
 		}
+		prev, curr = curr, prev
 	}
+	return prev[len(b)]
+}
 
-	// Find removed tables
-	for tableName := range old.Tables {
-		if _, exists := new.Tables[tableName]; !exists {
-			diff.Removed = append(diff.Removed, fmt.Sprintf("table: %s", tableName))
+// containsValue reports whether any value in m equals v
+func containsValue(m map[int]int, v int) bool {
+	for _, value := range m {
+		if value == v {
+			return true
 		}
 	}
+	return false
+}
 
-	return diff
+// foreignKeyLabels describes each foreign key of a table for diffing
+func foreignKeyLabels(table *Table) []string {
+	var labels []string
+	for _, fk := range table.ForeignKeys {
+		labels = append(labels, fmt.Sprintf("fk: %s.%s -> %s.%s", table.Name, fk.Column, fk.ReferencedTable, fk.ReferencedColumn))
+	}
+	return labels
+}
+
+// indexLabels describes each index of a table for diffing
+func indexLabels(table *Table) []string {
+	var labels []string
+	for _, idx := range table.Indexes {
+		kind := "index"
+		if idx.Unique {
+			kind = "unique index"
+		}
+		labels = append(labels, fmt.Sprintf("%s: %s.%s (%s)", kind, table.Name, idx.Name, strings.Join(idx.Columns, ", ")))
+	}
+	return labels
+}
+
+// diffStringSets returns items only in new (added) and only in old (removed)
+func diffStringSets(old, new []string) (added, removed []string) {
+	oldSet := make(map[string]bool)
+	for _, item := range old {
+		oldSet[item] = true
+	}
+	newSet := make(map[string]bool)
+	for _, item := range new {
+		newSet[item] = true
+		if !oldSet[item] {
+			added = append(added, item)
+		}
+	}
+	for _, item := range old {
+		if !newSet[item] {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
 }
 
 // displaySchemaSnapshot displays a schema snapshot
@@ -763,7 +3062,7 @@ func displaySchemaSnapshot(snapshot *SchemaSnapshot) {
 	output.Header("SCHEMA")
 	fmt.Println("")
 	fmt.Printf("Project: %s\n", snapshot.Project)
-	fmt.Printf("Source: %s\n", snapshot.Source)
+	fmt.Printf("Source: %s\n", schemaSources(snapshot))
 	fmt.Printf("Tables: %d\n", len(snapshot.Tables))
 	fmt.Println("")
 
@@ -804,7 +3103,215 @@ func displaySchemaSnapshot(snapshot *SchemaSnapshot) {
 			if len(table.Columns) > limit {
 				fmt.Printf("    ... and %d more columns\n", len(table.Columns)-limit)
 			}
+			for _, fk := range table.ForeignKeys {
+				fmt.Printf("    → %s references %s.%s\n", fk.Column, fk.ReferencedTable, fk.ReferencedColumn)
+			}
+			if len(table.Indexes) > 0 {
+				fmt.Printf("    Indexes: %d\n", len(table.Indexes))
+			}
 			fmt.Println("")
 		}
 	}
+
+	if warnings := lintSchema(snapshot, schemaLintMaxColumns); len(warnings) > 0 {
+		output.Header(fmt.Sprintf("WARNINGS (%d):", len(warnings)))
+		fmt.Println("")
+		displaySchemaLintWarnings(warnings)
+	}
+}
+
+// schemaLintMaxColumns is how many columns a table can have before lint
+// calls it suspiciously wide
+const schemaLintMaxColumns = 40
+
+// SchemaLintWarning is one schema smell found by lint
+type SchemaLintWarning struct {
+	Table   string `json:"table"`
+	Column  string `json:"column,omitempty"`
+	Check   string `json:"check"` // no-primary-key, ambiguous-type, wide-table, unindexed-foreign-key
+	Message string `json:"message"`
+}
+
+// schemaCatchAllTypes are column types that say nothing about what the
+// column holds
+var schemaCatchAllTypes = map[string]bool{
+	"any": true, "variant": true, "sql_variant": true, "unknown": true,
+	"object": true, "mixed": true, "unsupported": true,
+}
+
+// runSchemaLint scans paths like scan, without cataloging, and reports
+// schema smells as warnings
+func runSchemaLint() error {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	maxColumns := fs.Int("max-columns", schemaLintMaxColumns, "Warn about tables with more columns than this")
+	project := fs.String("project", "", "Project name (default: name of the first path)")
+	jsonFlag := fs.Bool("json", false, "Output as JSON")
+	if len(os.Args) > 3 {
+		fs.Parse(os.Args[3:])
+	}
+	if *maxColumns < 1 {
+		return fmt.Errorf("--max-columns must be at least 1")
+	}
+
+	roots, err := resolveSchemaRoots(fs.Args())
+	if err != nil {
+		return err
+	}
+	for _, root := range roots {
+		if _, err := os.Stat(root); err != nil {
+			return fmt.Errorf("path does not exist: %s", root)
+		}
+	}
+
+	snapshot := scanSchemaRoots(schemaProjectName(*project, roots), roots, !*jsonFlag)
+	warnings := lintSchema(snapshot, *maxColumns)
+
+	if *jsonFlag {
+		if warnings == nil {
+			warnings = []SchemaLintWarning{}
+		}
+		return output.EmitJSON(struct {
+			Project  string              `json:"project"`
+			Tables   int                 `json:"tables"`
+			Warnings []SchemaLintWarning `json:"warnings"`
+		}{snapshot.Project, len(snapshot.Tables), warnings})
+	}
+
+	output.Success("📚 Schema Catalog - Lint")
+	fmt.Println("")
+	fmt.Printf("Project: %s\n", snapshot.Project)
+	fmt.Printf("Source: %s\n", schemaSources(snapshot))
+	fmt.Printf("Tables: %d\n", len(snapshot.Tables))
+	fmt.Println("")
+
+	if len(snapshot.SourceFiles) == 0 {
+		fmt.Println("No schema files found.")
+		return nil
+	}
+	if len(warnings) == 0 {
+		output.Success("✓ No schema warnings")
+		return nil
+	}
+
+	output.Header(fmt.Sprintf("WARNINGS (%d):", len(warnings)))
+	fmt.Println("")
+	displaySchemaLintWarnings(warnings)
+	return nil
+}
+
+// displaySchemaLintWarnings prints warnings as table[.column]: message
+func displaySchemaLintWarnings(warnings []SchemaLintWarning) {
+	for _, w := range warnings {
+		where := w.Table
+		if w.Column != "" {
+			where += "." + w.Column
+		}
+		fmt.Printf("  %s⚠%s %s: %s\n", output.Yellow, output.Reset, where, w.Message)
+	}
+	fmt.Println("")
+}
+
+// lintSchema reports tables with no primary key, columns with missing or
+// catch-all types, tables wider than maxColumns, and foreign-key columns no
+// index leads with. Warnings are ordered by table, then check.
+func lintSchema(snapshot *SchemaSnapshot, maxColumns int) []SchemaLintWarning {
+	var warnings []SchemaLintWarning
+	for _, name := range sortedTableNames(snapshot) {
+		table := snapshot.Tables[name]
+
+		if !tableHasPrimaryKey(table) {
+			warnings = append(warnings, SchemaLintWarning{
+				Table: name, Check: "no-primary-key",
+				Message: "no primary key",
+			})
+		}
+
+		for _, col := range table.Columns {
+			if reason := ambiguousColumnType(col.Type); reason != "" {
+				warnings = append(warnings, SchemaLintWarning{
+					Table: name, Column: col.Name, Check: "ambiguous-type",
+					Message: reason,
+				})
+			}
+		}
+
+		if len(table.Columns) > maxColumns {
+			warnings = append(warnings, SchemaLintWarning{
+				Table: name, Check: "wide-table",
+				Message: fmt.Sprintf("%d columns (more than %d)", len(table.Columns), maxColumns),
+			})
+		}
+
+		for _, fk := range table.ForeignKeys {
+			if !foreignKeyIndexed(table, fk) {
+				warnings = append(warnings, SchemaLintWarning{
+					Table: name, Column: fk.Column, Check: "unindexed-foreign-key",
+					Message: fmt.Sprintf("foreign key to %s.%s has no index", fk.ReferencedTable, fk.ReferencedColumn),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// tableHasPrimaryKey reports whether any column is part of the primary key
+func tableHasPrimaryKey(table *Table) bool {
+	for _, col := range table.Columns {
+		if col.PrimaryKey {
+			return true
+		}
+	}
+	return false
+}
+
+// ambiguousColumnType explains why a column type doesn't pin down what the
+// column holds, or returns "" if it does
+func ambiguousColumnType(colType string) string {
+	shape := parseColumnTypeShape(colType)
+	switch {
+	case shape.base == "":
+		return "no type declared"
+	case schemaCatchAllTypes[shape.base]:
+		return fmt.Sprintf("catch-all type %s", colType)
+	case shape.family == "decimal" && shape.size == 0:
+		return fmt.Sprintf("%s without precision", colType)
+	}
+	return ""
+}
+
+// foreignKeyIndexed reports whether lookups on a foreign-key column can use
+// an index: the column is unique, leads the primary key or an index, or
+// follows only columns of the same composite foreign key in one
+func foreignKeyIndexed(table *Table, fk ForeignKey) bool {
+	for _, col := range table.Columns {
+		if col.PrimaryKey {
+			if col.Name == fk.Column {
+				return true
+			}
+			break
+		}
+	}
+	for _, col := range table.Columns {
+		if col.Name == fk.Column && col.Unique {
+			return true
+		}
+	}
+
+	sameKey := make(map[string]bool)
+	for _, other := range table.ForeignKeys {
+		if other.ReferencedTable == fk.ReferencedTable {
+			sameKey[other.Column] = true
+		}
+	}
+	for _, index := range table.Indexes {
+		for _, col := range index.Columns {
+			if col == fk.Column {
+				return true
+			}
+			if !sameKey[col] {
+				break
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,1057 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const schemaCatalogFixture = `
+CREATE TABLE users (
+  id INTEGER PRIMARY KEY,
+  email VARCHAR(255) NOT NULL UNIQUE,
+  balance DECIMAL(10,2)
+);
+
+CREATE TABLE posts (
+  id INTEGER PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id),
+  title TEXT,
+  KEY idx_title (title)
+);
+
+CREATE TABLE memberships (
+  user_id INTEGER NOT NULL,
+  group_id INTEGER NOT NULL,
+  PRIMARY KEY (user_id, group_id),
+  CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id),
+  UNIQUE (group_id, user_id)
+);
+
+CREATE UNIQUE INDEX idx_users_email ON users (email);
+CREATE INDEX idx_posts_user ON posts (user_id);
+`
+
+func parseFixtureTables(t *testing.T, sql string) map[string]*Table {
+	t.Helper()
+	tables, err := parseSQLSchema(sql)
+	if err != nil {
+		t.Fatalf("parseSQLSchema() failed: %v", err)
+	}
+	byName := make(map[string]*Table)
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	return byName
+}
+
+func TestParseSQLSchemaForeignKeys(t *testing.T) {
+	tables := parseFixtureTables(t, schemaCatalogFixture)
+
+	posts := tables["posts"]
+	if posts == nil {
+		t.Fatal("posts table not parsed")
+	}
+	if len(posts.ForeignKeys) != 1 {
+		t.Fatalf("Expected 1 inline foreign key on posts, got %d", len(posts.ForeignKeys))
+	}
+	fk := posts.ForeignKeys[0]
+	if fk.Column != "user_id" || fk.ReferencedTable != "users" || fk.ReferencedColumn != "id" {
+		t.Errorf("Unexpected inline foreign key: %+v", fk)
+	}
+
+	memberships := tables["memberships"]
+	if len(memberships.ForeignKeys) != 1 || memberships.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("Expected table-level foreign key to users, got %+v", memberships.ForeignKeys)
+	}
+}
+
+func TestParseSQLSchemaCompositePrimaryKey(t *testing.T) {
+	tables := parseFixtureTables(t, schemaCatalogFixture)
+
+	memberships := tables["memberships"]
+	if len(memberships.Columns) != 2 {
+		t.Fatalf("Expected 2 columns (constraints skipped), got %d", len(memberships.Columns))
+	}
+	for _, col := range memberships.Columns {
+		if !col.PrimaryKey {
+			t.Errorf("Expected %s to be part of composite primary key", col.Name)
+		}
+	}
+}
+
+func TestParseSQLSchemaIndexes(t *testing.T) {
+	tables := parseFixtureTables(t, schemaCatalogFixture)
+
+	users := tables["users"]
+	if len(users.Columns) != 3 {
+		t.Errorf("Expected DECIMAL(10,2) to stay one column, got %d columns", len(users.Columns))
+	}
+	if len(users.Indexes) != 1 || !users.Indexes[0].Unique || users.Indexes[0].Name != "idx_users_email" {
+		t.Errorf("Expected unique index idx_users_email on users, got %+v", users.Indexes)
+	}
+
+	posts := tables["posts"]
+	if len(posts.Indexes) != 2 {
+		t.Fatalf("Expected inline KEY and CREATE INDEX on posts, got %+v", posts.Indexes)
+	}
+
+	memberships := tables["memberships"]
+	if len(memberships.Indexes) != 1 || !memberships.Indexes[0].Unique {
+		t.Errorf("Expected table-level UNIQUE constraint as index, got %+v", memberships.Indexes)
+	}
+}
+
+func TestParseSQLSchemaColumnNamedKey(t *testing.T) {
+	tables := parseFixtureTables(t, `
+CREATE TABLE settings (
+  id INTEGER PRIMARY KEY,
+  key VARCHAR(50) NOT NULL,
+  index INTEGER,
+  KEY idx_key (key),
+  INDEX (`+"`index`"+`)
+);
+`)
+
+	settings := tables["settings"]
+	var names []string
+	for _, col := range settings.Columns {
+		names = append(names, col.Name+" "+col.Type)
+	}
+	if want := []string{"id INTEGER", "key VARCHAR(50)", "index INTEGER"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Columns = %q, want %q", names, want)
+	}
+
+	want := []Index{
+		{Name: "idx_key", Columns: []string{"key"}},
+		{Columns: []string{"index"}},
+	}
+	if !reflect.DeepEqual(settings.Indexes, want) {
+		t.Errorf("Indexes = %+v, want %+v", settings.Indexes, want)
+	}
+}
+
+func TestCompareSnapshotsRelationships(t *testing.T) {
+	before := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY);
+CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, slug TEXT);
+CREATE INDEX idx_posts_slug ON posts (slug);
+`)}
+	after := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY);
+CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users(id), slug TEXT);
+`)}
+
+	diff := compareSnapshots(before, after)
+
+	if !containsSubstring(diff.Added, "fk: posts.user_id -> users.id") {
+		t.Errorf("Expected added foreign key in diff, got added=%v", diff.Added)
+	}
+	if !containsSubstring(diff.Removed, "idx_posts_slug") {
+		t.Errorf("Expected removed index in diff, got removed=%v", diff.Removed)
+	}
+}
+
+func TestCompareSnapshotsColumnRename(t *testing.T) {
+	before := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, fullname TEXT, email VARCHAR(255), age INTEGER);
+`)}
+	after := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, full_name TEXT, phone VARCHAR(255), age_years TEXT);
+`)}
+
+	diff := compareSnapshots(before, after)
+
+	if len(diff.Renamed) != 1 || diff.Renamed[0] != "users.fullname -> users.full_name (TEXT)" {
+		t.Errorf("Expected fullname -> full_name rename, got renamed=%v", diff.Renamed)
+	}
+	if containsSubstring(diff.Added, "full_name") || containsSubstring(diff.Removed, "users.fullname") {
+		t.Errorf("Renamed column also reported as add/remove: added=%v removed=%v", diff.Added, diff.Removed)
+	}
+
+	// Dissimilar names and changed types stay as add + remove
+	if !containsSubstring(diff.Added, "users.phone") || !containsSubstring(diff.Removed, "users.email") {
+		t.Errorf("Expected email/phone as add+remove, got added=%v removed=%v", diff.Added, diff.Removed)
+	}
+	if !containsSubstring(diff.Added, "users.age_years") || !containsSubstring(diff.Removed, "users.age") {
+		t.Errorf("Expected type-changing age column as add+remove, got added=%v removed=%v", diff.Added, diff.Removed)
+	}
+}
+
+func TestCompareSnapshotsRenameOrderStable(t *testing.T) {
+	before := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, fullname TEXT, emailaddr TEXT, homephone TEXT, streetname TEXT);
+CREATE TABLE orders (id INTEGER PRIMARY KEY, total INTEGER, placed_at TIMESTAMP);
+CREATE TABLE invoices (id INTEGER PRIMARY KEY, amount INTEGER, due_at TIMESTAMP);
+`)}
+	after := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, full_name TEXT, email_addr TEXT, home_phone TEXT, street_name TEXT);
+CREATE TABLE purchase_orders (id INTEGER PRIMARY KEY, total INTEGER, placed_at TIMESTAMP);
+CREATE TABLE bills (id INTEGER PRIMARY KEY, amount INTEGER, due_at TIMESTAMP);
+`)}
+
+	// Renames come from a map; they must still list in the same order every run
+	first := compareSnapshots(before, after)
+	if len(first.Renamed) != 6 {
+		t.Fatalf("Expected 4 column and 2 table renames, got %v", first.Renamed)
+	}
+	for run := 0; run < 20; run++ {
+		diff := compareSnapshots(before, after)
+		if !reflect.DeepEqual(diff.Renamed, first.Renamed) || !reflect.DeepEqual(diff.Added, first.Added) {
+			t.Fatalf("Diff order changed between runs:\n%v\n%v", first.Renamed, diff.Renamed)
+		}
+	}
+}
+
+func TestCompareSnapshotsTableRename(t *testing.T) {
+	before := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, email VARCHAR(255), created_at TIMESTAMP);
+CREATE TABLE logs (id INTEGER PRIMARY KEY, message TEXT);
+`)}
+	after := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE accounts (id INTEGER PRIMARY KEY, email VARCHAR(255), created_at TIMESTAMP, plan TEXT);
+CREATE TABLE audit_events (event_id INTEGER PRIMARY KEY, payload JSON);
+`)}
+
+	diff := compareSnapshots(before, after)
+
+	if !containsSubstring(diff.Renamed, "table: users -> accounts") {
+		t.Errorf("Expected users -> accounts table rename, got renamed=%v", diff.Renamed)
+	}
+	if !containsSubstring(diff.Added, "accounts.plan") {
+		t.Errorf("Expected new column on renamed table, got added=%v", diff.Added)
+	}
+	if !containsSubstring(diff.Added, "table: audit_events") || !containsSubstring(diff.Removed, "table: logs") {
+		t.Errorf("Unrelated tables should be add+remove, got added=%v removed=%v", diff.Added, diff.Removed)
+	}
+}
+
+func TestCompareSnapshotsDestructive(t *testing.T) {
+	before := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, email VARCHAR(255), bio VARCHAR(100), nickname TEXT, score INTEGER, legacy_flag BOOLEAN);
+CREATE TABLE sessions (token TEXT PRIMARY KEY, expires_at TIMESTAMP);
+CREATE INDEX idx_users_score ON users (score);
+`)}
+	after := &SchemaSnapshot{Tables: parseFixtureTables(t, `
+CREATE TABLE users (id BIGINT PRIMARY KEY, email VARCHAR(50), bio TEXT, nickname VARCHAR(40) NOT NULL, score SMALLINT, signup_source TEXT);
+`)}
+
+	diff := compareSnapshots(before, after)
+
+	want := map[string]string{
+		"users.email (VARCHAR(255) -> VARCHAR(50))":     "narrows VARCHAR(255) to VARCHAR(50)",
+		"users.nickname (TEXT -> VARCHAR(40) NOT NULL)": "becomes NOT NULL; existing NULLs would fail",
+		"users.score (INTEGER -> SMALLINT)":             "narrows INTEGER to SMALLINT",
+		"users.legacy_flag":                             "drops the column and its data",
+		"table: sessions":                               "drops the table and its data",
+	}
+	if !reflect.DeepEqual(diff.Destructive, want) {
+		t.Errorf("Destructive = %v, want %v", diff.Destructive, want)
+	}
+
+	// Widening and index drops are safe
+	for _, safe := range []string{"users.id (INTEGER -> BIGINT)", "users.bio (VARCHAR(100) -> TEXT)"} {
+		if !containsSubstring(diff.Modified, safe) {
+			t.Errorf("Expected %q in modified, got %v", safe, diff.Modified)
+		}
+	}
+	if !containsSubstring(diff.Removed, "idx_users_score") {
+		t.Errorf("Expected removed index, got %v", diff.Removed)
+	}
+
+	if err := destructiveDriftError(diff, true); err == nil {
+		t.Error("Expected --fail-on-destructive to fail")
+	}
+	if err := destructiveDriftError(diff, false); err != nil {
+		t.Errorf("Expected no error without --fail-on-destructive, got %v", err)
+	}
+}
+
+func TestColumnChangeRisk(t *testing.T) {
+	tests := []struct {
+		from, to    string
+		destructive bool
+	}{
+		{"varchar(50)", "varchar(255)", false},
+		{"decimal(10,2)", "decimal(12,2)", false},
+		{"decimal(10,4)", "decimal(10,2)", true},
+		{"decimal(10,2)", "decimal(10,4)", true},
+		{"int", "bigint unsigned", false},
+		{"bigint", "int", true},
+		{"integer", "double precision", false},
+		{"float", "integer", true},
+		{"text", "integer", true},
+		{"timestamp", "date", true},
+		{"json", "text", false},
+		{"String", "String(20)", true},
+	}
+	for _, tt := range tests {
+		_, destructive := columnChangeRisk(Column{Name: "c", Type: tt.from}, Column{Name: "c", Type: tt.to})
+		if destructive != tt.destructive {
+			t.Errorf("%s -> %s destructive = %v, want %v", tt.from, tt.to, destructive, tt.destructive)
+		}
+	}
+}
+
+func TestParseSQLSchemaQualifiedNames(t *testing.T) {
+	tables, err := parseSQLSchema(`
+CREATE TABLE public.users (id SERIAL PRIMARY KEY, email TEXT);
+CREATE TABLE IF NOT EXISTS "billing"."invoices" (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER REFERENCES public.users(id)
+);
+CREATE TABLE ` + "`shop`.`orders`" + ` (
+  id INT PRIMARY KEY,
+  invoice_id INT,
+  FOREIGN KEY (invoice_id) REFERENCES ` + "`billing`.`invoices`" + ` (id)
+);
+CREATE TABLE ` + "`legacy`" + ` (id INT);
+CREATE TABLE "odd.name" (id INT);
+CREATE INDEX idx_orders_invoice ON shop.orders (invoice_id);
+`)
+	if err != nil {
+		t.Fatalf("parseSQLSchema() failed: %v", err)
+	}
+
+	want := map[string]string{
+		"users":    "public",
+		"invoices": "billing",
+		"orders":   "shop",
+		"legacy":   "",
+		"odd.name": "",
+	}
+	byName := make(map[string]*Table)
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	if len(byName) != len(want) {
+		t.Fatalf("Expected %d tables, got %d: %v", len(want), len(byName), byName)
+	}
+	for name, schema := range want {
+		table, ok := byName[name]
+		if !ok {
+			t.Errorf("Table %q not parsed", name)
+			continue
+		}
+		if table.Schema != schema {
+			t.Errorf("Table %q: expected schema %q, got %q", name, schema, table.Schema)
+		}
+	}
+
+	if fks := byName["invoices"].ForeignKeys; len(fks) != 1 || fks[0].ReferencedTable != "users" {
+		t.Errorf("Expected schema-qualified inline reference to users, got %+v", fks)
+	}
+	if fks := byName["orders"].ForeignKeys; len(fks) != 1 || fks[0].ReferencedTable != "invoices" {
+		t.Errorf("Expected backtick-qualified reference to invoices, got %+v", fks)
+	}
+	if idx := byName["orders"].Indexes; len(idx) != 1 || idx[0].Name != "idx_orders_invoice" {
+		t.Errorf("Expected CREATE INDEX on shop.orders to attach to orders, got %+v", idx)
+	}
+}
+
+func TestSplitQualifiedName(t *testing.T) {
+	tests := []struct {
+		input, schema, table string
+	}{
+		{"users", "", "users"},
+		{"public.users", "public", "users"},
+		{"`db`.`tbl`", "db", "tbl"},
+		{`"my.schema"."users"`, "my.schema", "users"},
+		{"[dbo].[Users]", "dbo", "Users"},
+		{"catalog.sales.orders", "sales", "orders"},
+	}
+
+	for _, tt := range tests {
+		schema, table := splitQualifiedName(tt.input)
+		if schema != tt.schema || table != tt.table {
+			t.Errorf("splitQualifiedName(%q) = (%q, %q), want (%q, %q)", tt.input, schema, table, tt.schema, tt.table)
+		}
+	}
+}
+
+// containsSubstring reports whether any item contains substr
+func containsSubstring(items []string, substr string) bool {
+	for _, item := range items {
+		if strings.Contains(item, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLoadSnapshotRef(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	older := &SchemaSnapshot{
+		Project:      "billing",
+		SnapshotTime: time.Date(2024, 1, 15, 9, 30, 0, 0, time.Local),
+		Tables:       parseFixtureTables(t, "CREATE TABLE users (id INTEGER PRIMARY KEY);"),
+	}
+	newer := &SchemaSnapshot{
+		Project:      "billing",
+		SnapshotTime: time.Date(2024, 2, 1, 12, 0, 0, 0, time.Local),
+		Tables:       parseFixtureTables(t, "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT);"),
+	}
+	for _, snapshot := range []*SchemaSnapshot{older, newer} {
+		if err := saveSnapshot(snapshot); err != nil {
+			t.Fatalf("saveSnapshot() failed: %v", err)
+		}
+	}
+
+	for _, ref := range []string{"billing@2024-01-15-093000", "billing@2024-01-15 09:30:00"} {
+		snapshot, err := loadSnapshotRef(ref)
+		if err != nil {
+			t.Fatalf("loadSnapshotRef(%q) failed: %v", ref, err)
+		}
+		if !snapshot.SnapshotTime.Equal(older.SnapshotTime) {
+			t.Errorf("loadSnapshotRef(%q) loaded snapshot from %s", ref, snapshot.SnapshotTime)
+		}
+	}
+
+	latest, err := loadSnapshotRef("billing")
+	if err != nil {
+		t.Fatalf("loadSnapshotRef() for latest failed: %v", err)
+	}
+	if !latest.SnapshotTime.Equal(newer.SnapshotTime) {
+		t.Errorf("Expected latest snapshot, got %s", latest.SnapshotTime)
+	}
+
+	for _, ref := range []string{"billing@2023-01-01-000000", "billing@yesterday", "missing", "@2024-01-15-093000"} {
+		if _, err := loadSnapshotRef(ref); err == nil {
+			t.Errorf("loadSnapshotRef(%q) should fail", ref)
+		}
+	}
+}
+
+func TestFilterSnapshotsToTableAcrossProjects(t *testing.T) {
+	billing := &SchemaSnapshot{Project: "billing", Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT);
+CREATE TABLE invoices (id INTEGER PRIMARY KEY);
+`)}
+	shop := &SchemaSnapshot{Project: "shop", Tables: parseFixtureTables(t, `
+CREATE TABLE Users (id INTEGER PRIMARY KEY, email TEXT, name TEXT);
+CREATE TABLE carts (id INTEGER PRIMARY KEY);
+`)}
+
+	from, to, err := filterSnapshotsToTable(billing, shop, "users")
+	if err != nil {
+		t.Fatalf("filterSnapshotsToTable() failed: %v", err)
+	}
+
+	diff := compareSnapshots(from, to)
+	if len(diff.Added) != 1 || !containsSubstring(diff.Added, "name") {
+		t.Errorf("Expected only the added name column, got added=%v", diff.Added)
+	}
+	if len(diff.Removed) != 0 || len(diff.Renamed) != 0 {
+		t.Errorf("Expected no removed or renamed items, got removed=%v renamed=%v", diff.Removed, diff.Renamed)
+	}
+
+	if _, _, err := filterSnapshotsToTable(billing, shop, "orders"); err == nil {
+		t.Error("Expected an error for a table missing from both snapshots")
+	}
+}
+
+func TestLoadLatestSnapshotRecoversFromTruncatedLatest(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	older := &SchemaSnapshot{
+		Project:      "billing",
+		SnapshotTime: time.Date(2024, 1, 15, 9, 30, 0, 0, time.Local),
+		Tables:       parseFixtureTables(t, "CREATE TABLE users (id INTEGER PRIMARY KEY);"),
+	}
+	newer := &SchemaSnapshot{
+		Project:      "billing",
+		SnapshotTime: time.Date(2024, 2, 1, 12, 0, 0, 0, time.Local),
+		Tables:       parseFixtureTables(t, "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT);"),
+	}
+	for _, snapshot := range []*SchemaSnapshot{older, newer} {
+		if err := saveSnapshot(snapshot); err != nil {
+			t.Fatalf("saveSnapshot() failed: %v", err)
+		}
+	}
+
+	// Simulate a save interrupted halfway through writing latest
+	latestFile := filepath.Join(getCatalogDir(), "billing", "schema-latest.json")
+	data, err := os.ReadFile(latestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(latestFile, data[:len(data)/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := loadLatestSnapshot("billing")
+	if err != nil {
+		t.Fatalf("loadLatestSnapshot() failed on truncated latest: %v", err)
+	}
+	if !latest.SnapshotTime.Equal(newer.SnapshotTime) {
+		t.Errorf("Expected newest timestamped snapshot, got %s", latest.SnapshotTime)
+	}
+	if cols := len(latest.Tables["users"].Columns); cols != 2 {
+		t.Errorf("Expected recovered snapshot to have 2 user columns, got %d", cols)
+	}
+
+	if _, err := loadLatestSnapshot("missing"); err == nil {
+		t.Error("loadLatestSnapshot() should fail for a project with no snapshots")
+	}
+}
+
+func TestParseSQLSchemaComments(t *testing.T) {
+	tables := parseFixtureTables(t, `
+-- Registered accounts
+CREATE TABLE users (
+  id INTEGER PRIMARY KEY, -- surrogate key
+  -- user's login email
+  email VARCHAR(255) NOT NULL,
+  nickname TEXT /* shown in the UI; optional */,
+  status VARCHAR(16) DEFAULT '--' COMMENT 'account state, e.g. ''active'''
+);
+
+CREATE TABLE posts ( -- blog posts
+  id INTEGER PRIMARY KEY,
+  body TEXT
+);
+
+COMMENT ON COLUMN posts.body IS 'Markdown source';
+`)
+
+	users := tables["users"]
+	if users == nil || len(users.Columns) != 4 {
+		t.Fatalf("Expected users with 4 columns, got %+v", users)
+	}
+	if users.Comment != "Registered accounts" {
+		t.Errorf("users comment = %q", users.Comment)
+	}
+
+	want := map[string]string{
+		"id":       "surrogate key",
+		"email":    "user's login email",
+		"nickname": "shown in the UI; optional",
+		"status":   "account state, e.g. 'active'",
+	}
+	for _, col := range users.Columns {
+		if col.Comment != want[col.Name] {
+			t.Errorf("column %s comment = %q, want %q", col.Name, col.Comment, want[col.Name])
+		}
+	}
+	if users.Columns[3].Default != "'--'" {
+		t.Errorf("Quoted -- should not start a comment, default = %q", users.Columns[3].Default)
+	}
+
+	posts := tables["posts"]
+	if posts.Comment != "blog posts" {
+		t.Errorf("posts comment = %q", posts.Comment)
+	}
+	if posts.Columns[1].Comment != "Markdown source" {
+		t.Errorf("COMMENT ON COLUMN not applied: %q", posts.Columns[1].Comment)
+	}
+}
+
+func TestParsePrismaSchema(t *testing.T) {
+	content := `datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL") // not a model
+}
+
+/// Registered accounts
+model User {
+  /// Primary key
+  id        Int      @id @default(autoincrement())
+  email     String   @unique /// login email
+  homepage  String?  @default("https://example.com") // plain comment
+  createdAt DateTime @default(now()) @map("created_at")
+  role      Role     @default(USER)
+  tags      String[]
+  posts     Post[]
+
+  @@map("users")
+}
+
+model Post {
+  id       Int  @id
+  authorId Int  @map("author_id")
+  author   User @relation(fields: [authorId], references: [id])
+
+  @@index([authorId])
+}
+
+model Membership {
+  userId  Int
+  groupId Int
+
+  @@id([userId, groupId])
+}
+
+enum Role {
+  USER
+  ADMIN
+}
+`
+
+	tables, err := parsePrismaSchema(content)
+	if err != nil {
+		t.Fatalf("parsePrismaSchema() failed: %v", err)
+	}
+	byName := make(map[string]*Table)
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	if len(tables) != 3 || byName["users"] == nil || byName["Post"] == nil {
+		t.Fatalf("Expected users, Post and Membership tables, got %+v", tables)
+	}
+
+	users := byName["users"]
+	if users.Comment != "Registered accounts" {
+		t.Errorf("users comment = %q", users.Comment)
+	}
+	wantColumns := []Column{
+		{Name: "id", Type: "Int", PrimaryKey: true, Default: "autoincrement()", Comment: "Primary key"},
+		{Name: "email", Type: "String", Unique: true, Comment: "login email"},
+		{Name: "homepage", Type: "String", Nullable: true, Default: `"https://example.com"`},
+		{Name: "created_at", Type: "DateTime", Default: "now()"},
+		{Name: "role", Type: "Role", Default: "USER"},
+		{Name: "tags", Type: "String[]"},
+	}
+	if len(users.Columns) != len(wantColumns) {
+		t.Fatalf("Expected %d user columns, got %+v", len(wantColumns), users.Columns)
+	}
+	for i, want := range wantColumns {
+		if users.Columns[i] != want {
+			t.Errorf("column %d = %+v, want %+v", i, users.Columns[i], want)
+		}
+	}
+
+	post := byName["Post"]
+	if len(post.ForeignKeys) != 1 || post.ForeignKeys[0] != (ForeignKey{Column: "author_id", ReferencedTable: "users", ReferencedColumn: "id"}) {
+		t.Errorf("Unexpected Post foreign keys: %+v", post.ForeignKeys)
+	}
+	if len(post.Indexes) != 1 || post.Indexes[0].Columns[0] != "author_id" {
+		t.Errorf("Unexpected Post indexes: %+v", post.Indexes)
+	}
+
+	for _, col := range byName["Membership"].Columns {
+		if !col.PrimaryKey {
+			t.Errorf("Expected %s in the composite primary key", col.Name)
+		}
+	}
+}
+
+func TestComputeCatalogStats(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	snapshots := []*SchemaSnapshot{
+		{Project: "billing", SnapshotTime: now.AddDate(0, 0, -2), Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT);
+CREATE TABLE invoices (id INTEGER PRIMARY KEY, total DECIMAL(10,2), user_id INTEGER);
+`)},
+		{Project: "shop", SnapshotTime: now.AddDate(0, 0, -45), Tables: parseFixtureTables(t, `
+CREATE TABLE Users (id INTEGER PRIMARY KEY);
+CREATE TABLE sessions (id INTEGER PRIMARY KEY, token TEXT);
+`)},
+		{Project: "auth", SnapshotTime: now.AddDate(0, 0, -90), Tables: parseFixtureTables(t, `
+CREATE TABLE users (id INTEGER PRIMARY KEY, password TEXT);
+CREATE TABLE sessions (id INTEGER PRIMARY KEY);
+`)},
+	}
+
+	stats := computeCatalogStats(snapshots, now, 30, 0)
+	if stats.Projects != 3 || stats.Tables != 6 || stats.Columns != 11 {
+		t.Errorf("Expected 3 projects, 6 tables, 11 columns, got %+v", stats)
+	}
+	if fmt.Sprintf("%.2f", stats.AvgColumns) != "1.83" {
+		t.Errorf("AvgColumns = %.2f, want 1.83", stats.AvgColumns)
+	}
+
+	if len(stats.CommonTables) != 2 {
+		t.Fatalf("Expected users and sessions to be shared, got %+v", stats.CommonTables)
+	}
+	if users := stats.CommonTables[0]; users.Name != "users" || strings.Join(users.Projects, ",") != "auth,billing,shop" {
+		t.Errorf("Unexpected top table: %+v", users)
+	}
+	if sessions := stats.CommonTables[1]; sessions.Name != "sessions" || len(sessions.Projects) != 2 {
+		t.Errorf("Unexpected second table: %+v", sessions)
+	}
+
+	if len(stats.StaleProjects) != 2 || stats.StaleProjects[0].Project != "auth" || stats.StaleProjects[0].DaysAgo != 90 {
+		t.Errorf("Expected auth then shop as stale, got %+v", stats.StaleProjects)
+	}
+
+	if top := computeCatalogStats(snapshots, now, 30, 1); len(top.CommonTables) != 1 {
+		t.Errorf("Expected --top 1 to keep one table, got %+v", top.CommonTables)
+	}
+}
+
+const railsSchemaFixture = `# This file is auto-generated from the current state of the database.
+ActiveRecord::Schema[7.1].define(version: 2024_05_01_120000) do
+  enable_extension "plpgsql"
+
+  create_table "users", force: :cascade, comment: "Registered accounts" do |t|
+    t.string "email", null: false
+    t.string "name", limit: 80
+    t.decimal "balance", precision: 10, scale: 2, default: "0.0"
+    t.datetime "created_at", default: -> { "CURRENT_TIMESTAMP" }, null: false
+    t.index ["email"], name: "index_users_on_email", unique: true
+  end
+
+  create_table "posts", id: :uuid, force: :cascade do |t|
+    t.bigint "author_id", null: false
+    t.text "body", comment: "Markdown, rendered on save"
+    t.string "tags", array: true
+  end
+
+  create_table "memberships", id: false, force: :cascade do |t|
+    t.bigint "user_id"
+    t.bigint "group_id"
+  end
+
+  add_foreign_key "posts", "users", column: "author_id"
+  add_foreign_key "memberships", "users"
+  add_index "memberships", ["user_id", "group_id"], name: "index_memberships", unique: true
+end
+`
+
+const djangoModelsFixture = `from django.db import models
+
+
+class TimeStamped(models.Model):
+    created = models.DateTimeField(auto_now_add=True)
+
+    class Meta:
+        abstract = True
+
+
+class Author(models.Model):
+    name = models.CharField(max_length=100, unique=True)  # display name
+    bio = models.TextField(null=True, blank=True, help_text="Shown on the profile")
+    rating = models.DecimalField(max_digits=3, decimal_places=1, default=0)
+
+
+class Post(models.Model):
+    """A blog post."""
+
+    id = models.UUIDField(primary_key=True)
+    author = models.ForeignKey(
+        Author,
+        on_delete=models.CASCADE,
+        related_name="posts",
+    )
+    editor = models.ForeignKey("accounts.User", null=True, on_delete=models.SET_NULL)
+    parent = models.ForeignKey("self", null=True, on_delete=models.CASCADE, db_column="parent_post")
+    tags = models.ManyToManyField("Tag")
+    status = models.CharField(max_length=10, default="draft")
+
+    class Meta:
+        db_table = "posts"
+        indexes = [models.Index(fields=["status"], name="post_status_idx")]
+        unique_together = [("author", "status")]
+
+    def __str__(self):
+        return self.status
+`
+
+func TestParseRailsSchema(t *testing.T) {
+	tables, err := parseRailsSchema(railsSchemaFixture)
+	if err != nil {
+		t.Fatalf("parseRailsSchema() failed: %v", err)
+	}
+	byName := make(map[string]*Table)
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	if len(tables) != 3 || byName["users"] == nil || byName["posts"] == nil || byName["memberships"] == nil {
+		t.Fatalf("Expected users, posts and memberships, got %+v", tables)
+	}
+
+	users := byName["users"]
+	if users.Comment != "Registered accounts" {
+		t.Errorf("users comment = %q", users.Comment)
+	}
+	wantColumns := []Column{
+		{Name: "id", Type: "bigint", PrimaryKey: true},
+		{Name: "email", Type: "string"},
+		{Name: "name", Type: "string(80)", Nullable: true},
+		{Name: "balance", Type: "decimal(10,2)", Nullable: true, Default: `"0.0"`},
+		{Name: "created_at", Type: "datetime", Default: "CURRENT_TIMESTAMP"},
+	}
+	if len(users.Columns) != len(wantColumns) {
+		t.Fatalf("Expected %d user columns, got %+v", len(wantColumns), users.Columns)
+	}
+	for i, want := range wantColumns {
+		if users.Columns[i] != want {
+			t.Errorf("column %d = %+v, want %+v", i, users.Columns[i], want)
+		}
+	}
+	if len(users.Indexes) != 1 || users.Indexes[0].Name != "index_users_on_email" || !users.Indexes[0].Unique {
+		t.Errorf("Unexpected users indexes: %+v", users.Indexes)
+	}
+
+	posts := byName["posts"]
+	if posts.Columns[0] != (Column{Name: "id", Type: "uuid", PrimaryKey: true}) {
+		t.Errorf("Expected a uuid primary key, got %+v", posts.Columns[0])
+	}
+	if posts.Columns[2].Comment != "Markdown, rendered on save" || posts.Columns[3].Type != "string[]" {
+		t.Errorf("Unexpected posts columns: %+v", posts.Columns)
+	}
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0] != (ForeignKey{Column: "author_id", ReferencedTable: "users", ReferencedColumn: "id"}) {
+		t.Errorf("Unexpected posts foreign keys: %+v", posts.ForeignKeys)
+	}
+
+	memberships := byName["memberships"]
+	if len(memberships.Columns) != 2 || memberships.Columns[0].PrimaryKey {
+		t.Errorf("Expected no implicit id with id: false, got %+v", memberships.Columns)
+	}
+	if len(memberships.ForeignKeys) != 1 || memberships.ForeignKeys[0].Column != "user_id" {
+		t.Errorf("Expected user_id derived from the table name, got %+v", memberships.ForeignKeys)
+	}
+	if len(memberships.Indexes) != 1 || len(memberships.Indexes[0].Columns) != 2 {
+		t.Errorf("Unexpected memberships indexes: %+v", memberships.Indexes)
+	}
+}
+
+func TestParseDjangoModels(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "blog")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "models.py")
+	if err := os.WriteFile(path, []byte(djangoModelsFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err := parseSchemaFile(path)
+	if err != nil {
+		t.Fatalf("parseSchemaFile() failed: %v", err)
+	}
+	byName := make(map[string]*Table)
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	if len(tables) != 2 || byName["blog_author"] == nil || byName["posts"] == nil {
+		t.Fatalf("Expected blog_author and posts (abstract model skipped), got %+v", tables)
+	}
+
+	wantAuthor := []Column{
+		{Name: "id", Type: "BigAutoField", PrimaryKey: true},
+		{Name: "name", Type: "CharField(100)", Unique: true},
+		{Name: "bio", Type: "TextField", Nullable: true, Comment: "Shown on the profile"},
+		{Name: "rating", Type: "DecimalField(3,1)", Default: "0"},
+	}
+	author := byName["blog_author"]
+	if len(author.Columns) != len(wantAuthor) {
+		t.Fatalf("Expected %d author columns, got %+v", len(wantAuthor), author.Columns)
+	}
+	for i, want := range wantAuthor {
+		if author.Columns[i] != want {
+			t.Errorf("column %d = %+v, want %+v", i, author.Columns[i], want)
+		}
+	}
+
+	posts := byName["posts"]
+	var names []string
+	for _, col := range posts.Columns {
+		names = append(names, col.Name)
+	}
+	if got := strings.Join(names, ","); got != "id,author_id,editor_id,parent_post,status" {
+		t.Errorf("posts columns = %s", got)
+	}
+	wantFKs := []ForeignKey{
+		{Column: "author_id", ReferencedTable: "blog_author", ReferencedColumn: "id"},
+		{Column: "editor_id", ReferencedTable: "accounts_user", ReferencedColumn: "id"},
+		{Column: "parent_post", ReferencedTable: "posts", ReferencedColumn: "id"},
+	}
+	if !reflect.DeepEqual(posts.ForeignKeys, wantFKs) {
+		t.Errorf("posts foreign keys = %+v, want %+v", posts.ForeignKeys, wantFKs)
+	}
+	if len(posts.Indexes) != 2 || posts.Indexes[0].Name != "post_status_idx" || !posts.Indexes[1].Unique ||
+		strings.Join(posts.Indexes[1].Columns, ",") != "author_id,status" {
+		t.Errorf("Unexpected posts indexes: %+v", posts.Indexes)
+	}
+}
+
+func TestScanSchemaRootsMergesRoots(t *testing.T) {
+	base := t.TempDir()
+	migrations := filepath.Join(base, "shop-migrations")
+	app := filepath.Join(base, "shop-app")
+	writeFixture(t, migrations, map[string]string{
+		"001_users.sql":  "CREATE TABLE users (id INTEGER PRIMARY KEY);",
+		"002_orders.sql": "CREATE TABLE orders (id INTEGER PRIMARY KEY);",
+	})
+	writeFixture(t, app, map[string]string{
+		"db/schema.sql": "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT);",
+	})
+
+	roots, err := resolveSchemaRoots([]string{migrations, app, migrations})
+	if err != nil {
+		t.Fatalf("resolveSchemaRoots() failed: %v", err)
+	}
+	if len(roots) != 2 {
+		t.Fatalf("Expected duplicate roots to collapse, got %v", roots)
+	}
+
+	snapshot := scanSchemaRoots(schemaProjectName("", roots), roots, false)
+	if snapshot.Project != "shop-migrations" {
+		t.Errorf("Expected project named after the first root, got %s", snapshot.Project)
+	}
+	if len(snapshot.SourceFiles) != 3 || len(snapshot.SourceRoots) != 2 {
+		t.Errorf("Expected 3 files from 2 roots, got %v from %v", snapshot.SourceFiles, snapshot.SourceRoots)
+	}
+	if len(snapshot.Tables) != 2 || len(snapshot.Tables["users"].Columns) != 2 {
+		t.Errorf("Expected users from the later root to win, got %+v", snapshot.Tables["users"])
+	}
+	if got := schemaDisplayPath(roots, snapshot.SourceFiles[2]); got != filepath.Join("shop-app", "db", "schema.sql") {
+		t.Errorf("Unexpected display path %s", got)
+	}
+	if got := schemaProjectName("shop", roots); got != "shop" {
+		t.Errorf("Expected --project to override the name, got %s", got)
+	}
+
+	// A root nested inside another doesn't scan its files twice
+	nested := scanSchemaRoots("shop", []string{base, migrations}, false)
+	if len(nested.SourceFiles) != 3 {
+		t.Errorf("Expected nested roots to yield 3 files, got %v", nested.SourceFiles)
+	}
+}
+
+func TestScanSchemaRootsKeepsSchemasApart(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"schema.sql": `CREATE TABLE public.users (id INTEGER PRIMARY KEY, email TEXT);
+CREATE TABLE audit.users (id INTEGER PRIMARY KEY, changed_at TIMESTAMP, actor TEXT);`,
+	})
+
+	snapshot := scanSchemaRoots("shop", []string{dir}, false)
+	if got := sortedTableNames(snapshot); !reflect.DeepEqual(got, []string{"audit.users", "public.users"}) {
+		t.Fatalf("Expected both users tables keyed by schema, got %v", got)
+	}
+	if table := findSchemaTable(snapshot, "audit.users"); table == nil || len(table.Columns) != 3 {
+		t.Errorf("Expected audit.users by key, got %+v", table)
+	}
+	if table := findSchemaTable(snapshot, "users"); table == nil || table.Schema != "audit" {
+		t.Errorf("Expected a bare name to find the first users table, got %+v", table)
+	}
+
+	// Snapshots saved with bare keys are re-keyed on load
+	legacy := &SchemaSnapshot{Tables: map[string]*Table{"users": snapshot.Tables["public.users"]}}
+	keySchemaTables(legacy)
+	if _, ok := legacy.Tables["public.users"]; !ok || len(legacy.Tables) != 1 {
+		t.Errorf("Expected legacy snapshot re-keyed to public.users, got %v", sortedTableNames(legacy))
+	}
+}
+
+// twoSchemaSnapshot has a users table in both public and audit, with foreign
+// keys resolved through the referencing table's schema and an explicit one
+func twoSchemaSnapshot(t *testing.T) *SchemaSnapshot {
+	t.Helper()
+	tables, err := parseSQLSchema(`
+CREATE TABLE public.users (id INTEGER PRIMARY KEY, email VARCHAR(255) NOT NULL UNIQUE);
+CREATE TABLE audit.users (id INTEGER PRIMARY KEY, actor TEXT);
+CREATE TABLE audit.events (
+  id INTEGER PRIMARY KEY,
+  user_id INTEGER REFERENCES users(id),
+  owner_id INTEGER REFERENCES public.users(id),
+  kind TEXT DEFAULT 'login',
+  KEY idx_user (user_id)
+);
+`)
+	if err != nil {
+		t.Fatalf("parseSQLSchema() failed: %v", err)
+	}
+	snapshot := &SchemaSnapshot{Project: "shop", Tables: make(map[string]*Table)}
+	for _, table := range tables {
+		snapshot.Tables[schemaTableKey(table)] = table
+	}
+	return snapshot
+}
+
+func TestRenderSchemaDBML(t *testing.T) {
+	dbml := renderSchemaDBML(twoSchemaSnapshot(t))
+
+	for _, want := range []string{
+		"Table audit.events {\n  id INTEGER [pk]\n",
+		"  kind TEXT [default: `'login'`]\n",
+		"  indexes {\n    (user_id) [name: 'idx_user']\n  }\n",
+		"Table audit.users {\n",
+		"Table public.users {\n  id INTEGER [pk]\n  email VARCHAR(255) [unique, not null]\n}\n",
+		"Ref: audit.events.user_id > audit.users.id\n",
+		"Ref: audit.events.owner_id > public.users.id\n",
+	} {
+		if !strings.Contains(dbml, want) {
+			t.Errorf("DBML missing %q:\n%s", want, dbml)
+		}
+	}
+	if strings.Contains(dbml, "Table users {") {
+		t.Errorf("Expected only schema-qualified tables:\n%s", dbml)
+	}
+}
+
+func TestRenderSchemaMermaid(t *testing.T) {
+	mermaid := renderSchemaMermaid(twoSchemaSnapshot(t))
+
+	for _, want := range []string{
+		"erDiagram\n",
+		"    audit_events {\n        INTEGER id PK\n        INTEGER user_id FK\n",
+		"    audit_users {\n",
+		"        VARCHAR(255) email UK\n",
+		"    audit_users ||--o{ audit_events : \"user_id\"\n",
+		"    public_users ||--o{ audit_events : \"owner_id\"\n",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid missing %q:\n%s", want, mermaid)
+		}
+	}
+
+	// Keys that sanitize alike still get separate entities
+	ids := mermaidEntityIDs([]string{"audit.users", "audit_users"})
+	if ids["audit.users"] == ids["audit_users"] {
+		t.Errorf("Expected distinct entity IDs, got %v", ids)
+	}
+}
+
+func TestLintSchema(t *testing.T) {
+	tables := parseFixtureTables(t, schemaCatalogFixture+`
+CREATE TABLE audit_log (
+  user_id INTEGER REFERENCES users(id),
+  payload sql_variant,
+  amount NUMERIC,
+  note TEXT
+);
+
+CREATE TABLE order_items (
+  order_id INTEGER NOT NULL,
+  line INTEGER NOT NULL,
+  tenant_id INTEGER NOT NULL,
+  product_id INTEGER NOT NULL,
+  PRIMARY KEY (order_id, line),
+  FOREIGN KEY (order_id) REFERENCES orders (id),
+  FOREIGN KEY (tenant_id, product_id) REFERENCES products (tenant_id, id)
+);
+CREATE INDEX idx_items_product ON order_items (tenant_id, product_id);
+`)
+	snapshot := &SchemaSnapshot{Tables: tables}
+
+	var got []string
+	for _, w := range lintSchema(snapshot, 3) {
+		got = append(got, w.Table+"."+w.Column+" "+w.Check)
+	}
+	want := []string{
+		"audit_log. no-primary-key",
+		"audit_log.payload ambiguous-type",
+		"audit_log.amount ambiguous-type",
+		"audit_log. wide-table",
+		"audit_log.user_id unindexed-foreign-key",
+		// order_items: order_id leads the primary key, and the composite
+		// product key leads idx_items_product
+		"order_items. wide-table",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lintSchema() =\n  %v\nwant\n  %v", got, want)
+	}
+
+	// posts.user_id has idx_posts_user, memberships.user_id leads the PK
+	if warnings := lintSchema(&SchemaSnapshot{Tables: parseFixtureTables(t, schemaCatalogFixture)}, schemaLintMaxColumns); len(warnings) != 0 {
+		t.Errorf("Expected the base fixture to lint clean, got %+v", warnings)
+	}
+}
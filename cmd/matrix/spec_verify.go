@@ -7,9 +7,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // RequirementLevel represents MUST/SHOULD/MAY
@@ -41,12 +46,14 @@ type Spec struct {
 	Requirements []Requirement `json:"requirements"`
 }
 
-// Requirement represents a single spec requirement
+// Requirement represents a single spec requirement. FileScope optionally
+// limits its patterns to files matching a glob, e.g. "server/**/*.go".
 type Requirement struct {
 	ID           string   `json:"id"`
 	Section      string   `json:"section"`
 	Level        string   `json:"level"`
 	Text         string   `json:"text"`
+	FileScope    string   `json:"file_scope,omitempty"`
 	Verification struct {
 		Type     string   `json:"type"`
 		Patterns []string `json:"patterns"`
@@ -65,6 +72,8 @@ type Match struct {
 	FilePath string
 	Line     int
 	Context  string
+	Before   []string // up to --context lines preceding the match, trimmed like Context
+	After    []string // up to --context lines following the match, trimmed like Context
 }
 
 // SpecVerifyConfig holds command configuration
@@ -73,11 +82,29 @@ type SpecVerifyConfig struct {
 	SpecName   string
 	TargetPath string
 	OutputJSON bool
+	Include    []string
+	Record     bool
+	Context    int // lines of surrounding code to capture per match
+}
+
+// ComplianceRun is a recorded summary of one spec verification
+type ComplianceRun struct {
+	Spec            string    `json:"spec"`
+	Target          string    `json:"target"`
+	RunTime         time.Time `json:"run_time"`
+	MustSatisfied   int       `json:"must_satisfied"`
+	MustTotal       int       `json:"must_total"`
+	ShouldSatisfied int       `json:"should_satisfied"`
+	ShouldTotal     int       `json:"should_total"`
+	Missing         []string  `json:"missing"` // IDs of MUST/SHOULD requirements not satisfied
 }
 
 // runSpecVerify implements the spec-verify command
 func runSpecVerify() error {
-	config := parseSVFlags()
+	config, err := parseSVFlags()
+	if err != nil {
+		return err
+	}
 
 	switch config.Subcommand {
 	case "list":
@@ -86,6 +113,8 @@ func runSpecVerify() error {
 		return verifySpec(config)
 	case "report":
 		return reportSpec(config)
+	case "trend":
+		return showComplianceTrend(config)
 	default:
 		printSVUsage()
 		return nil
@@ -93,7 +122,7 @@ func runSpecVerify() error {
 }
 
 // parseSVFlags parses command-line flags for spec-verify
-func parseSVFlags() SpecVerifyConfig {
+func parseSVFlags() (SpecVerifyConfig, error) {
 	config := SpecVerifyConfig{
 		Subcommand: "",
 		SpecName:   "",
@@ -104,7 +133,7 @@ func parseSVFlags() SpecVerifyConfig {
 	args := os.Args[2:] // Skip "matrix" and "spec-verify"
 
 	if len(args) == 0 {
-		return config
+		return config, nil
 	}
 
 	// First arg is subcommand
@@ -117,11 +146,27 @@ func parseSVFlags() SpecVerifyConfig {
 		switch {
 		case arg == "--json":
 			config.OutputJSON = true
+		case arg == "--record":
+			config.Record = true
 		case arg == "--format" && i+1 < len(args):
 			i++
 			if args[i] == "json" {
 				config.OutputJSON = true
 			}
+		case arg == "--include" && i+1 < len(args):
+			i++
+			config.Include = append(config.Include, args[i])
+		case arg == "--context" && i+1 < len(args), strings.HasPrefix(arg, "--context="):
+			value := strings.TrimPrefix(arg, "--context=")
+			if arg == "--context" {
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return config, fmt.Errorf("invalid --context: %s (must be 0 or more lines)", value)
+			}
+			config.Context = n
 		case config.SpecName == "":
 			config.SpecName = arg
 		case config.TargetPath == ".":
@@ -129,7 +174,7 @@ func parseSVFlags() SpecVerifyConfig {
 		}
 	}
 
-	return config
+	return config, nil
 }
 
 // printSVUsage prints usage information
@@ -140,15 +185,23 @@ func printSVUsage() {
 	fmt.Println("  list                    List available specs")
 	fmt.Println("  verify <spec> <path>    Verify codebase against spec")
 	fmt.Println("  report <spec> <path>    Generate detailed compliance report")
+	fmt.Println("  trend <spec>            Show compliance across recorded runs")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --json                  Output in JSON format")
 	fmt.Println("  --format json           Output in JSON format")
+	fmt.Println("  --include <glob>        Only scan matching files (repeatable)")
+	fmt.Println("  --record                Save a compliance summary for trend (verify/report)")
+	fmt.Println("  --context <n>           Show n lines around each match, like grep -C")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  matrix spec-verify list")
 	fmt.Println("  matrix spec-verify verify oauth2 ~/project")
 	fmt.Println("  matrix spec-verify report oauth2 . --json")
+	fmt.Println("  matrix spec-verify verify oauth2 . --include 'src/**/*.go'")
+	fmt.Println("  matrix spec-verify verify oauth2 . --record")
+	fmt.Println("  matrix spec-verify report oauth2 . --context 3")
+	fmt.Println("  matrix spec-verify trend oauth2")
 }
 
 // listSpecs lists available spec files
@@ -215,13 +268,26 @@ func verifySpec(config SpecVerifyConfig) error {
 	}
 
 	// Verify requirements
-	results := verifyRequirements(spec, absPath)
+	results := verifyRequirements(spec, absPath, config.Include, config.Context)
 
 	// Output results
 	if config.OutputJSON {
-		outputSVJSON(spec, results)
+		if err := outputSVJSON(spec, results, config.Context > 0); err != nil {
+			return err
+		}
 	} else {
-		outputVerifyText(spec, results, absPath)
+		outputVerifyText(spec, results, absPath, config.Context > 0)
+	}
+
+	if config.Record {
+		run := summarizeCompliance(config.SpecName, absPath, results)
+		run.RunTime = time.Now()
+		if err := saveComplianceRun(run); err != nil {
+			return fmt.Errorf("failed to record compliance run: %w", err)
+		}
+		if !config.OutputJSON {
+			output.Success(fmt.Sprintf("✓ Recorded run for trend (matrix spec-verify trend %s)", config.SpecName))
+		}
 	}
 
 	return nil
@@ -235,11 +301,193 @@ func reportSpec(config SpecVerifyConfig) error {
 
 // getSpecsDir returns the specs directory path
 func getSpecsDir() string {
-	homeDir, err := os.UserHomeDir()
+	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
 		return ".claude/ram/lock/specs"
 	}
-	return filepath.Join(homeDir, ".claude", "ram", "lock", "specs")
+	return filepath.Join(ramDir, "lock", "specs")
+}
+
+// getComplianceDir returns the directory holding recorded runs of a spec
+func getComplianceDir(specName string) string {
+	return filepath.Join(filepath.Dir(getSpecsDir()), "compliance", specName)
+}
+
+// summarizeCompliance counts satisfied MUST and SHOULD requirements
+func summarizeCompliance(specName, target string, results []VerificationResult) ComplianceRun {
+	run := ComplianceRun{Spec: specName, Target: target, Missing: []string{}}
+
+	for _, result := range results {
+		satisfied := result.Status == StatusSatisfied
+		switch RequirementLevel(result.Requirement.Level) {
+		case LevelMust:
+			run.MustTotal++
+			if satisfied {
+				run.MustSatisfied++
+			}
+		case LevelShould:
+			run.ShouldTotal++
+			if satisfied {
+				run.ShouldSatisfied++
+			}
+		default:
+			continue
+		}
+		if !satisfied {
+			run.Missing = append(run.Missing, result.Requirement.ID)
+		}
+	}
+
+	return run
+}
+
+// saveComplianceRun writes a run as a timestamped file under the spec's
+// compliance directory
+func saveComplianceRun(run ComplianceRun) error {
+	dir := getComplianceDir(run.Spec)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file := filepath.Join(dir, fmt.Sprintf("run-%s.json", run.RunTime.Format(snapshotFileTimeFormat)))
+	return util.WriteFileAtomic(file, data, 0644)
+}
+
+// loadComplianceRuns loads all recorded runs of a spec, oldest first
+func loadComplianceRuns(specName string) ([]ComplianceRun, error) {
+	files, err := filepath.Glob(filepath.Join(getComplianceDir(specName), "run-*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []ComplianceRun
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var run ComplianceRun
+		if err := json.Unmarshal(data, &run); err != nil {
+			continue
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].RunTime.Before(runs[j].RunTime)
+	})
+
+	return runs, nil
+}
+
+// showComplianceTrend shows how compliance with a spec changed across runs
+func showComplianceTrend(config SpecVerifyConfig) error {
+	if config.SpecName == "" {
+		return fmt.Errorf("spec name required")
+	}
+
+	runs, err := loadComplianceRuns(config.SpecName)
+	if err != nil {
+		return fmt.Errorf("failed to load compliance history: %w", err)
+	}
+
+	if config.OutputJSON {
+		if runs == nil {
+			runs = []ComplianceRun{}
+		}
+		return output.EmitJSON(runs)
+	}
+
+	if len(runs) == 0 {
+		fmt.Printf("No recorded runs for %s.\n", config.SpecName)
+		fmt.Printf("Record one with: matrix spec-verify verify %s <path> --record\n", config.SpecName)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("📈 Compliance Trend: %s (%d runs)\n", config.SpecName, len(runs))
+	fmt.Println()
+
+	for i, run := range runs {
+		line := fmt.Sprintf("  %s  MUST %s  SHOULD %s",
+			run.RunTime.Format("2006-01-02 15:04:05"),
+			formatComplianceCount(run.MustSatisfied, run.MustTotal),
+			formatComplianceCount(run.ShouldSatisfied, run.ShouldTotal))
+		if i > 0 {
+			line += "  " + formatComplianceDelta(run.MustSatisfied-runs[i-1].MustSatisfied, "MUST")
+		}
+		fmt.Println(line)
+		fmt.Printf("    %s%s%s\n", output.Dim, run.Target, output.Reset)
+	}
+	fmt.Println()
+
+	first, last := runs[0], runs[len(runs)-1]
+	closed, opened := diffMissing(first.Missing, last.Missing)
+	if len(runs) > 1 {
+		fmt.Printf("Since %s:\n", first.RunTime.Format("2006-01-02"))
+		fmt.Printf("  MUST:   %d → %d satisfied\n", first.MustSatisfied, last.MustSatisfied)
+		fmt.Printf("  SHOULD: %d → %d satisfied\n", first.ShouldSatisfied, last.ShouldSatisfied)
+		if len(closed) > 0 {
+			fmt.Printf("  %sClosed:%s %s\n", output.Green, output.Reset, strings.Join(closed, ", "))
+		}
+		if len(opened) > 0 {
+			fmt.Printf("  %sNew gaps:%s %s\n", output.Red, output.Reset, strings.Join(opened, ", "))
+		}
+		fmt.Println()
+	}
+	if len(last.Missing) > 0 {
+		fmt.Printf("%sStill missing:%s %s\n", output.Yellow, output.Reset, strings.Join(last.Missing, ", "))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// formatComplianceCount renders "3/4 (75%)", or "-" when there are none
+func formatComplianceCount(satisfied, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d (%.0f%%)", satisfied, total, float64(satisfied)/float64(total)*100)
+}
+
+// formatComplianceDelta renders a change in satisfied count between runs
+func formatComplianceDelta(delta int, level string) string {
+	switch {
+	case delta > 0:
+		return fmt.Sprintf("%s+%d %s%s", output.Green, delta, level, output.Reset)
+	case delta < 0:
+		return fmt.Sprintf("%s%d %s%s", output.Red, delta, level, output.Reset)
+	default:
+		return fmt.Sprintf("%s±0%s", output.Dim, output.Reset)
+	}
+}
+
+// diffMissing returns requirement IDs that were closed (missing before, not
+// after) and newly opened (missing after, not before)
+func diffMissing(before, after []string) (closed, opened []string) {
+	beforeSet := make(map[string]bool)
+	for _, id := range before {
+		beforeSet[id] = true
+	}
+	afterSet := make(map[string]bool)
+	for _, id := range after {
+		afterSet[id] = true
+		if !beforeSet[id] {
+			opened = append(opened, id)
+		}
+	}
+	for _, id := range before {
+		if !afterSet[id] {
+			closed = append(closed, id)
+		}
+	}
+	return closed, opened
 }
 
 // loadSpec loads a spec file
@@ -260,33 +508,59 @@ func loadSpec(specName string) (*Spec, error) {
 	return &spec, nil
 }
 
-// verifyRequirements verifies all requirements against codebase
-func verifyRequirements(spec *Spec, targetPath string) []VerificationResult {
-	var results []VerificationResult
+// verifyRequirements verifies all requirements against codebase.
+// The tree is walked once and every file is read once, with each line
+// tested against all requirements' patterns. contextLines > 0 captures that
+// many surrounding lines with each match.
+func verifyRequirements(spec *Spec, targetPath string, include []string, contextLines int) []VerificationResult {
+	results := make([]VerificationResult, len(spec.Requirements))
+	var patternSets [][]*regexp.Regexp
+	var scopes []*regexp.Regexp // compiled file_scope for each pattern set, nil if unscoped
+	var scanned []int           // index into results for each pattern set
+
+	for i, req := range spec.Requirements {
+		results[i] = VerificationResult{
+			Requirement: req,
+			Status:      StatusMissing,
+			Matches:     []Match{},
+		}
 
-	for _, req := range spec.Requirements {
-		result := verifyRequirement(req, targetPath)
-		results = append(results, result)
+		regexes := compileRequirementPatterns(req)
+		if len(regexes) == 0 {
+			// Manual verification, or no usable patterns
+			results[i].Status = StatusManual
+			continue
+		}
+		patternSets = append(patternSets, regexes)
+		scopes = append(scopes, compileFileScope(req))
+		scanned = append(scanned, i)
 	}
 
-	return results
-}
+	if len(patternSets) == 0 {
+		return results
+	}
 
-// verifyRequirement verifies a single requirement
-func verifyRequirement(req Requirement, targetPath string) VerificationResult {
-	result := VerificationResult{
-		Requirement: req,
-		Status:      StatusMissing,
-		Matches:     []Match{},
+	// Scan codebase
+	matches := scanCodebase(targetPath, patternSets, scopes, compileGlobs(include), contextLines)
+
+	// Determine status
+	for set, i := range scanned {
+		if len(matches[set]) > 0 {
+			results[i].Matches = matches[set]
+			results[i].Status = StatusSatisfied
+		}
 	}
 
-	// Handle manual verification
+	return results
+}
+
+// compileRequirementPatterns compiles a requirement's verification patterns,
+// skipping invalid ones. Returns nil for manually verified requirements.
+func compileRequirementPatterns(req Requirement) []*regexp.Regexp {
 	if req.Verification.Type == "manual" {
-		result.Status = StatusManual
-		return result
+		return nil
 	}
 
-	// Compile patterns
 	var regexes []*regexp.Regexp
 	for _, pattern := range req.Verification.Patterns {
 		re, err := regexp.Compile(pattern)
@@ -295,29 +569,24 @@ func verifyRequirement(req Requirement, targetPath string) VerificationResult {
 		}
 		regexes = append(regexes, re)
 	}
+	return regexes
+}
 
-	if len(regexes) == 0 {
-		result.Status = StatusManual
-		return result
-	}
-
-	// Scan codebase
-	matches := scanCodebase(targetPath, regexes)
-	result.Matches = matches
-
-	// Determine status
-	if len(matches) > 0 {
-		result.Status = StatusSatisfied
-	} else {
-		result.Status = StatusMissing
+// compileFileScope compiles a requirement's file_scope glob, or returns nil
+// when the requirement applies to every file
+func compileFileScope(req Requirement) *regexp.Regexp {
+	if scopes := compileGlobs([]string{req.FileScope}); len(scopes) > 0 {
+		return scopes[0]
 	}
-
-	return result
+	return nil
 }
 
-// scanCodebase scans for pattern matches
-func scanCodebase(rootPath string, patterns []*regexp.Regexp) []Match {
-	var matches []Match
+// scanCodebase scans for pattern matches, returning the matches for each
+// pattern set in the same order. When include globs are given they replace
+// the default code-file filter. A non-nil scope further limits its pattern
+// set to files matching that glob.
+func scanCodebase(rootPath string, patternSets [][]*regexp.Regexp, scopes, include []*regexp.Regexp, contextLines int) [][]Match {
+	matches := make([][]Match, len(patternSets))
 
 	filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil || info.IsDir() {
@@ -327,8 +596,14 @@ func scanCodebase(rootPath string, patterns []*regexp.Regexp) []Match {
 			return nil
 		}
 
-		// Skip non-code files
-		if !isSVCodeFile(path) {
+		relPath, _ := filepath.Rel(rootPath, path)
+
+		// Skip files outside the include globs, or non-code files by default
+		if len(include) > 0 {
+			if !matchesGlobs(relPath, include) {
+				return nil
+			}
+		} else if !isSVCodeFile(path) {
 			return nil
 		}
 
@@ -337,9 +612,14 @@ func scanCodebase(rootPath string, patterns []*regexp.Regexp) []Match {
 			return nil
 		}
 
+		// Pattern sets whose file scope this file is outside sit it out
+		active, ok := scopedPatternSets(relPath, scopes)
+		if !ok {
+			return nil
+		}
+
 		// Scan file
-		fileMatches := scanFile(rootPath, path, patterns)
-		matches = append(matches, fileMatches...)
+		scanFile(relPath, path, patternSets, active, matches, contextLines)
 
 		return nil
 	})
@@ -347,39 +627,95 @@ func scanCodebase(rootPath string, patterns []*regexp.Regexp) []Match {
 	return matches
 }
 
-// scanFile scans a single file for patterns
-func scanFile(rootPath, filePath string, patterns []*regexp.Regexp) []Match {
-	var matches []Match
+// scopedPatternSets reports which pattern sets apply to relPath given their
+// file scopes, and whether any do. A nil active slice means all of them.
+func scopedPatternSets(relPath string, scopes []*regexp.Regexp) (active []bool, ok bool) {
+	for set, scope := range scopes {
+		if scope == nil {
+			continue
+		}
+		if active == nil {
+			active = make([]bool, len(scopes))
+			for i := range active {
+				active[i] = true
+			}
+		}
+		active[set] = matchesGlobs(relPath, []*regexp.Regexp{scope})
+	}
+	if active == nil {
+		return nil, true
+	}
+	for _, applies := range active {
+		if applies {
+			return active, true
+		}
+	}
+	return active, false
+}
 
+// scanFile scans a single file for every active pattern set (all of them when
+// active is nil), appending to matches. The last contextLines lines are kept in a small window for each match's
+// Before, and matches still short of their After lines are filled in as the
+// following lines are read.
+func scanFile(relPath, filePath string, patternSets [][]*regexp.Regexp, active []bool, matches [][]Match, contextLines int) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return matches
+		return
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
+	var window []string
+	var pending [][2]int // {set, index} of matches still collecting After lines
 
 	for scanner.Scan() {
 		lineNum++
 		line := scanner.Text()
 
-		// Check each pattern
-		for _, pattern := range patterns {
-			if pattern.MatchString(line) {
-				relPath, _ := filepath.Rel(rootPath, filePath)
-				matches = append(matches, Match{
-					FilePath: relPath,
-					Line:     lineNum,
-					Context:  strings.TrimSpace(line),
-				})
-				// Only match once per line
-				break
+		if contextLines > 0 {
+			open := pending[:0]
+			for _, p := range pending {
+				m := &matches[p[0]][p[1]]
+				m.After = append(m.After, strings.TrimSpace(line))
+				if len(m.After) < contextLines {
+					open = append(open, p)
+				}
 			}
+			pending = open
 		}
-	}
 
-	return matches
+		for set, patterns := range patternSets {
+			if active != nil && !active[set] {
+				continue
+			}
+
+			// Check each pattern
+			for _, pattern := range patterns {
+				if pattern.MatchString(line) {
+					match := Match{
+						FilePath: relPath,
+						Line:     lineNum,
+						Context:  strings.TrimSpace(line),
+					}
+					if contextLines > 0 {
+						match.Before = append([]string(nil), window...)
+						pending = append(pending, [2]int{set, len(matches[set])})
+					}
+					matches[set] = append(matches[set], match)
+					// Only match once per line
+					break
+				}
+			}
+		}
+
+		if contextLines > 0 {
+			window = append(window, strings.TrimSpace(line))
+			if len(window) > contextLines {
+				window = window[1:]
+			}
+		}
+	}
 }
 
 // shouldSkipSVDir returns true if directory should be skipped
@@ -411,7 +747,7 @@ func isSVCodeFile(path string) bool {
 }
 
 // outputVerifyText outputs verification results in text format
-func outputVerifyText(spec *Spec, results []VerificationResult, targetPath string) {
+func outputVerifyText(spec *Spec, results []VerificationResult, targetPath string, showContext bool) {
 	fmt.Println()
 	fmt.Printf("📋 Spec Verification: %s\n", spec.Spec.Name)
 	fmt.Println()
@@ -475,7 +811,11 @@ func outputVerifyText(spec *Spec, results []VerificationResult, targetPath strin
 				result.Requirement.ID,
 				result.Requirement.Level,
 				result.Requirement.Text)
-			fmt.Println("    - No matching patterns found")
+			if result.Requirement.FileScope != "" {
+				fmt.Printf("    - No matching patterns found in %s\n", result.Requirement.FileScope)
+			} else {
+				fmt.Println("    - No matching patterns found")
+			}
 			fmt.Println()
 		}
 	}
@@ -496,6 +836,9 @@ func outputVerifyText(spec *Spec, results []VerificationResult, targetPath strin
 			if len(result.Matches) > 0 {
 				match := result.Matches[0]
 				fmt.Printf("    - Found in %s:%d\n", match.FilePath, match.Line)
+				if showContext {
+					printMatchContext(match)
+				}
 			}
 			fmt.Println()
 		}
@@ -505,58 +848,94 @@ func outputVerifyText(spec *Spec, results []VerificationResult, targetPath strin
 	}
 }
 
+// printMatchContext prints a match with its surrounding lines, grep-style,
+// marking the matching line with '>'
+func printMatchContext(match Match) {
+	first := match.Line - len(match.Before)
+	last := match.Line + len(match.After)
+	width := len(strconv.Itoa(last))
+
+	for i, line := range match.Before {
+		fmt.Printf("      %s  %*d  %s%s\n", output.Dim, width, first+i, line, output.Reset)
+	}
+	fmt.Printf("      > %*d  %s\n", width, match.Line, match.Context)
+	for i, line := range match.After {
+		fmt.Printf("      %s  %*d  %s%s\n", output.Dim, width, match.Line+1+i, line, output.Reset)
+	}
+}
+
+// svJSONMatch is the JSON shape of one match, emitted with --context
+type svJSONMatch struct {
+	File    string   `json:"file"`
+	Line    int      `json:"line"`
+	Context string   `json:"context"`
+	Before  []string `json:"before,omitempty"`
+	After   []string `json:"after,omitempty"`
+}
+
+// svJSONResult is the JSON shape of one requirement's verification
+type svJSONResult struct {
+	ID        string            `json:"id"`
+	Level     string            `json:"level"`
+	Text      string            `json:"text"`
+	FileScope string            `json:"file_scope,omitempty"`
+	Status    RequirementStatus `json:"status"`
+	Matches   int               `json:"matches"`
+	Found     []svJSONMatch     `json:"found,omitempty"`
+}
+
+// svJSONReport is the JSON shape of a spec verification run
+type svJSONReport struct {
+	Spec              string         `json:"spec"`
+	Identifier        string         `json:"identifier"`
+	TotalRequirements int            `json:"total_requirements"`
+	Satisfied         int            `json:"satisfied"`
+	Missing           int            `json:"missing"`
+	Manual            int            `json:"manual"`
+	Results           []svJSONResult `json:"results"`
+}
+
 // outputSVJSON outputs verification results in JSON format
-func outputSVJSON(spec *Spec, results []VerificationResult) {
-	fmt.Println("{")
-	fmt.Printf("  \"spec\": \"%s\",\n", escapeSVJSON(spec.Spec.Name))
-	fmt.Printf("  \"identifier\": \"%s\",\n", escapeSVJSON(spec.Spec.Identifier))
-	fmt.Printf("  \"total_requirements\": %d,\n", len(results))
+func outputSVJSON(spec *Spec, results []VerificationResult, withContext bool) error {
+	report := svJSONReport{
+		Spec:              spec.Spec.Name,
+		Identifier:        spec.Spec.Identifier,
+		TotalRequirements: len(results),
+		Results:           []svJSONResult{},
+	}
 
 	// Count by status
-	satisfied := 0
-	missing := 0
-	manual := 0
 	for _, r := range results {
 		switch r.Status {
 		case StatusSatisfied:
-			satisfied++
+			report.Satisfied++
 		case StatusMissing:
-			missing++
+			report.Missing++
 		case StatusManual:
-			manual++
+			report.Manual++
 		}
-	}
 
-	fmt.Printf("  \"satisfied\": %d,\n", satisfied)
-	fmt.Printf("  \"missing\": %d,\n", missing)
-	fmt.Printf("  \"manual\": %d,\n", manual)
-	fmt.Println("  \"results\": [")
-
-	for i, result := range results {
-		comma := ","
-		if i == len(results)-1 {
-			comma = ""
+		result := svJSONResult{
+			ID:        r.Requirement.ID,
+			Level:     r.Requirement.Level,
+			Text:      r.Requirement.Text,
+			FileScope: r.Requirement.FileScope,
+			Status:    r.Status,
+			Matches:   len(r.Matches),
 		}
-
-		fmt.Println("    {")
-		fmt.Printf("      \"id\": \"%s\",\n", escapeSVJSON(result.Requirement.ID))
-		fmt.Printf("      \"level\": \"%s\",\n", escapeSVJSON(result.Requirement.Level))
-		fmt.Printf("      \"text\": \"%s\",\n", escapeSVJSON(result.Requirement.Text))
-		fmt.Printf("      \"status\": \"%s\",\n", result.Status)
-		fmt.Printf("      \"matches\": %d\n", len(result.Matches))
-		fmt.Printf("    }%s\n", comma)
+		if withContext {
+			for _, m := range r.Matches {
+				result.Found = append(result.Found, svJSONMatch{
+					File:    m.FilePath,
+					Line:    m.Line,
+					Context: m.Context,
+					Before:  m.Before,
+					After:   m.After,
+				})
+			}
+		}
+		report.Results = append(report.Results, result)
 	}
 
-	fmt.Println("  ]")
-	fmt.Println("}")
-}
-
-// escapeSVJSON escapes strings for JSON output
-func escapeSVJSON(s string) string {
-	s = strings.ReplaceAll(s, "\\", "\\\\")
-	s = strings.ReplaceAll(s, "\"", "\\\"")
-	s = strings.ReplaceAll(s, "\n", "\\n")
-	s = strings.ReplaceAll(s, "\r", "\\r")
-	s = strings.ReplaceAll(s, "\t", "\\t")
-	return s
+	return output.EmitJSON(report)
 }
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func specRequirement(id string, patterns ...string) Requirement {
+	req := Requirement{ID: id, Level: string(LevelMust)}
+	req.Verification.Type = "pattern"
+	req.Verification.Patterns = patterns
+	return req
+}
+
+func TestVerifyRequirementsSinglePass(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"auth.go":   "func validateState(state string) {}\nfunc pkce(verifier string) {}\n",
+		"notes.txt": "validateState lives here too\n",
+	})
+
+	spec := &Spec{Requirements: []Requirement{
+		specRequirement("STATE", `validateState`, `state`),
+		specRequirement("PKCE", `pkce`),
+		specRequirement("MISSING", `refresh_token`),
+		specRequirement("BROKEN", `(`),
+	}}
+	spec.Requirements = append(spec.Requirements, Requirement{ID: "MANUAL"})
+	spec.Requirements[4].Verification.Type = "manual"
+
+	results := verifyRequirements(spec, dir, nil, 0)
+
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(results))
+	}
+	// Both STATE patterns hit line 1, but it only counts once
+	if results[0].Status != StatusSatisfied || len(results[0].Matches) != 1 {
+		t.Errorf("Expected STATE satisfied by one match, got %s %+v", results[0].Status, results[0].Matches)
+	}
+	if results[1].Status != StatusSatisfied || results[1].Matches[0].Line != 2 {
+		t.Errorf("Expected PKCE satisfied on line 2, got %s %+v", results[1].Status, results[1].Matches)
+	}
+	if results[2].Status != StatusMissing {
+		t.Errorf("Expected MISSING to be missing, got %s", results[2].Status)
+	}
+	if results[3].Status != StatusManual || results[4].Status != StatusManual {
+		t.Errorf("Expected invalid and manual requirements to be manual, got %s and %s", results[3].Status, results[4].Status)
+	}
+}
+
+func TestVerifyRequirementsInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"src/api/handler.go": "token := issue()\n",
+		"scripts/gen.go":     "token := fake()\n",
+		"config/app.yaml":    "token: secret\n",
+	})
+
+	spec := &Spec{Requirements: []Requirement{specRequirement("TOKEN", `token`)}}
+
+	results := verifyRequirements(spec, dir, []string{"src/**/*.go"}, 0)
+	if len(results[0].Matches) != 1 || results[0].Matches[0].FilePath != filepath.Join("src", "api", "handler.go") {
+		t.Errorf("Expected only src/api/handler.go, got %+v", results[0].Matches)
+	}
+
+	// Include globs replace the code-file filter
+	results = verifyRequirements(spec, dir, []string{"*.yaml"}, 0)
+	if len(results[0].Matches) != 1 || results[0].Matches[0].FilePath != filepath.Join("config", "app.yaml") {
+		t.Errorf("Expected only config/app.yaml, got %+v", results[0].Matches)
+	}
+}
+
+func TestVerifyRequirementsContext(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"auth.go": "package auth\n\n// pkce check\nfunc verify(state string) {\n\tcheckState(state)   \n\tcheckPKCE()\n}\n",
+	})
+
+	spec := &Spec{Requirements: []Requirement{
+		specRequirement("STATE", `checkState`),
+		specRequirement("PKCE", `(?i)pkce`),
+	}}
+
+	results := verifyRequirements(spec, dir, nil, 2)
+
+	state := results[0].Matches[0]
+	if !reflect.DeepEqual(state.Before, []string{"// pkce check", "func verify(state string) {"}) {
+		t.Errorf("STATE Before = %q", state.Before)
+	}
+	if !reflect.DeepEqual(state.After, []string{"checkPKCE()", "}"}) {
+		t.Errorf("STATE After = %q", state.After)
+	}
+
+	// A match on line 3 only has two lines before it; later matches still
+	// collect their own window while earlier ones are pending
+	pkce := results[1].Matches
+	if len(pkce) != 2 {
+		t.Fatalf("Expected 2 PKCE matches, got %+v", pkce)
+	}
+	if !reflect.DeepEqual(pkce[0].Before, []string{"package auth", ""}) || len(pkce[0].After) != 2 {
+		t.Errorf("First PKCE match context = %q / %q", pkce[0].Before, pkce[0].After)
+	}
+	if !reflect.DeepEqual(pkce[1].After, []string{"}"}) {
+		t.Errorf("Expected the last match's After to stop at end of file, got %q", pkce[1].After)
+	}
+
+	// Without --context nothing extra is kept
+	results = verifyRequirements(spec, dir, nil, 0)
+	if m := results[0].Matches[0]; m.Before != nil || m.After != nil {
+		t.Errorf("Expected no context lines, got %+v", m)
+	}
+}
+
+func TestComplianceTrendHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	should := specRequirement("R3")
+	should.Level = string(LevelShould)
+	may := specRequirement("R4")
+	may.Level = string(LevelMay)
+
+	results := []VerificationResult{
+		{Requirement: specRequirement("R1"), Status: StatusSatisfied},
+		{Requirement: specRequirement("R2"), Status: StatusMissing},
+		{Requirement: should, Status: StatusPartial},
+		{Requirement: may, Status: StatusMissing},
+	}
+
+	first := summarizeCompliance("oauth2", "/src/app", results)
+	if first.MustSatisfied != 1 || first.MustTotal != 2 || first.ShouldSatisfied != 0 || first.ShouldTotal != 1 {
+		t.Errorf("Unexpected summary: %+v", first)
+	}
+	if !reflect.DeepEqual(first.Missing, []string{"R2", "R3"}) {
+		t.Errorf("Expected MAY requirements left out of Missing, got %v", first.Missing)
+	}
+
+	results[1].Status = StatusSatisfied
+	second := summarizeCompliance("oauth2", "/src/app", results)
+
+	first.RunTime = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	second.RunTime = time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	for _, run := range []ComplianceRun{second, first} {
+		if err := saveComplianceRun(run); err != nil {
+			t.Fatalf("saveComplianceRun() failed: %v", err)
+		}
+	}
+
+	runs, err := loadComplianceRuns("oauth2")
+	if err != nil {
+		t.Fatalf("loadComplianceRuns() failed: %v", err)
+	}
+	if len(runs) != 2 || !runs[0].RunTime.Equal(first.RunTime) || runs[1].MustSatisfied != 2 {
+		t.Fatalf("Expected runs oldest first, got %+v", runs)
+	}
+
+	closed, opened := diffMissing(runs[0].Missing, []string{"R3", "R5"})
+	if !reflect.DeepEqual(closed, []string{"R2"}) || !reflect.DeepEqual(opened, []string{"R5"}) {
+		t.Errorf("diffMissing() = %v, %v", closed, opened)
+	}
+
+	if runs, _ := loadComplianceRuns("other"); len(runs) != 0 {
+		t.Errorf("Expected no runs for an unrecorded spec, got %+v", runs)
+	}
+}
+
+func TestVerifyRequirementsFileScope(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, map[string]string{
+		"server/headers.go":     "w.Header().Set(\"Cache-Control\", \"no-store\")\n",
+		"tests/headers_test.go": "// Cache-Control: no-store and Pragma: no-cache\n",
+		"server/legacy/old.go":  "// Pragma is not set here\n",
+	})
+
+	noStore := specRequirement("NO-STORE", `Cache-Control`)
+	noStore.FileScope = "server/**"
+	pragma := specRequirement("PRAGMA", `Pragma: no-cache`)
+	pragma.FileScope = "server/*.go"
+	anywhere := specRequirement("ANYWHERE", `Pragma`)
+
+	spec := &Spec{Requirements: []Requirement{noStore, pragma, anywhere}}
+	results := verifyRequirements(spec, dir, nil, 0)
+
+	if len(results[0].Matches) != 1 || results[0].Matches[0].FilePath != filepath.Join("server", "headers.go") {
+		t.Errorf("Expected NO-STORE matched only in server/headers.go, got %+v", results[0].Matches)
+	}
+	// Only the test file mentions it, and that's out of scope
+	if results[1].Status != StatusMissing {
+		t.Errorf("Expected PRAGMA missing outside its scope, got %s %+v", results[1].Status, results[1].Matches)
+	}
+	if len(results[2].Matches) != 2 {
+		t.Errorf("Expected an unscoped requirement to match everywhere, got %+v", results[2].Matches)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/coryzibell/matrix/internal/output"
 	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // TensionType represents a category of tension
@@ -286,10 +287,7 @@ func displayTensionGroup(group TensionGroup) {
 			t.LineNum)
 
 		// Truncate long quotes
-		quote := t.Quote
-		if len(quote) > 120 {
-			quote = quote[:117] + "..."
-		}
+		quote := util.Truncate(t.Quote, 120)
 		fmt.Printf("    \"%s\"\n", quote)
 		fmt.Println("")
 	}
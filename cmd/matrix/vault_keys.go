@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // SecurityCategory represents a type of security-relevant finding
@@ -367,10 +368,7 @@ func outputVKText(keys []VaultKey, targetPath string, filesScanned int) {
 			fmt.Printf("   Pattern: %s\n", key.Pattern)
 
 			// Truncate long context lines
-			context := key.Context
-			if len(context) > 80 {
-				context = context[:77] + "..."
-			}
+			context := util.Truncate(key.Context, 80)
 			fmt.Printf("   Context: %s\n", context)
 			fmt.Println()
 		}
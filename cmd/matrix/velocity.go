@@ -1,9 +1,11 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"sort"
@@ -19,7 +21,7 @@ import (
 type TaskMetadata struct {
 	Identity   string
 	FilePath   string
-	Status     string    // success, failure, partial
+	Status     string    // success, failure, partial, incomplete
 	Started    time.Time // Zero if not found
 	Completed  time.Time // Zero if not found
 	Duration   time.Duration
@@ -29,24 +31,28 @@ type TaskMetadata struct {
 
 // VelocityStats tracks performance metrics for an identity
 type VelocityStats struct {
-	Identity       string
-	TotalTasks     int
-	SuccessCount   int
-	FailureCount   int
-	PartialCount   int
-	SuccessRate    float64
-	AvgDuration    time.Duration
-	HandoffsGiven  int
-	MostHandoffTo  string
+	Identity        string
+	TotalTasks      int
+	SuccessCount    int
+	FailureCount    int
+	PartialCount    int
+	IncompleteCount int // Started tasks with no recorded outcome
+	SuccessRate     float64
+	AvgDuration     time.Duration
+	HandoffsGiven   int
+	MostHandoffTo   string
 }
 
-// HandoffPair tracks handoff patterns between identities
+// HandoffPair tracks handoff patterns between identities. Success and
+// Failure count handoffs by the outcome of the work that followed (see
+// handoffOutcome); partial or still-open outcomes count toward neither.
 type HandoffPair struct {
-	From    string
-	To      string
-	Count   int
-	Success int
-	Failure int
+	From        string
+	To          string
+	Count       int
+	Success     int
+	Failure     int
+	SuccessRate float64
 }
 
 // VelocityReport contains the full analysis
@@ -54,6 +60,7 @@ type VelocityReport struct {
 	Stats           []VelocityStats
 	Handoffs        []HandoffPair
 	TotalTasks      int
+	IncompleteTasks int
 	FileCount       int
 	AnalysisPeriod  string
 	HighPerformers  []VelocityStats
@@ -67,12 +74,24 @@ func runVelocity() error {
 	identityFlag := fs.String("identity", "", "Filter by specific identity")
 	daysFlag := fs.Int("days", 0, "Only analyze last N days (0 = all time)")
 	jsonFlag := fs.Bool("json", false, "Output as JSON")
+	formatFlag := fs.String("format", "text", "Output format: text, csv, or markdown")
+	topFlag := fs.Int("top", velocityDefaultTop, "Identities to show as top performers and as needing attention")
 
 	// Parse remaining args (after "velocity")
 	if len(os.Args) > 2 {
 		fs.Parse(os.Args[2:])
 	}
 
+	switch *formatFlag {
+	case "text", "csv", "markdown":
+	default:
+		return fmt.Errorf("unknown format: %s (use text, csv, or markdown)", *formatFlag)
+	}
+	tableFormat := !*jsonFlag && *formatFlag != "text"
+	if *topFlag < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+
 	// Validate identity flag
 	if *identityFlag != "" && !identity.IsValid(*identityFlag) {
 		return fmt.Errorf("invalid identity: %s", *identityFlag)
@@ -91,12 +110,20 @@ func runVelocity() error {
 			outputJSON(emptyReport)
 			return nil
 		}
+		if tableFormat {
+			return writeVelocityTable(os.Stdout, VelocityReport{}, *formatFlag)
+		}
 		fmt.Println("🌾 No garden found at ~/.claude/ram/ - no velocity data yet")
 		return nil
 	}
 
-	// Scan RAM directory
-	files, err := ram.ScanDir(ramDir)
+	// Scan RAM directory, or only the requested identity's directory
+	var files []ram.File
+	if *identityFlag != "" {
+		files, err = ram.ScanIdentity(*identityFlag)
+	} else {
+		files, err = ram.ScanDir(ramDir)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to scan RAM directory: %w", err)
 	}
@@ -107,21 +134,13 @@ func runVelocity() error {
 			outputJSON(emptyReport)
 			return nil
 		}
+		if tableFormat {
+			return writeVelocityTable(os.Stdout, VelocityReport{}, *formatFlag)
+		}
 		fmt.Println("🌾 Garden exists but no markdown files found yet")
 		return nil
 	}
 
-	// Filter by identity if specified
-	if *identityFlag != "" {
-		filtered := make([]ram.File, 0)
-		for _, f := range files {
-			if f.Identity == *identityFlag {
-				filtered = append(filtered, f)
-			}
-		}
-		files = filtered
-	}
-
 	// Parse tasks from files
 	tasks := parseTaskMetadata(files)
 
@@ -140,7 +159,7 @@ func runVelocity() error {
 	}
 
 	// Generate report
-	report := generateReport(tasks, files)
+	report := generateReport(tasks, files, *topFlag)
 
 	if *daysFlag > 0 {
 		report.AnalysisPeriod = fmt.Sprintf("Last %d days", *daysFlag)
@@ -151,6 +170,8 @@ func runVelocity() error {
 	// Output
 	if *jsonFlag {
 		outputJSON(report)
+	} else if tableFormat {
+		return writeVelocityTable(os.Stdout, report, *formatFlag)
 	} else {
 		displayReport(report)
 	}
@@ -168,32 +189,81 @@ func parseTaskMetadata(files []ram.File) []TaskMetadata {
 
 	for _, file := range files {
 		lines := strings.Split(file.Content, "\n")
+		fileStart := len(tasks)
+
+		// newTask builds a task at lineNum with timestamps and handoffs from context
+		newTask := func(lineNum int, status string) TaskMetadata {
+			task := TaskMetadata{
+				Identity:   file.Identity,
+				FilePath:   file.Path,
+				Status:     status,
+				LineNumber: lineNum + 1,
+			}
 
-		for lineNum, line := range lines {
-			// Check for status lines
-			if statusMatch := statusPattern.FindStringSubmatch(line); statusMatch != nil {
-				task := TaskMetadata{
-					Identity:   file.Identity,
-					FilePath:   file.Path,
-					Status:     normalizeStatus(statusMatch[2]),
-					LineNumber: lineNum + 1,
+			// Look for timestamps in surrounding lines (context window)
+			task.Started, task.Completed = extractTimestamps(lines, lineNum)
+			if !task.Started.IsZero() && !task.Completed.IsZero() {
+				task.Duration = task.Completed.Sub(task.Started)
+			}
+
+			// Look for handoffs in surrounding lines
+			for i := max(0, lineNum-3); i < min(len(lines), lineNum+3); i++ {
+				if handoffMatch := handoffPattern.FindStringSubmatch(lines[i]); handoffMatch != nil {
+					task.HandoffTo = strings.ToLower(handoffMatch[1])
+					break
 				}
+			}
+
+			return task
+		}
 
-				// Look for timestamps in surrounding lines (context window)
-				task.Started, task.Completed = extractTimestamps(lines, lineNum)
-				if !task.Started.IsZero() && !task.Completed.IsZero() {
-					task.Duration = task.Completed.Sub(task.Started)
+		// Checkbox lists are tracked as a block: first line, done, and open counts
+		checklistStart, checklistDone, checklistOpen := -1, 0, 0
+		flushChecklist := func() {
+			if checklistStart >= 0 {
+				if status := checklistStatus(checklistDone, checklistOpen); status != "" {
+					tasks = append(tasks, newTask(checklistStart, status))
 				}
+			}
+			checklistStart, checklistDone, checklistOpen = -1, 0, 0
+		}
 
-				// Look for handoffs in surrounding lines
-				for i := max(0, lineNum-3); i < min(len(lines), lineNum+3); i++ {
-					if handoffMatch := handoffPattern.FindStringSubmatch(lines[i]); handoffMatch != nil {
-						task.HandoffTo = strings.ToLower(handoffMatch[1])
-						break
-					}
+		for lineNum, line := range lines {
+			// Checkbox items: - [x] done / - [ ] open
+			if done, ok := parseCheckbox(line); ok {
+				if checklistStart < 0 {
+					checklistStart = lineNum
+				}
+				if done {
+					checklistDone++
+				} else {
+					checklistOpen++
 				}
+				continue
+			}
+
+			// Blank lines don't end a checklist; anything else does
+			if strings.TrimSpace(line) != "" {
+				flushChecklist()
+			}
+
+			// Check for status lines
+			if statusMatch := statusPattern.FindStringSubmatch(line); statusMatch != nil {
+				tasks = append(tasks, newTask(lineNum, normalizeStatus(statusMatch[2])))
+				continue
+			}
 
-				tasks = append(tasks, task)
+			// Outcome emoji: ✅ success, ❌ failure, ⚠️ partial
+			if status := emojiStatus(line); status != "" {
+				tasks = append(tasks, newTask(lineNum, status))
+			}
+		}
+		flushChecklist()
+
+		// A task that was started but never got an outcome
+		if len(tasks) == fileStart {
+			if lineNum := findTaskStart(lines); lineNum >= 0 {
+				tasks = append(tasks, newTask(lineNum, "incomplete"))
 			}
 		}
 	}
@@ -201,6 +271,60 @@ func parseTaskMetadata(files []ram.File) []TaskMetadata {
 	return tasks
 }
 
+// taskStartPattern matches lines that mark a file as a task: a started
+// marker ("Started: 2024-03-01", "Status: in progress") or a task heading
+var taskStartPattern = regexp.MustCompile(`(?i)^\s*(?:[-*]\s+)?(?:\*\*)?(?:(?:started|began)(?:\*\*)?:(?:\*\*)?\s*\w|(?:status|state)(?:\*\*)?:(?:\*\*)?\s*(?:in[ -]progress|started|wip|ongoing)\b)|^#{1,6}\s+task\b`)
+
+// findTaskStart returns the line of the first task marker, or -1 if the file
+// doesn't look like a task
+func findTaskStart(lines []string) int {
+	for i, line := range lines {
+		if taskStartPattern.MatchString(line) {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkboxPattern matches markdown task list items like "- [x] ship it"
+var checkboxPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+\.)\s+\[([ xX])\]\s`)
+
+// parseCheckbox reports whether line is a checkbox item and if it's checked
+func parseCheckbox(line string) (done, ok bool) {
+	match := checkboxPattern.FindStringSubmatch(line)
+	if match == nil {
+		return false, false
+	}
+	return match[1] != " ", true
+}
+
+// checklistStatus maps a checklist's completion to a task status. Lists with
+// nothing checked have no outcome yet and are not counted.
+func checklistStatus(done, open int) string {
+	switch {
+	case done > 0 && open == 0:
+		return "success"
+	case done > 0:
+		return "partial"
+	default:
+		return ""
+	}
+}
+
+// emojiStatus maps outcome emoji on a line to a task status
+func emojiStatus(line string) string {
+	switch {
+	case strings.Contains(line, "❌") || strings.Contains(line, "🔴"):
+		return "failure"
+	case strings.Contains(line, "⚠️") || strings.Contains(line, "🟡"):
+		return "partial"
+	case strings.Contains(line, "✅") || strings.Contains(line, "✔️"):
+		return "success"
+	default:
+		return ""
+	}
+}
+
 // extractTimestamps looks for timestamp patterns near a status line
 func extractTimestamps(lines []string, centerLine int) (started, completed time.Time) {
 	// Search context window around status line
@@ -267,11 +391,19 @@ func normalizeStatus(s string) string {
 }
 
 // generateReport computes velocity statistics
-func generateReport(tasks []TaskMetadata, files []ram.File) VelocityReport {
+func generateReport(tasks []TaskMetadata, files []ram.File, top int) VelocityReport {
 	// Build stats per identity
 	identityStats := make(map[string]*VelocityStats)
 	handoffCounts := make(map[string]map[string]int) // from -> to -> count
 	handoffSuccess := make(map[string]map[string]int)
+	handoffFailure := make(map[string]map[string]int)
+	incomplete := 0
+
+	// Receiving identities' tasks, to see how handed-off work turned out
+	tasksByIdentity := make(map[string][]TaskMetadata)
+	for _, task := range tasks {
+		tasksByIdentity[task.Identity] = append(tasksByIdentity[task.Identity], task)
+	}
 
 	for _, task := range tasks {
 		// Initialize stats if needed
@@ -282,6 +414,13 @@ func generateReport(tasks []TaskMetadata, files []ram.File) VelocityReport {
 		}
 
 		stats := identityStats[task.Identity]
+
+		// Incomplete tasks have no outcome, so they stay out of the rates
+		if task.Status == "incomplete" {
+			stats.IncompleteCount++
+			incomplete++
+			continue
+		}
 		stats.TotalTasks++
 
 		// Count by status
@@ -306,10 +445,14 @@ func generateReport(tasks []TaskMetadata, files []ram.File) VelocityReport {
 			if handoffCounts[task.Identity] == nil {
 				handoffCounts[task.Identity] = make(map[string]int)
 				handoffSuccess[task.Identity] = make(map[string]int)
+				handoffFailure[task.Identity] = make(map[string]int)
 			}
 			handoffCounts[task.Identity][task.HandoffTo]++
-			if task.Status == "success" {
+			switch handoffOutcome(task, tasksByIdentity) {
+			case "success":
 				handoffSuccess[task.Identity][task.HandoffTo]++
+			case "failure":
+				handoffFailure[task.Identity][task.HandoffTo]++
 			}
 		}
 	}
@@ -346,55 +489,118 @@ func generateReport(tasks []TaskMetadata, files []ram.File) VelocityReport {
 	for from, targets := range handoffCounts {
 		for to, count := range targets {
 			pair := HandoffPair{
-				From:    from,
-				To:      to,
-				Count:   count,
-				Success: handoffSuccess[from][to],
-				Failure: count - handoffSuccess[from][to],
+				From:        from,
+				To:          to,
+				Count:       count,
+				Success:     handoffSuccess[from][to],
+				Failure:     handoffFailure[from][to],
+				SuccessRate: float64(handoffSuccess[from][to]) / float64(count) * 100,
 			}
 			handoffPairs = append(handoffPairs, pair)
 		}
 	}
 	sort.Slice(handoffPairs, func(i, j int) bool {
-		return handoffPairs[i].Count > handoffPairs[j].Count
+		a, b := handoffPairs[i], handoffPairs[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
 	})
 
-	// Identify high performers (top 3 by success rate with >3 tasks)
-	highPerformers := make([]VelocityStats, 0)
-	for _, stats := range statsList {
-		if stats.TotalTasks >= 3 {
-			highPerformers = append(highPerformers, stats)
+	highPerformers, bottlenecks := rankIdentities(statsList, top)
+
+	return VelocityReport{
+		Stats:           statsList,
+		Handoffs:        handoffPairs,
+		TotalTasks:      len(tasks) - incomplete,
+		IncompleteTasks: incomplete,
+		FileCount:       len(files),
+		HighPerformers:  highPerformers,
+		Bottlenecks:     bottlenecks,
+	}
+}
+
+// handoffOutcome returns the status that decides whether a handoff worked:
+// the receiving identity's first task finished (or started) at or after the
+// handoff. Without a timestamp to correlate on, or when the receiver has no
+// later task on record, the handing-off task's own status stands in.
+func handoffOutcome(task TaskMetadata, tasksByIdentity map[string][]TaskMetadata) string {
+	handedOff := taskTime(task)
+	if handedOff.IsZero() {
+		return task.Status
+	}
+
+	var next TaskMetadata
+	var nextTime time.Time
+	for _, candidate := range tasksByIdentity[task.HandoffTo] {
+		at := taskTime(candidate)
+		if at.IsZero() || at.Before(handedOff) {
+			continue
+		}
+		if nextTime.IsZero() || at.Before(nextTime) {
+			next, nextTime = candidate, at
 		}
 	}
-	sort.Slice(highPerformers, func(i, j int) bool {
-		return highPerformers[i].SuccessRate > highPerformers[j].SuccessRate
-	})
-	if len(highPerformers) > 3 {
-		highPerformers = highPerformers[:3]
+	if nextTime.IsZero() {
+		return task.Status
+	}
+	return next.Status
+}
+
+// taskTime is when a task finished, or when it started if it never recorded
+// a finish
+func taskTime(task TaskMetadata) time.Time {
+	if !task.Completed.IsZero() {
+		return task.Completed
 	}
+	return task.Started
+}
+
+// velocityDefaultTop is how many identities the leaderboard shows by default
+const velocityDefaultTop = 3
 
-	// Identify bottlenecks (high failure rate with >2 tasks)
-	bottlenecks := make([]VelocityStats, 0)
+// velocityMinRankedTasks is the fewest finished tasks an identity needs to be
+// ranked, so one lucky or unlucky task doesn't top the leaderboard
+const velocityMinRankedTasks = 3
+
+// rankIdentities ranks identities with at least velocityMinRankedTasks tasks
+// by success rate. The best top become high performers (more tasks breaks
+// ties); the worst top of the rest that had failures or partials need
+// attention.
+func rankIdentities(statsList []VelocityStats, top int) (highPerformers, bottlenecks []VelocityStats) {
+	var eligible []VelocityStats
 	for _, stats := range statsList {
-		if stats.TotalTasks >= 2 && stats.FailureCount > 0 {
-			bottlenecks = append(bottlenecks, stats)
+		if stats.TotalTasks >= velocityMinRankedTasks {
+			eligible = append(eligible, stats)
 		}
 	}
-	sort.Slice(bottlenecks, func(i, j int) bool {
-		return bottlenecks[i].FailureCount > bottlenecks[j].FailureCount
+
+	sort.Slice(eligible, func(i, j int) bool {
+		a, b := eligible[i], eligible[j]
+		if a.SuccessRate != b.SuccessRate {
+			return a.SuccessRate > b.SuccessRate
+		}
+		if a.TotalTasks != b.TotalTasks {
+			return a.TotalTasks > b.TotalTasks
+		}
+		return a.Identity < b.Identity
 	})
-	if len(bottlenecks) > 3 {
-		bottlenecks = bottlenecks[:3]
-	}
 
-	return VelocityReport{
-		Stats:          statsList,
-		Handoffs:       handoffPairs,
-		TotalTasks:     len(tasks),
-		FileCount:      len(files),
-		HighPerformers: highPerformers,
-		Bottlenecks:    bottlenecks,
+	limit := min(top, len(eligible))
+	highPerformers = append(make([]VelocityStats, 0, limit), eligible[:limit]...)
+	bottlenecks = make([]VelocityStats, 0)
+
+	// Walk up from the bottom so the weakest success rates come first
+	for i := len(eligible) - 1; i >= limit && len(bottlenecks) < top; i-- {
+		if eligible[i].SuccessCount < eligible[i].TotalTasks {
+			bottlenecks = append(bottlenecks, eligible[i])
+		}
 	}
+
+	return highPerformers, bottlenecks
 }
 
 // displayReport outputs the velocity report to stdout
@@ -403,12 +609,15 @@ func displayReport(report VelocityReport) {
 	fmt.Println("")
 	fmt.Printf("Analysis Period: %s\n", report.AnalysisPeriod)
 	fmt.Printf("Total Tasks: %d\n", report.TotalTasks)
+	if report.IncompleteTasks > 0 {
+		fmt.Printf("Incomplete/Abandoned: %s%d%s (started, no recorded outcome)\n", output.Yellow, report.IncompleteTasks, output.Reset)
+	}
 	fmt.Printf("Files Scanned: %d markdown files\n", report.FileCount)
 	fmt.Println("")
 
-	// High Performers
+	// Top Performers
 	if len(report.HighPerformers) > 0 {
-		output.Header("High Performers:")
+		output.Header(fmt.Sprintf("Top Performers (%d+ tasks):", velocityMinRankedTasks))
 		fmt.Println("")
 		for _, stats := range report.HighPerformers {
 			fmt.Printf("  %s - %d tasks, %.0f%% success",
@@ -434,6 +643,9 @@ func displayReport(report VelocityReport) {
 				stats.SuccessCount,
 				stats.FailureCount,
 				stats.PartialCount)
+			if stats.IncompleteCount > 0 {
+				fmt.Printf("    Incomplete/abandoned: %d\n", stats.IncompleteCount)
+			}
 			fmt.Printf("    Success Rate: %.1f%%\n", stats.SuccessRate)
 			if stats.AvgDuration > 0 {
 				fmt.Printf("    Avg Duration: %s\n", formatDuration(stats.AvgDuration))
@@ -445,40 +657,57 @@ func displayReport(report VelocityReport) {
 		}
 	}
 
-	// Bottlenecks
+	// Needs Attention
 	if len(report.Bottlenecks) > 0 {
-		output.Header("Bottlenecks:")
+		output.Header("Needs Attention:")
 		fmt.Println("")
 		for _, stats := range report.Bottlenecks {
-			fmt.Printf("  %s - %d failures in %d tasks (%.1f%% failure rate)\n",
+			fmt.Printf("  %s - %.0f%% success, %d failures and %d partial in %d tasks\n",
 				output.Yellow+stats.Identity+output.Reset,
+				stats.SuccessRate,
 				stats.FailureCount,
-				stats.TotalTasks,
-				float64(stats.FailureCount)/float64(stats.TotalTasks)*100)
+				stats.PartialCount,
+				stats.TotalTasks)
 		}
 		fmt.Println("")
 	}
 
-	// Handoff Patterns
+	// Handoff Reliability
 	if len(report.Handoffs) > 0 {
-		output.Header("Top Handoff Patterns:")
+		output.Header("Handoff Reliability:")
 		fmt.Println("")
-		limit := min(5, len(report.Handoffs))
-		for i := 0; i < limit; i++ {
-			h := report.Handoffs[i]
-			successRate := 0.0
-			if h.Count > 0 {
-				successRate = float64(h.Success) / float64(h.Count) * 100
-			}
-			fmt.Printf("  %s → %s (%d handoffs, %.0f%% success)\n",
-				h.From, h.To, h.Count, successRate)
-		}
+		displayHandoffTable(report.Handoffs)
 		fmt.Println("")
 	}
 
 	output.Success("⚡ Analysis complete")
 }
 
+// displayHandoffTable prints each from → to path with how the handed-off
+// work turned out, colouring the success rate
+func displayHandoffTable(handoffs []HandoffPair) {
+	pathWidth := len("Path")
+	for _, h := range handoffs {
+		pathWidth = max(pathWidth, len([]rune(h.From+" → "+h.To)))
+	}
+
+	fmt.Printf("  %-*s  %8s  %7s  %7s  %7s\n", pathWidth, "Path", "Handoffs", "Success", "Failure", "Rate")
+	for _, h := range handoffs {
+		path := h.From + " → " + h.To
+		color := output.Green
+		switch {
+		case h.Failure > h.Success:
+			color = output.Red
+		case h.Failure > 0 || h.Success < h.Count:
+			color = output.Yellow
+		}
+		fmt.Printf("  %s%s  %8d  %7d  %7d  %s%6.0f%%%s\n",
+			path, strings.Repeat(" ", pathWidth-len([]rune(path))),
+			h.Count, h.Success, h.Failure,
+			color, h.SuccessRate, output.Reset)
+	}
+}
+
 // outputJSON outputs the report as JSON
 func outputJSON(report VelocityReport) {
 	encoder := json.NewEncoder(os.Stdout)
@@ -486,6 +715,49 @@ func outputJSON(report VelocityReport) {
 	encoder.Encode(report)
 }
 
+// velocityTableHeader names the columns of the csv and markdown exports
+var velocityTableHeader = []string{
+	"identity", "tasks", "success", "failure", "partial",
+	"success_rate", "avg_duration", "handoffs", "most_handoff_to", "incomplete",
+}
+
+// writeVelocityTable writes per-identity stats as a csv or markdown table
+func writeVelocityTable(w io.Writer, report VelocityReport, format string) error {
+	rows := make([][]string, 0, len(report.Stats))
+	for _, stats := range report.Stats {
+		avg := ""
+		if stats.AvgDuration > 0 {
+			avg = formatDuration(stats.AvgDuration)
+		}
+		rows = append(rows, []string{
+			stats.Identity,
+			fmt.Sprintf("%d", stats.TotalTasks),
+			fmt.Sprintf("%d", stats.SuccessCount),
+			fmt.Sprintf("%d", stats.FailureCount),
+			fmt.Sprintf("%d", stats.PartialCount),
+			fmt.Sprintf("%.1f", stats.SuccessRate),
+			avg,
+			fmt.Sprintf("%d", stats.HandoffsGiven),
+			stats.MostHandoffTo,
+			fmt.Sprintf("%d", stats.IncompleteCount),
+		})
+	}
+
+	if format == "csv" {
+		cw := csv.NewWriter(w)
+		cw.Write(velocityTableHeader)
+		cw.WriteAll(rows)
+		return cw.Error()
+	}
+
+	fmt.Fprintf(w, "| %s |\n", strings.Join(velocityTableHeader, " | "))
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(velocityTableHeader)))
+	for _, row := range rows {
+		fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | "))
+	}
+	return nil
+}
+
 // formatDuration formats a duration in human-readable form
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -497,17 +769,3 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%.1fh", d.Hours())
 }
 
-// Helper functions
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coryzibell/matrix/internal/ram"
+)
+
+func TestParseTaskMetadataInformalSignals(t *testing.T) {
+	content := `# Sprint notes
+
+## Release checklist
+- [x] tag release
+- [X] publish binaries
+
+- [x] announce
+
+## Migration
+- [x] copy data
+- [ ] cut over
+
+## Ideas
+- [ ] someday
+
+Deploy to staging ✅
+Load test ❌
+Docs review ⚠️ needs another pass
+status: success ✅
+`
+
+	files := []ram.File{{Path: "/ram/smith/notes.md", Identity: "smith", Content: content}}
+	tasks := parseTaskMetadata(files)
+
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		counts[task.Status]++
+	}
+
+	// Release checklist (all done) + staging + status line
+	if counts["success"] != 3 {
+		t.Errorf("Expected 3 successes, got %d (%+v)", counts["success"], tasks)
+	}
+	// Migration checklist (mixed) + docs review
+	if counts["partial"] != 2 {
+		t.Errorf("Expected 2 partials, got %d (%+v)", counts["partial"], tasks)
+	}
+	if counts["failure"] != 1 {
+		t.Errorf("Expected 1 failure, got %d (%+v)", counts["failure"], tasks)
+	}
+	// The untouched "Ideas" checklist has no outcome yet
+	if len(tasks) != 6 {
+		t.Errorf("Expected 6 tasks, got %d", len(tasks))
+	}
+}
+
+func TestParseTaskMetadataIncomplete(t *testing.T) {
+	files := []ram.File{
+		{Path: "/ram/smith/migrate.md", Identity: "smith", Content: "# Task: migrate billing\n\nStarted: 2024-03-01\nCopied half the tables.\n"},
+		{Path: "/ram/smith/cache.md", Identity: "smith", Content: "## Cache rewrite\n**Status:** in progress\n"},
+		{Path: "/ram/smith/deploy.md", Identity: "smith", Content: "# Task: deploy\nStarted: 2024-03-02\nstatus: success\n"},
+		{Path: "/ram/neo/notes.md", Identity: "neo", Content: "# Notes\nSome thoughts on caching.\n"},
+	}
+
+	tasks := parseTaskMetadata(files)
+	report := generateReport(tasks, files, velocityDefaultTop)
+
+	if report.TotalTasks != 1 || report.IncompleteTasks != 2 {
+		t.Fatalf("Expected 1 finished and 2 incomplete tasks, got %d and %d (%+v)", report.TotalTasks, report.IncompleteTasks, tasks)
+	}
+	if len(report.Stats) != 1 {
+		t.Fatalf("Expected stats for smith only, got %+v", report.Stats)
+	}
+	smith := report.Stats[0]
+	if smith.IncompleteCount != 2 || smith.SuccessRate != 100 {
+		t.Errorf("Incomplete tasks should not affect the success rate: %+v", smith)
+	}
+	for _, task := range tasks {
+		if task.FilePath == "/ram/smith/migrate.md" && (task.LineNumber != 1 || task.Started.IsZero()) {
+			t.Errorf("Expected incomplete task at the heading with its start time, got %+v", task)
+		}
+	}
+}
+
+func TestWriteVelocityTable(t *testing.T) {
+	report := VelocityReport{Stats: []VelocityStats{
+		{Identity: "smith", TotalTasks: 4, SuccessCount: 3, FailureCount: 1, SuccessRate: 75, AvgDuration: 90 * time.Minute, HandoffsGiven: 2, MostHandoffTo: "trinity"},
+		{Identity: "neo", TotalTasks: 1, PartialCount: 1, IncompleteCount: 2},
+	}}
+
+	var csvOut bytes.Buffer
+	if err := writeVelocityTable(&csvOut, report, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	wantCSV := `identity,tasks,success,failure,partial,success_rate,avg_duration,handoffs,most_handoff_to,incomplete
+smith,4,3,1,0,75.0,1.5h,2,trinity,0
+neo,1,0,0,1,0.0,,0,,2
+`
+	if csvOut.String() != wantCSV {
+		t.Errorf("csv output:\n%s\nwant:\n%s", csvOut.String(), wantCSV)
+	}
+
+	var mdOut bytes.Buffer
+	if err := writeVelocityTable(&mdOut, report, "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	wantMD := `| identity | tasks | success | failure | partial | success_rate | avg_duration | handoffs | most_handoff_to | incomplete |
+| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |
+| smith | 4 | 3 | 1 | 0 | 75.0 | 1.5h | 2 | trinity | 0 |
+| neo | 1 | 0 | 0 | 1 | 0.0 |  | 0 |  | 2 |
+`
+	if mdOut.String() != wantMD {
+		t.Errorf("markdown output:\n%s\nwant:\n%s", mdOut.String(), wantMD)
+	}
+}
+
+func TestRankIdentities(t *testing.T) {
+	stats := []VelocityStats{
+		{Identity: "neo", TotalTasks: 10, SuccessCount: 9, FailureCount: 1, SuccessRate: 90},
+		{Identity: "trinity", TotalTasks: 4, SuccessCount: 4, SuccessRate: 100},
+		{Identity: "smith", TotalTasks: 5, SuccessCount: 1, FailureCount: 4, SuccessRate: 20},
+		{Identity: "tank", TotalTasks: 6, SuccessCount: 3, PartialCount: 3, SuccessRate: 50},
+		{Identity: "mouse", TotalTasks: 2, FailureCount: 2}, // too few tasks to rank
+		{Identity: "oracle", TotalTasks: 8, SuccessCount: 6, FailureCount: 2, SuccessRate: 75},
+	}
+
+	names := func(list []VelocityStats) string {
+		var out []string
+		for _, s := range list {
+			out = append(out, s.Identity)
+		}
+		return strings.Join(out, ",")
+	}
+
+	top, attention := rankIdentities(stats, 2)
+	if got := names(top); got != "trinity,neo" {
+		t.Errorf("top performers = %s, want trinity,neo", got)
+	}
+	if got := names(attention); got != "smith,tank" {
+		t.Errorf("needs attention = %s, want smith,tank", got)
+	}
+
+	// Nobody lands in both lists
+	top, attention = rankIdentities(stats, 10)
+	if got := names(top); got != "trinity,neo,oracle,tank,smith" || len(attention) != 0 {
+		t.Errorf("Unexpected ranking with a large limit: top=%s attention=%s", got, names(attention))
+	}
+
+	// A perfect record never needs attention
+	top, attention = rankIdentities(stats[:2], 1)
+	if names(top) != "trinity" || names(attention) != "neo" {
+		t.Errorf("Unexpected small ranking: top=%s attention=%s", names(top), names(attention))
+	}
+	if _, attention = rankIdentities(stats[1:2], 3); len(attention) != 0 {
+		t.Errorf("Expected no attention entries, got %s", names(attention))
+	}
+}
+
+func TestGenerateReportHandoffReliability(t *testing.T) {
+	files := []ram.File{
+		// smith hands off twice to neo: neo's next task after each decides the outcome
+		{Path: "/ram/smith/api.md", Identity: "smith", Content: "# Task: api\nCompleted: 2024-03-01\nstatus: success\nHandoff to: neo\n"},
+		{Path: "/ram/smith/db.md", Identity: "smith", Content: "# Task: db\nCompleted: 2024-03-05\nstatus: success\nHandoff to: neo\n"},
+		{Path: "/ram/neo/api.md", Identity: "neo", Content: "# Task: wire api\nCompleted: 2024-03-02\nstatus: success\n"},
+		{Path: "/ram/neo/db.md", Identity: "neo", Content: "# Task: migrate\nCompleted: 2024-03-06\nstatus: failure\n"},
+		{Path: "/ram/neo/old.md", Identity: "neo", Content: "# Task: old\nCompleted: 2024-02-01\nstatus: success\n"},
+		// No timestamps: the handing-off task's own status stands in
+		{Path: "/ram/trinity/ops.md", Identity: "trinity", Content: "status: failure\nHandoff to: smith\n"},
+	}
+
+	report := generateReport(parseTaskMetadata(files), files, velocityDefaultTop)
+
+	want := []HandoffPair{
+		{From: "smith", To: "neo", Count: 2, Success: 1, Failure: 1, SuccessRate: 50},
+		{From: "trinity", To: "smith", Count: 1, Success: 0, Failure: 1, SuccessRate: 0},
+	}
+	if len(report.Handoffs) != len(want) {
+		t.Fatalf("Expected %d handoff pairs, got %+v", len(want), report.Handoffs)
+	}
+	for i, pair := range want {
+		if report.Handoffs[i] != pair {
+			t.Errorf("Handoff %d: expected %+v, got %+v", i, pair, report.Handoffs[i])
+		}
+	}
+}
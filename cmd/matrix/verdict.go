@@ -12,6 +12,8 @@ import (
 
 	"github.com/coryzibell/matrix/internal/identity"
 	"github.com/coryzibell/matrix/internal/output"
+	"github.com/coryzibell/matrix/internal/ram"
+	"github.com/coryzibell/matrix/internal/util"
 )
 
 // VerdictEntry represents a single test result or benchmark
@@ -26,6 +28,7 @@ type VerdictEntry struct {
 	Value     float64   `json:"value"`     // metric value (for benchmarks)
 	Duration  float64   `json:"duration"`  // duration in seconds (for tests)
 	Timestamp time.Time `json:"timestamp"`
+	Tags      map[string]string `json:"tags,omitempty"` // free-form labels, e.g. env=ci
 }
 
 // VerdictBaseline represents a performance baseline
@@ -43,6 +46,12 @@ type VerdictData struct {
 	Baselines []VerdictBaseline `json:"baselines"`
 }
 
+// VerdictBaselineFile is a repo-local baseline file, committed alongside
+// the code so the whole team checks against the same budgets
+type VerdictBaselineFile struct {
+	Baselines []VerdictBaseline `json:"baselines"`
+}
+
 // VerdictSummary aggregates verdict data for reporting
 type VerdictSummary struct {
 	Component    string
@@ -56,6 +65,59 @@ type VerdictSummary struct {
 	ConsecutivePass int
 }
 
+// FlakyTest describes a test whose results flip between pass and fail
+type FlakyTest struct {
+	Component  string  `json:"component"`
+	Test       string  `json:"test"`
+	Runs       int     `json:"runs"`
+	Passes     int     `json:"passes"`
+	Fails      int     `json:"fails"`
+	Flips      int     `json:"flips"`
+	FlipRate   float64 `json:"flip_rate"` // flips per run transition, 0-1
+	LastResult string  `json:"last_result"`
+}
+
+// DurationRegression is a test whose recent runs are slower than its history
+type DurationRegression struct {
+	Component     string  `json:"component"`
+	Test          string  `json:"test"`
+	RecentAvg     float64 `json:"recent_avg"`   // seconds
+	BaselineAvg   float64 `json:"baseline_avg"` // seconds
+	PercentChange float64 `json:"percent_change"`
+	RecentRuns    int     `json:"recent_runs"`
+	BaselineRuns  int     `json:"baseline_runs"`
+}
+
+// durationBaselineWindow caps how many runs before the recent window make
+// up a test's rolling duration baseline
+const durationBaselineWindow = 20
+
+// minDurationBaselineRuns is how much history a test needs before its
+// duration is checked, so one slow early run can't set the baseline
+const minDurationBaselineRuns = 3
+
+// ComponentComparison contrasts two identities' results on a shared component
+type ComponentComparison struct {
+	Component     string  `json:"component"`
+	SuccessRateA  float64 `json:"success_rate_a"`
+	SuccessRateB  float64 `json:"success_rate_b"`
+	SuccessDelta  float64 `json:"success_delta"` // B - A, percentage points
+	AvgDurationA  float64 `json:"avg_duration_a"`
+	AvgDurationB  float64 `json:"avg_duration_b"`
+	DurationDelta float64 `json:"duration_delta"` // B - A, seconds
+	TestsA        int     `json:"tests_a"`
+	TestsB        int     `json:"tests_b"`
+}
+
+// IdentityComparison is the result of comparing two identities
+type IdentityComparison struct {
+	A          string                `json:"a"`
+	B          string                `json:"b"`
+	Components []ComponentComparison `json:"components"`
+	OnlyA      []string              `json:"only_a"`
+	OnlyB      []string              `json:"only_b"`
+}
+
 // runVerdict implements the verdict command
 func runVerdict() error {
 	if len(os.Args) < 3 {
@@ -78,6 +140,10 @@ func runVerdict() error {
 		return runVerdictBaseline()
 	case "list":
 		return runVerdictList()
+	case "flaky":
+		return runVerdictFlaky()
+	case "compare":
+		return runVerdictCompare()
 	default:
 		return fmt.Errorf("unknown verdict subcommand: %s", subcommand)
 	}
@@ -91,6 +157,9 @@ func runVerdictRecord() error {
 	testFlag := fs.String("test", "", "Test name")
 	resultFlag := fs.String("result", "", "Result: pass or fail")
 	durationFlag := fs.Float64("duration", 0, "Test duration in seconds")
+	newFlag := fs.Bool("new", false, "Component is new; don't warn that it has no history")
+	var tagFlags stringSliceFlag
+	fs.Var(&tagFlags, "tags", "Labels as k=v, comma-separated or repeated (e.g. env=ci)")
 
 	// Parse remaining args (after "verdict record")
 	if len(os.Args) > 3 {
@@ -111,8 +180,7 @@ func runVerdictRecord() error {
 		return fmt.Errorf("result must be 'pass' or 'fail', got: %s", *resultFlag)
 	}
 
-	// Load existing data
-	data, err := loadVerdictData()
+	tags, err := parseVerdictTags(tagFlags)
 	if err != nil {
 		return err
 	}
@@ -127,15 +195,23 @@ func runVerdictRecord() error {
 		Result:    result,
 		Duration:  *durationFlag,
 		Timestamp: time.Now(),
+		Tags:      tags,
 	}
 
-	// Add to data
-	data.Entries = append(data.Entries, entry)
-
-	// Save
-	if err := saveVerdictData(data); err != nil {
+	// Append under the store lock so concurrent records don't drop entries
+	var isNew bool
+	var suggestions []string
+	_, err = updateVerdictData(func(data *VerdictData) error {
+		isNew, suggestions = newComponentSuggestions(data.Entries, entry.Component)
+		data.Entries = append(data.Entries, entry)
+		return nil
+	})
+	if err != nil {
 		return err
 	}
+	if isNew && !*newFlag {
+		warnNewComponent(entry.Component, suggestions)
+	}
 
 	// Display result
 	output.Success("⚖️ VERDICT RECORDED")
@@ -146,6 +222,9 @@ func runVerdictRecord() error {
 	if entry.Duration > 0 {
 		fmt.Printf("Duration: %.2fs\n", entry.Duration)
 	}
+	if len(entry.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", formatVerdictTags(entry.Tags))
+	}
 	fmt.Printf("Identity: %s\n", entry.Identity)
 	fmt.Printf("Timestamp: %s\n", entry.Timestamp.Format("2006-01-02 15:04:05"))
 
@@ -159,6 +238,9 @@ func runVerdictBench() error {
 	componentFlag := fs.String("component", "", "Component being benchmarked")
 	metricFlag := fs.String("metric", "", "Metric name")
 	valueFlag := fs.Float64("value", 0, "Metric value")
+	newFlag := fs.Bool("new", false, "Component is new; don't warn that it has no history")
+	var tagFlags stringSliceFlag
+	fs.Var(&tagFlags, "tags", "Labels as k=v, comma-separated or repeated (e.g. env=ci)")
 
 	// Parse remaining args (after "verdict bench")
 	if len(os.Args) > 3 {
@@ -174,8 +256,7 @@ func runVerdictBench() error {
 		return fmt.Errorf("invalid identity: %s", *identityFlag)
 	}
 
-	// Load existing data
-	data, err := loadVerdictData()
+	tags, err := parseVerdictTags(tagFlags)
 	if err != nil {
 		return err
 	}
@@ -189,15 +270,23 @@ func runVerdictBench() error {
 		Metric:    *metricFlag,
 		Value:     *valueFlag,
 		Timestamp: time.Now(),
+		Tags:      tags,
 	}
 
-	// Add to data
-	data.Entries = append(data.Entries, entry)
-
-	// Save
-	if err := saveVerdictData(data); err != nil {
+	// Append under the store lock so concurrent records don't drop entries
+	var isNew bool
+	var suggestions []string
+	data, err := updateVerdictData(func(data *VerdictData) error {
+		isNew, suggestions = newComponentSuggestions(data.Entries, entry.Component)
+		data.Entries = append(data.Entries, entry)
+		return nil
+	})
+	if err != nil {
 		return err
 	}
+	if isNew && !*newFlag {
+		warnNewComponent(entry.Component, suggestions)
+	}
 
 	// Check against baseline
 	baseline := findBaseline(data, *componentFlag, *metricFlag)
@@ -211,43 +300,205 @@ func runVerdictBench() error {
 		percentChange := ((entry.Value - baseline.Value) / baseline.Value) * 100
 		fmt.Printf("Baseline: %.2f (%+.1f%%)\n", baseline.Value, percentChange)
 	}
+	if len(entry.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", formatVerdictTags(entry.Tags))
+	}
 	fmt.Printf("Identity: %s\n", entry.Identity)
 	fmt.Printf("Timestamp: %s\n", entry.Timestamp.Format("2006-01-02 15:04:05"))
 
 	return nil
 }
 
+// componentSuggestionLimit caps the close matches offered for a new component
+const componentSuggestionLimit = 3
+
+// newComponentSuggestions reports whether component would be new to a store
+// that already has components, along with existing components a small edit
+// distance away, closest first. An empty store never reports a new component.
+func newComponentSuggestions(entries []VerdictEntry, component string) (bool, []string) {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	lower := strings.ToLower(component)
+	maxDistance := 1 + len(lower)/5
+
+	seen := make(map[string]bool)
+	var candidates []candidate
+	for _, entry := range entries {
+		if entry.Component == component {
+			return false, nil
+		}
+		if seen[entry.Component] {
+			continue
+		}
+		seen[entry.Component] = true
+		if d := levenshtein(lower, strings.ToLower(entry.Component)); d <= maxDistance {
+			candidates = append(candidates, candidate{entry.Component, d})
+		}
+	}
+	if len(seen) == 0 {
+		return false, nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < componentSuggestionLimit; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return true, suggestions
+}
+
+// warnNewComponent warns that a verdict started a new component's history
+func warnNewComponent(component string, suggestions []string) {
+	fmt.Fprintf(os.Stderr, "%sWarning: no earlier verdicts for component '%s'", output.Yellow, component)
+	if len(suggestions) > 0 {
+		fmt.Fprintf(os.Stderr, " - did you mean %s?", strings.Join(suggestions, ", "))
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", output.Reset)
+	fmt.Fprintln(os.Stderr, "  Pass --new when recording a genuinely new component to skip this check.")
+}
+
+// parseVerdictTags parses --tags values ("env=ci,os=linux", repeatable)
+// into a map. Later values for the same key win.
+func parseVerdictTags(values []string) (map[string]string, error) {
+	var tags map[string]string
+	for _, value := range values {
+		for _, pair := range strings.Split(value, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			key, val, ok := strings.Cut(pair, "=")
+			key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+			if !ok || key == "" {
+				return nil, fmt.Errorf("invalid tag: %s (expected key=value)", pair)
+			}
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[key] = val
+		}
+	}
+	return tags, nil
+}
+
+// matchesVerdictTags reports whether an entry carries every tag in filter
+func matchesVerdictTags(entry VerdictEntry, filter map[string]string) bool {
+	for key, val := range filter {
+		got, ok := entry.Tags[key]
+		if !ok || got != val {
+			return false
+		}
+	}
+	return true
+}
+
+// filterVerdictTags returns the entries carrying every tag in filter
+func filterVerdictTags(entries []VerdictEntry, filter map[string]string) []VerdictEntry {
+	if len(filter) == 0 {
+		return entries
+	}
+	var filtered []VerdictEntry
+	for _, entry := range entries {
+		if matchesVerdictTags(entry, filter) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// formatVerdictTags renders tags as "k=v, k=v" sorted by key
+func formatVerdictTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + tags[key]
+	}
+	return strings.Join(pairs, ", ")
+}
+
 // runVerdictCheck checks for regressions
 func runVerdictCheck() error {
 	fs := flag.NewFlagSet("verdict check", flag.ExitOnError)
 	componentFlag := fs.String("component", "", "Component to check")
 	thresholdFlag := fs.Float64("threshold", 10.0, "Regression threshold percentage (default: 10%)")
+	testsFlag := fs.Bool("tests", false, "Check test durations against their history instead of benchmarks")
+	recentFlag := fs.Int("recent", 3, "Recent runs averaged per test with --tests")
+	baselineFileFlag := fs.String("baseline-file", "", "Repo-local baseline JSON file, preferred over the RAM store")
+	var tagFlags stringSliceFlag
+	fs.Var(&tagFlags, "tag", "Only entries with this k=v tag (repeatable)")
 
 	// Parse remaining args (after "verdict check")
 	if len(os.Args) > 3 {
 		fs.Parse(os.Args[3:])
 	}
 
-	if *componentFlag == "" {
+	tagFilter, err := parseVerdictTags(tagFlags)
+	if err != nil {
+		return err
+	}
+
+	if *componentFlag == "" && !*testsFlag {
 		return fmt.Errorf("required flag: --component")
 	}
+	if *recentFlag < 1 {
+		return fmt.Errorf("--recent must be at least 1")
+	}
 
 	// Load existing data
 	data, err := loadVerdictData()
 	if err != nil {
 		return err
 	}
+	entries := filterVerdictTags(data.Entries, tagFilter)
+
+	var fileBaselines []VerdictBaseline
+	if *baselineFileFlag != "" {
+		path := util.ExpandPath(*baselineFileFlag)
+		file, err := loadVerdictBaselineFile(path)
+		if err != nil {
+			return err
+		}
+		if file == nil {
+			fmt.Fprintf(os.Stderr, "Note: baseline file %s not found; using baselines from the RAM store\n", path)
+		} else {
+			fileBaselines = file.Baselines
+		}
+	}
+
+	if *testsFlag {
+		regressions := detectDurationRegressions(entries, *componentFlag, *recentFlag, *thresholdFlag)
+		displayDurationRegressions(regressions, *componentFlag, *recentFlag, *thresholdFlag)
+		return nil
+	}
 
 	// Get benchmarks for component
 	var benchmarks []VerdictEntry
-	for _, entry := range data.Entries {
+	for _, entry := range entries {
 		if entry.Type == "benchmark" && entry.Component == *componentFlag {
 			benchmarks = append(benchmarks, entry)
 		}
 	}
 
 	if len(benchmarks) == 0 {
-		fmt.Printf("No benchmark data for component: %s\n", *componentFlag)
+		fmt.Printf("No benchmark data for component: %s", *componentFlag)
+		if len(tagFilter) > 0 {
+			fmt.Printf(" (tags: %s)", formatVerdictTags(tagFilter))
+		}
+		fmt.Println()
 		return nil
 	}
 
@@ -259,7 +510,7 @@ func runVerdictCheck() error {
 	})
 
 	for _, bench := range benchmarks {
-		baseline := findBaseline(data, bench.Component, bench.Metric)
+		baseline := resolveBaseline(fileBaselines, data, bench.Component, bench.Metric)
 		if baseline != nil {
 			percentChange := ((bench.Value - baseline.Value) / baseline.Value) * 100
 			// Negative change is regression (assuming lower is better)
@@ -300,17 +551,29 @@ func runVerdictReport() error {
 	fs := flag.NewFlagSet("verdict report", flag.ExitOnError)
 	identityFlag := fs.String("identity", "", "Filter by identity")
 	componentFlag := fs.String("component", "", "Filter by component")
+	formatFlag := fs.String("format", "text", "Output format: text, markdown")
+	var tagFlags stringSliceFlag
+	fs.Var(&tagFlags, "tag", "Only entries with this k=v tag (repeatable)")
 
 	// Parse remaining args (after "verdict report")
 	if len(os.Args) > 3 {
 		fs.Parse(os.Args[3:])
 	}
 
+	tagFilter, err := parseVerdictTags(tagFlags)
+	if err != nil {
+		return err
+	}
+
 	// Validate identity flag
 	if *identityFlag != "" && !identity.IsValid(*identityFlag) {
 		return fmt.Errorf("invalid identity: %s", *identityFlag)
 	}
 
+	if *formatFlag != "text" && *formatFlag != "markdown" {
+		return fmt.Errorf("invalid format: %s (valid: text, markdown)", *formatFlag)
+	}
+
 	// Load existing data
 	data, err := loadVerdictData()
 	if err != nil {
@@ -331,16 +594,27 @@ func runVerdictReport() error {
 		if *componentFlag != "" && entry.Component != *componentFlag {
 			continue
 		}
+		if !matchesVerdictTags(entry, tagFilter) {
+			continue
+		}
 		filtered = append(filtered, entry)
 	}
 
 	// Generate summaries per component
 	summaries := generateSummaries(filtered)
 
+	if *formatFlag == "markdown" {
+		fmt.Print(renderVerdictMarkdown(summaries, len(filtered)))
+		return nil
+	}
+
 	// Display report
 	output.Success("⚖️ VERDICT REPORT")
 	fmt.Println("")
 	fmt.Printf("Total Entries: %d\n", len(filtered))
+	if len(tagFilter) > 0 {
+		fmt.Printf("Tags: %s\n", formatVerdictTags(tagFilter))
+	}
 	fmt.Println("")
 
 	for _, summary := range summaries {
@@ -362,6 +636,51 @@ func runVerdictReport() error {
 	return nil
 }
 
+// renderVerdictMarkdown renders component summaries as a markdown table
+// suitable for pasting into release notes
+func renderVerdictMarkdown(summaries []VerdictSummary, totalEntries int) string {
+	var b strings.Builder
+
+	b.WriteString("## Verdict Report\n\n")
+	fmt.Fprintf(&b, "Total entries: %d\n\n", totalEntries)
+
+	if len(summaries) == 0 {
+		b.WriteString("No test results recorded.\n")
+		return b.String()
+	}
+
+	b.WriteString("| Component | Tests | Pass / Fail | Success Rate | Avg Duration | Trend |\n")
+	b.WriteString("|-----------|------:|------------:|-------------:|-------------:|-------|\n")
+	for _, summary := range summaries {
+		duration := "-"
+		if summary.AvgDuration > 0 {
+			duration = fmt.Sprintf("%.2fs", summary.AvgDuration)
+		}
+		fmt.Fprintf(&b, "| %s | %d | %d / %d | %.1f%% | %s | %s |\n",
+			strings.ReplaceAll(summary.Component, "|", "\\|"),
+			summary.TotalTests,
+			summary.PassCount,
+			summary.FailCount,
+			summary.SuccessRate,
+			duration,
+			trendLabel(summary.Trend))
+	}
+
+	return b.String()
+}
+
+// trendLabel spells out a trend arrow for readers without context
+func trendLabel(trend string) string {
+	switch trend {
+	case "↑":
+		return "↑ improving"
+	case "↓":
+		return "↓ declining"
+	default:
+		return "→ stable"
+	}
+}
+
 // runVerdictBaseline sets a performance baseline
 func runVerdictBaseline() error {
 	fs := flag.NewFlagSet("verdict baseline", flag.ExitOnError)
@@ -369,6 +688,7 @@ func runVerdictBaseline() error {
 	metricFlag := fs.String("metric", "", "Metric name")
 	valueFlag := fs.Float64("value", 0, "Baseline value")
 	identityFlag := fs.String("identity", "", "Identity setting baseline")
+	baselineFileFlag := fs.String("baseline-file", "", "Write to this repo-local baseline JSON file instead of the RAM store")
 
 	// Parse remaining args (after "verdict baseline")
 	if len(os.Args) > 3 {
@@ -384,12 +704,6 @@ func runVerdictBaseline() error {
 		return fmt.Errorf("invalid identity: %s", *identityFlag)
 	}
 
-	// Load existing data
-	data, err := loadVerdictData()
-	if err != nil {
-		return err
-	}
-
 	// Create or update baseline
 	baseline := VerdictBaseline{
 		Component: *componentFlag,
@@ -399,19 +713,29 @@ func runVerdictBaseline() error {
 		SetBy:     *identityFlag,
 	}
 
-	// Remove existing baseline for this component/metric
-	newBaselines := []VerdictBaseline{}
-	for _, b := range data.Baselines {
-		if b.Component != *componentFlag || b.Metric != *metricFlag {
-			newBaselines = append(newBaselines, b)
+	// Replace any existing baseline for this component/metric
+	baselineFile := ""
+	if *baselineFileFlag != "" {
+		baselineFile = util.ExpandPath(*baselineFileFlag)
+		file, err := loadVerdictBaselineFile(baselineFile)
+		if err != nil {
+			return err
+		}
+		if file == nil {
+			file = &VerdictBaselineFile{}
+		}
+		file.Baselines = setBaseline(file.Baselines, baseline)
+		if err := saveVerdictBaselineFile(baselineFile, file); err != nil {
+			return err
+		}
+	} else {
+		_, err := updateVerdictData(func(data *VerdictData) error {
+			data.Baselines = setBaseline(data.Baselines, baseline)
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-	}
-	newBaselines = append(newBaselines, baseline)
-	data.Baselines = newBaselines
-
-	// Save
-	if err := saveVerdictData(data); err != nil {
-		return err
 	}
 
 	output.Success("⚖️ BASELINE SET")
@@ -421,19 +745,95 @@ func runVerdictBaseline() error {
 	fmt.Printf("Value: %.2f\n", baseline.Value)
 	fmt.Printf("Set By: %s\n", baseline.SetBy)
 	fmt.Printf("Set At: %s\n", baseline.SetAt.Format("2006-01-02 15:04:05"))
+	if baselineFile != "" {
+		fmt.Printf("File: %s\n", baselineFile)
+	}
+
+	return nil
+}
+
+// setBaseline replaces any baseline for the same component/metric, keeping
+// the list sorted so committed baseline files diff cleanly
+func setBaseline(baselines []VerdictBaseline, baseline VerdictBaseline) []VerdictBaseline {
+	updated := []VerdictBaseline{}
+	for _, b := range baselines {
+		if b.Component != baseline.Component || b.Metric != baseline.Metric {
+			updated = append(updated, b)
+		}
+	}
+	updated = append(updated, baseline)
+	sort.SliceStable(updated, func(i, j int) bool {
+		if updated[i].Component != updated[j].Component {
+			return updated[i].Component < updated[j].Component
+		}
+		return updated[i].Metric < updated[j].Metric
+	})
+	return updated
+}
+
+// loadVerdictBaselineFile reads a repo-local baseline file. A missing file
+// returns nil without an error.
+func loadVerdictBaselineFile(path string) (*VerdictBaselineFile, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var file VerdictBaselineFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file %s: %w", path, err)
+	}
+	return &file, nil
+}
 
+// saveVerdictBaselineFile writes a repo-local baseline file
+func saveVerdictBaselineFile(path string, file *VerdictBaselineFile) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create baseline directory: %w", err)
+		}
+	}
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline file: %w", err)
+	}
+	if err := util.WriteFileAtomic(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline file: %w", err)
+	}
 	return nil
 }
 
 // runVerdictList lists all verdicts
 func runVerdictList() error {
+	fs := flag.NewFlagSet("verdict list", flag.ExitOnError)
+	var tagFlags stringSliceFlag
+	fs.Var(&tagFlags, "tag", "Only entries with this k=v tag (repeatable)")
+
+	// Parse remaining args (after "verdict list")
+	if len(os.Args) > 3 {
+		fs.Parse(os.Args[3:])
+	}
+
+	tagFilter, err := parseVerdictTags(tagFlags)
+	if err != nil {
+		return err
+	}
+
 	// Load existing data
 	data, err := loadVerdictData()
 	if err != nil {
 		return err
 	}
 
-	if len(data.Entries) == 0 {
+	matching := filterVerdictTags(data.Entries, tagFilter)
+	if len(matching) == 0 {
+		if len(tagFilter) > 0 {
+			fmt.Printf("No verdicts tagged %s\n", formatVerdictTags(tagFilter))
+			return nil
+		}
 		fmt.Println("No verdicts recorded yet")
 		return nil
 	}
@@ -442,8 +842,8 @@ func runVerdictList() error {
 	fmt.Println("")
 
 	// Sort by timestamp (newest first)
-	entries := make([]VerdictEntry, len(data.Entries))
-	copy(entries, data.Entries)
+	entries := make([]VerdictEntry, len(matching))
+	copy(entries, matching)
 	sort.Slice(entries, func(i, j int) bool {
 		return entries[i].Timestamp.After(entries[j].Timestamp)
 	})
@@ -465,15 +865,21 @@ func runVerdictList() error {
 			if entry.Duration > 0 {
 				fmt.Printf(" (%.2fs)", entry.Duration)
 			}
-			fmt.Printf(" - %s\n", entry.Identity)
+			fmt.Printf(" - %s", entry.Identity)
 		} else if entry.Type == "benchmark" {
-			fmt.Printf("[%s] %s/%s: %.2f - %s\n",
+			fmt.Printf("[%s] %s/%s: %.2f - %s",
 				entry.Timestamp.Format("2006-01-02 15:04"),
 				entry.Component,
 				entry.Metric,
 				entry.Value,
 				entry.Identity)
+		} else {
+			continue
+		}
+		if len(entry.Tags) > 0 {
+			fmt.Printf(" %s[%s]%s", output.Dim, formatVerdictTags(entry.Tags), output.Reset)
 		}
+		fmt.Println()
 	}
 
 	if len(entries) > limit {
@@ -483,8 +889,355 @@ func runVerdictList() error {
 	return nil
 }
 
+// runVerdictFlaky reports tests whose results are unstable across runs
+func runVerdictFlaky() error {
+	fs := flag.NewFlagSet("verdict flaky", flag.ExitOnError)
+	componentFlag := fs.String("component", "", "Filter by component")
+	minRunsFlag := fs.Int("min-runs", 3, "Minimum recorded runs before a test is considered")
+	jsonFlag := fs.Bool("json", false, "Output as JSON")
+
+	// Parse remaining args (after "verdict flaky")
+	if len(os.Args) > 3 {
+		fs.Parse(os.Args[3:])
+	}
+
+	// Load existing data
+	data, err := loadVerdictData()
+	if err != nil {
+		return err
+	}
+
+	flaky := detectFlakyTests(data.Entries, *componentFlag, *minRunsFlag)
+
+	if *jsonFlag {
+		if flaky == nil {
+			flaky = []FlakyTest{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(flaky)
+	}
+
+	output.Success("⚖️ FLAKY TESTS")
+	fmt.Println("")
+	if *componentFlag != "" {
+		fmt.Printf("Component: %s\n", *componentFlag)
+	}
+	fmt.Printf("Minimum runs: %d\n", *minRunsFlag)
+	fmt.Println("")
+
+	if len(flaky) == 0 {
+		output.Success("✓ No flaky tests detected")
+		return nil
+	}
+
+	for _, test := range flaky {
+		fmt.Printf("%s/%s\n", test.Component, output.Yellow+test.Test+output.Reset)
+		fmt.Printf("  Runs: %d (Pass: %d, Fail: %d)\n", test.Runs, test.Passes, test.Fails)
+		fmt.Printf("  Flips: %d (%.0f%% of transitions)\n", test.Flips, test.FlipRate*100)
+		fmt.Printf("  Last Result: %s\n", strings.ToUpper(test.LastResult))
+		fmt.Println("")
+	}
+
+	fmt.Printf("%d flaky tests found\n", len(flaky))
+	return nil
+}
+
+// runVerdictCompare compares success rate and duration between two identities
+func runVerdictCompare() error {
+	fs := flag.NewFlagSet("verdict compare", flag.ExitOnError)
+	aFlag := fs.String("a", "", "First identity (required)")
+	bFlag := fs.String("b", "", "Second identity (required)")
+	jsonFlag := fs.Bool("json", false, "Output as JSON")
+
+	// Parse remaining args (after "verdict compare")
+	if len(os.Args) > 3 {
+		fs.Parse(os.Args[3:])
+	}
+
+	// Validate required fields
+	if *aFlag == "" || *bFlag == "" {
+		return fmt.Errorf("--a and --b are required")
+	}
+	if !identity.IsValid(*aFlag) {
+		return fmt.Errorf("invalid identity: %s", *aFlag)
+	}
+	if !identity.IsValid(*bFlag) {
+		return fmt.Errorf("invalid identity: %s", *bFlag)
+	}
+
+	// Load existing data
+	data, err := loadVerdictData()
+	if err != nil {
+		return err
+	}
+
+	comparison := compareIdentities(data.Entries, *aFlag, *bFlag)
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(comparison)
+	}
+
+	output.Success("⚖️ VERDICT COMPARISON")
+	fmt.Println("")
+	fmt.Printf("%s vs %s\n", output.Yellow+comparison.A+output.Reset, output.Yellow+comparison.B+output.Reset)
+	fmt.Println("")
+
+	if len(comparison.Components) == 0 {
+		fmt.Println("No shared components with test results")
+	} else {
+		fmt.Printf("%-20s %-34s %s\n", "", "SUCCESS RATE", "AVG DURATION")
+		fmt.Printf("%-20s %10s %10s %9s   %9s %9s %9s\n", "COMPONENT",
+			util.Truncate(comparison.A, 10), util.Truncate(comparison.B, 10), "Δ",
+			util.Truncate(comparison.A, 9), util.Truncate(comparison.B, 9), "Δ")
+		fmt.Println(strings.Repeat("─", 84))
+		for _, c := range comparison.Components {
+			fmt.Printf("%-20s %9.1f%% %9.1f%% %+8.1f%%   %8.2fs %8.2fs %+8.2fs\n",
+				util.Truncate(c.Component, 20), c.SuccessRateA, c.SuccessRateB, c.SuccessDelta,
+				c.AvgDurationA, c.AvgDurationB, c.DurationDelta)
+		}
+		fmt.Println("")
+		fmt.Printf("Δ is %s minus %s\n", comparison.B, comparison.A)
+	}
+
+	if len(comparison.OnlyA) > 0 {
+		fmt.Printf("Only %s: %s\n", comparison.A, strings.Join(comparison.OnlyA, ", "))
+	}
+	if len(comparison.OnlyB) > 0 {
+		fmt.Printf("Only %s: %s\n", comparison.B, strings.Join(comparison.OnlyB, ", "))
+	}
+
+	return nil
+}
+
 // Helper functions
 
+// compareIdentities builds per-component summaries for two identities and
+// pairs them up on shared components
+func compareIdentities(entries []VerdictEntry, a, b string) IdentityComparison {
+	var entriesA, entriesB []VerdictEntry
+	for _, entry := range entries {
+		switch entry.Identity {
+		case a:
+			entriesA = append(entriesA, entry)
+		case b:
+			entriesB = append(entriesB, entry)
+		}
+	}
+
+	summariesB := make(map[string]VerdictSummary)
+	for _, summary := range generateSummaries(entriesB) {
+		summariesB[summary.Component] = summary
+	}
+
+	comparison := IdentityComparison{
+		A:          a,
+		B:          b,
+		Components: []ComponentComparison{},
+		OnlyA:      []string{},
+		OnlyB:      []string{},
+	}
+
+	seen := make(map[string]bool)
+	for _, sa := range generateSummaries(entriesA) {
+		seen[sa.Component] = true
+		sb, shared := summariesB[sa.Component]
+		if !shared {
+			comparison.OnlyA = append(comparison.OnlyA, sa.Component)
+			continue
+		}
+
+		comparison.Components = append(comparison.Components, ComponentComparison{
+			Component:     sa.Component,
+			SuccessRateA:  sa.SuccessRate,
+			SuccessRateB:  sb.SuccessRate,
+			SuccessDelta:  sb.SuccessRate - sa.SuccessRate,
+			AvgDurationA:  sa.AvgDuration,
+			AvgDurationB:  sb.AvgDuration,
+			DurationDelta: sb.AvgDuration - sa.AvgDuration,
+			TestsA:        sa.TotalTests,
+			TestsB:        sb.TotalTests,
+		})
+	}
+
+	for component := range summariesB {
+		if !seen[component] {
+			comparison.OnlyB = append(comparison.OnlyB, component)
+		}
+	}
+	sort.Strings(comparison.OnlyB)
+
+	return comparison
+}
+
+// detectFlakyTests finds tests with both passes and failures in their history,
+// sorted by how often consecutive runs flip result
+func detectFlakyTests(entries []VerdictEntry, component string, minRuns int) []FlakyTest {
+	type testKey struct{ component, test string }
+	history := make(map[testKey][]VerdictEntry)
+
+	for _, entry := range entries {
+		if entry.Type != "test" {
+			continue
+		}
+		if component != "" && entry.Component != component {
+			continue
+		}
+		key := testKey{entry.Component, entry.Test}
+		history[key] = append(history[key], entry)
+	}
+
+	var flaky []FlakyTest
+	for key, runs := range history {
+		if len(runs) < minRuns {
+			continue
+		}
+
+		sort.Slice(runs, func(i, j int) bool {
+			return runs[i].Timestamp.Before(runs[j].Timestamp)
+		})
+
+		result := FlakyTest{
+			Component:  key.component,
+			Test:       key.test,
+			Runs:       len(runs),
+			LastResult: runs[len(runs)-1].Result,
+		}
+		for i, run := range runs {
+			if run.Result == "pass" {
+				result.Passes++
+			} else {
+				result.Fails++
+			}
+			if i > 0 && run.Result != runs[i-1].Result {
+				result.Flips++
+			}
+		}
+
+		// Stable tests never mix passes and failures
+		if result.Passes == 0 || result.Fails == 0 {
+			continue
+		}
+
+		result.FlipRate = float64(result.Flips) / float64(result.Runs-1)
+		flaky = append(flaky, result)
+	}
+
+	sort.Slice(flaky, func(i, j int) bool {
+		if flaky[i].FlipRate != flaky[j].FlipRate {
+			return flaky[i].FlipRate > flaky[j].FlipRate
+		}
+		if flaky[i].Runs != flaky[j].Runs {
+			return flaky[i].Runs > flaky[j].Runs
+		}
+		return flaky[i].Component+flaky[i].Test < flaky[j].Component+flaky[j].Test
+	})
+
+	return flaky
+}
+
+// detectDurationRegressions compares each test's average duration over its
+// last recent runs against the average of up to durationBaselineWindow runs
+// before them, and reports tests that slowed down by more than threshold
+// percent. Runs without a recorded duration are ignored.
+func detectDurationRegressions(entries []VerdictEntry, component string, recent int, threshold float64) []DurationRegression {
+	type testKey struct{ component, test string }
+	history := make(map[testKey][]VerdictEntry)
+
+	for _, entry := range entries {
+		if entry.Type != "test" || entry.Duration <= 0 {
+			continue
+		}
+		if component != "" && entry.Component != component {
+			continue
+		}
+		key := testKey{entry.Component, entry.Test}
+		history[key] = append(history[key], entry)
+	}
+
+	var regressions []DurationRegression
+	for key, runs := range history {
+		if len(runs) < recent+minDurationBaselineRuns {
+			continue
+		}
+
+		sort.Slice(runs, func(i, j int) bool {
+			return runs[i].Timestamp.Before(runs[j].Timestamp)
+		})
+
+		split := len(runs) - recent
+		baselineRuns := runs[max(0, split-durationBaselineWindow):split]
+		recentRuns := runs[split:]
+
+		baselineAvg := averageDuration(baselineRuns)
+		recentAvg := averageDuration(recentRuns)
+		percentChange := ((recentAvg - baselineAvg) / baselineAvg) * 100
+
+		if percentChange > threshold {
+			regressions = append(regressions, DurationRegression{
+				Component:     key.component,
+				Test:          key.test,
+				RecentAvg:     recentAvg,
+				BaselineAvg:   baselineAvg,
+				PercentChange: percentChange,
+				RecentRuns:    len(recentRuns),
+				BaselineRuns:  len(baselineRuns),
+			})
+		}
+	}
+
+	sort.Slice(regressions, func(i, j int) bool {
+		if regressions[i].PercentChange != regressions[j].PercentChange {
+			return regressions[i].PercentChange > regressions[j].PercentChange
+		}
+		return regressions[i].Component+regressions[i].Test < regressions[j].Component+regressions[j].Test
+	})
+
+	return regressions
+}
+
+// averageDuration returns the mean duration of runs in seconds
+func averageDuration(runs []VerdictEntry) float64 {
+	total := 0.0
+	for _, run := range runs {
+		total += run.Duration
+	}
+	return total / float64(len(runs))
+}
+
+// displayDurationRegressions prints the result of check --tests
+func displayDurationRegressions(regressions []DurationRegression, component string, recent int, threshold float64) {
+	scope := component
+	if scope == "" {
+		scope = "all components"
+	}
+
+	if len(regressions) == 0 {
+		output.Success("✓ No test duration regressions detected")
+		fmt.Printf("Component: %s (threshold: %.1f%%, last %d runs vs history)\n", scope, threshold, recent)
+		return
+	}
+
+	output.Header("⚠️ TEST DURATION REGRESSIONS")
+	fmt.Println("")
+	fmt.Printf("Component: %s\n", scope)
+	fmt.Printf("Threshold: %.1f%% (last %d runs vs up to %d before)\n", threshold, recent, durationBaselineWindow)
+	fmt.Println("")
+	for _, r := range regressions {
+		fmt.Printf("Test: %s\n", output.Yellow+r.Component+"/"+r.Test+output.Reset)
+		fmt.Printf("  Recent: %.2fs avg (%d runs)\n", r.RecentAvg, r.RecentRuns)
+		fmt.Printf("  Baseline: %.2fs avg (%d runs)\n", r.BaselineAvg, r.BaselineRuns)
+		fmt.Printf("  Change: %s%+.1f%%%s\n", output.Red, r.PercentChange, output.Reset)
+		fmt.Println("")
+	}
+}
+
+// verdictLockTimeout is how long writers wait for another verdict command
+// to release the store
+const verdictLockTimeout = 10 * time.Second
+
 func loadVerdictData() (*VerdictData, error) {
 	verdictPath, err := getVerdictPath()
 	if err != nil {
@@ -507,12 +1260,55 @@ func loadVerdictData() (*VerdictData, error) {
 
 	var data VerdictData
 	if err := json.Unmarshal(content, &data); err != nil {
-		return nil, fmt.Errorf("failed to parse verdict data: %w", err)
+		// Move the corrupt store aside instead of failing every command;
+		// the backup keeps the history recoverable by hand
+		backupPath := fmt.Sprintf("%s.corrupt-%s", verdictPath, time.Now().Format("20060102-150405"))
+		if renameErr := os.Rename(verdictPath, backupPath); renameErr != nil {
+			return nil, fmt.Errorf("failed to parse verdict data: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "%sWarning: verdict data was corrupt (%v); moved to %s and starting fresh%s\n", output.Yellow, err, backupPath, output.Reset)
+		return &VerdictData{
+			Entries:   []VerdictEntry{},
+			Baselines: []VerdictBaseline{},
+		}, nil
 	}
 
 	return &data, nil
 }
 
+// updateVerdictData loads the verdict store, applies fn, and saves the
+// result while holding the store's lock file, so concurrent writers
+// serialize instead of overwriting each other's entries
+func updateVerdictData(fn func(*VerdictData) error) (*VerdictData, error) {
+	verdictPath, err := getVerdictPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(verdictPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create verdict directory: %w", err)
+	}
+
+	release, err := util.AcquireLock(verdictPath+".lock", verdictLockTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	data, err := loadVerdictData()
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(data); err != nil {
+		return nil, err
+	}
+	if err := saveVerdictData(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 func saveVerdictData(data *VerdictData) error {
 	verdictPath, err := getVerdictPath()
 	if err != nil {
@@ -531,8 +1327,9 @@ func saveVerdictData(data *VerdictData) error {
 		return fmt.Errorf("failed to marshal verdict data: %w", err)
 	}
 
-	// Write file
-	if err := os.WriteFile(verdictPath, content, 0644); err != nil {
+	// Write to a temp file and rename so an interrupted write can't
+	// truncate the store
+	if err := util.WriteFileAtomic(verdictPath, content, 0644); err != nil {
 		return fmt.Errorf("failed to write verdict data: %w", err)
 	}
 
@@ -540,15 +1337,19 @@ func saveVerdictData(data *VerdictData) error {
 }
 
 func getVerdictPath() (string, error) {
-	home, err := os.UserHomeDir()
+	ramDir, err := ram.DefaultRAMDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, ".claude", "ram", "deus", "verdicts", "entries.json"), nil
+	return filepath.Join(ramDir, "deus", "verdicts", "entries.json"), nil
 }
 
 func findBaseline(data *VerdictData, component, metric string) *VerdictBaseline {
-	for _, baseline := range data.Baselines {
+	return findBaselineIn(data.Baselines, component, metric)
+}
+
+func findBaselineIn(baselines []VerdictBaseline, component, metric string) *VerdictBaseline {
+	for _, baseline := range baselines {
 		if baseline.Component == component && baseline.Metric == metric {
 			return &baseline
 		}
@@ -556,6 +1357,15 @@ func findBaseline(data *VerdictData, component, metric string) *VerdictBaseline
 	return nil
 }
 
+// resolveBaseline prefers a baseline from the repo-local file and falls back
+// to the RAM store for metrics the file doesn't cover
+func resolveBaseline(fileBaselines []VerdictBaseline, data *VerdictData, component, metric string) *VerdictBaseline {
+	if baseline := findBaselineIn(fileBaselines, component, metric); baseline != nil {
+		return baseline
+	}
+	return findBaseline(data, component, metric)
+}
+
 func generateSummaries(entries []VerdictEntry) []VerdictSummary {
 	// Group by component
 	byComponent := make(map[string][]VerdictEntry)
@@ -636,12 +1446,24 @@ func printVerdictUsage() {
 	fmt.Println("  report      Generate verdict report")
 	fmt.Println("  baseline    Set a performance baseline")
 	fmt.Println("  list        List all verdicts")
+	fmt.Println("  flaky       Detect tests that flip between pass and fail")
+	fmt.Println("  compare     Compare two identities across shared components")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  matrix verdict record --identity smith --component auth --test login --result pass --duration 2.3")
+	fmt.Println("  matrix verdict record --identity smith --component billing --test charge --result pass --new")
+	fmt.Println("  matrix verdict record --identity smith --component auth --test login --result pass --tags env=ci")
 	fmt.Println("  matrix verdict bench --identity smith --component parser --metric \"ops/sec\" --value 1000")
 	fmt.Println("  matrix verdict check --component parser --threshold 10")
+	fmt.Println("  matrix verdict check --tests --component auth --recent 3 --threshold 50")
 	fmt.Println("  matrix verdict baseline --component parser --metric \"ops/sec\" --value 1000 --identity deus")
+	fmt.Println("  matrix verdict baseline --component parser --metric \"ops/sec\" --value 1000 --identity deus --baseline-file perf/baselines.json")
+	fmt.Println("  matrix verdict check --component parser --baseline-file perf/baselines.json")
 	fmt.Println("  matrix verdict report --component auth")
+	fmt.Println("  matrix verdict report --format markdown")
+	fmt.Println("  matrix verdict report --component auth --tag env=ci")
+	fmt.Println("  matrix verdict list --tag env=local")
 	fmt.Println("  matrix verdict list")
+	fmt.Println("  matrix verdict flaky --component auth --min-runs 5")
+	fmt.Println("  matrix verdict compare --a smith --b neo")
 }
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRenderVerdictMarkdown(t *testing.T) {
+	summaries := []VerdictSummary{
+		{Component: "auth", TotalTests: 4, PassCount: 3, FailCount: 1, SuccessRate: 75, AvgDuration: 2.345, Trend: "↑"},
+		{Component: "a|b", TotalTests: 2, PassCount: 0, FailCount: 2, SuccessRate: 0, Trend: "↓"},
+	}
+
+	md := renderVerdictMarkdown(summaries, 7)
+
+	for _, want := range []string{
+		"Total entries: 7",
+		"| Component | Tests | Pass / Fail | Success Rate | Avg Duration | Trend |",
+		"| auth | 4 | 3 / 1 | 75.0% | 2.35s | ↑ improving |",
+		`| a\|b | 2 | 0 / 2 | 0.0% | - | ↓ declining |`,
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "\033[") {
+		t.Errorf("Markdown output contains ANSI escapes:\n%s", md)
+	}
+}
+
+func TestUpdateVerdictDataConcurrent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := updateVerdictData(func(data *VerdictData) error {
+				data.Entries = append(data.Entries, VerdictEntry{Type: "test", Result: "pass"})
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := loadVerdictData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Entries) != 8 {
+		t.Errorf("Expected 8 entries after concurrent updates, got %d", len(data.Entries))
+	}
+}
+
+func TestLoadVerdictDataRecoversFromCorruption(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	verdictPath, err := getVerdictPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(verdictPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(verdictPath, []byte(`{"entries": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := loadVerdictData()
+	if err != nil {
+		t.Fatalf("Expected corrupt data to be recovered, got error: %v", err)
+	}
+	if len(data.Entries) != 0 {
+		t.Errorf("Expected empty data, got %d entries", len(data.Entries))
+	}
+
+	backups, _ := filepath.Glob(verdictPath + ".corrupt-*")
+	if len(backups) != 1 {
+		t.Fatalf("Expected one backup of the corrupt file, got %v", backups)
+	}
+	if content, _ := os.ReadFile(backups[0]); string(content) != `{"entries": [` {
+		t.Errorf("Backup content changed: %q", content)
+	}
+}
+
+func TestDetectDurationRegressions(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	var entries []VerdictEntry
+	add := func(component, test string, durations ...float64) {
+		for _, d := range durations {
+			entries = append(entries, VerdictEntry{
+				Type:      "test",
+				Component: component,
+				Test:      test,
+				Result:    "pass",
+				Duration:  d,
+				Timestamp: start.Add(time.Duration(len(entries)) * time.Hour),
+			})
+		}
+	}
+
+	add("auth", "login", 1.0, 1.2, 0.8, 1.0, 5.0, 5.2, 4.8) // 5x slower recently
+	add("auth", "logout", 1.0, 1.0, 1.0, 1.0, 1.05)         // within threshold
+	add("auth", "signup", 1.0, 9.0)                         // not enough history
+	add("billing", "charge", 2.0, 2.0, 2.0, 8.0, 8.0, 8.0)  // other component
+	add("auth", "untimed", 0, 0, 0, 0, 0, 0)                // no durations recorded
+
+	regressions := detectDurationRegressions(entries, "auth", 3, 10)
+	if len(regressions) != 1 {
+		t.Fatalf("Expected 1 regression, got %+v", regressions)
+	}
+	r := regressions[0]
+	if r.Test != "login" || r.RecentRuns != 3 || r.BaselineRuns != 4 {
+		t.Errorf("Unexpected regression: %+v", r)
+	}
+	if r.BaselineAvg != 1.0 || r.RecentAvg != 5.0 || r.PercentChange != 400 {
+		t.Errorf("Expected 1.00s -> 5.00s (+400%%), got %.2f -> %.2f (%+.1f%%)", r.BaselineAvg, r.RecentAvg, r.PercentChange)
+	}
+
+	all := detectDurationRegressions(entries, "", 3, 10)
+	if len(all) != 2 || all[0].Test != "login" || all[1].Test != "charge" {
+		t.Errorf("Expected login then charge across components, got %+v", all)
+	}
+}
+
+func TestNewComponentSuggestions(t *testing.T) {
+	entries := []VerdictEntry{
+		{Component: "auth"}, {Component: "auth"}, {Component: "Authz"},
+		{Component: "billing"}, {Component: "parser"},
+	}
+
+	if isNew, _ := newComponentSuggestions(nil, "auth"); isNew {
+		t.Error("Expected empty store not to flag a new component")
+	}
+	if isNew, _ := newComponentSuggestions(entries, "billing"); isNew {
+		t.Error("Expected existing component not to be flagged")
+	}
+
+	isNew, suggestions := newComponentSuggestions(entries, "aauth")
+	if !isNew || strings.Join(suggestions, ",") != "auth,Authz" {
+		t.Errorf("Expected new with suggestions auth,Authz, got %v %v", isNew, suggestions)
+	}
+
+	isNew, suggestions = newComponentSuggestions(entries, "scheduler")
+	if !isNew || len(suggestions) != 0 {
+		t.Errorf("Expected new without suggestions, got %v %v", isNew, suggestions)
+	}
+}
+
+func TestParseVerdictTags(t *testing.T) {
+	tags, err := parseVerdictTags([]string{"env=ci, os=linux", "env=staging", "region="})
+	if err != nil {
+		t.Fatalf("parseVerdictTags() failed: %v", err)
+	}
+	if len(tags) != 3 || tags["env"] != "staging" || tags["os"] != "linux" || tags["region"] != "" {
+		t.Errorf("Unexpected tags: %v", tags)
+	}
+	if got := formatVerdictTags(tags); got != "env=staging, os=linux, region=" {
+		t.Errorf("formatVerdictTags() = %q", got)
+	}
+
+	if tags, err := parseVerdictTags(nil); err != nil || tags != nil {
+		t.Errorf("Expected no tags without --tags, got %v, %v", tags, err)
+	}
+	for _, bad := range []string{"ci", "=ci"} {
+		if _, err := parseVerdictTags([]string{bad}); err == nil {
+			t.Errorf("Expected error for tag %q", bad)
+		}
+	}
+}
+
+func TestFilterVerdictTags(t *testing.T) {
+	entries := []VerdictEntry{
+		{ID: "ci-linux", Tags: map[string]string{"env": "ci", "os": "linux"}},
+		{ID: "ci-mac", Tags: map[string]string{"env": "ci", "os": "mac"}},
+		{ID: "local", Tags: map[string]string{"env": "local"}},
+		{ID: "untagged"},
+	}
+
+	ids := func(entries []VerdictEntry) string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := ids(filterVerdictTags(entries, nil)); got != "ci-linux,ci-mac,local,untagged" {
+		t.Errorf("Expected no filter to keep everything, got %s", got)
+	}
+	if got := ids(filterVerdictTags(entries, map[string]string{"env": "ci"})); got != "ci-linux,ci-mac" {
+		t.Errorf("env=ci matched %s", got)
+	}
+	if got := ids(filterVerdictTags(entries, map[string]string{"env": "ci", "os": "mac"})); got != "ci-mac" {
+		t.Errorf("env=ci,os=mac matched %s", got)
+	}
+}
+
+func TestVerdictBaselineFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "perf", "baselines.json")
+
+	missing, err := loadVerdictBaselineFile(path)
+	if err != nil || missing != nil {
+		t.Fatalf("Expected nil for a missing baseline file, got %+v, %v", missing, err)
+	}
+
+	file := &VerdictBaselineFile{}
+	file.Baselines = setBaseline(file.Baselines, VerdictBaseline{Component: "parser", Metric: "ops/sec", Value: 900})
+	file.Baselines = setBaseline(file.Baselines, VerdictBaseline{Component: "auth", Metric: "p99", Value: 40})
+	file.Baselines = setBaseline(file.Baselines, VerdictBaseline{Component: "parser", Metric: "ops/sec", Value: 1000})
+	if err := saveVerdictBaselineFile(path, file); err != nil {
+		t.Fatalf("saveVerdictBaselineFile() failed: %v", err)
+	}
+
+	loaded, err := loadVerdictBaselineFile(path)
+	if err != nil {
+		t.Fatalf("loadVerdictBaselineFile() failed: %v", err)
+	}
+	if len(loaded.Baselines) != 2 || loaded.Baselines[0].Component != "auth" || loaded.Baselines[1].Value != 1000 {
+		t.Fatalf("Expected sorted, replaced baselines, got %+v", loaded.Baselines)
+	}
+
+	store := &VerdictData{Baselines: []VerdictBaseline{
+		{Component: "parser", Metric: "ops/sec", Value: 500},
+		{Component: "parser", Metric: "allocs", Value: 12},
+	}}
+	if b := resolveBaseline(loaded.Baselines, store, "parser", "ops/sec"); b == nil || b.Value != 1000 {
+		t.Errorf("Expected the file baseline to win, got %+v", b)
+	}
+	if b := resolveBaseline(loaded.Baselines, store, "parser", "allocs"); b == nil || b.Value != 12 {
+		t.Errorf("Expected fallback to the RAM store, got %+v", b)
+	}
+	if b := resolveBaseline(nil, store, "auth", "p99"); b != nil {
+		t.Errorf("Expected no baseline, got %+v", b)
+	}
+}
+
+// flakyEntries records each result string as one run per hour, in order
+func flakyEntries() []VerdictEntry {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	var entries []VerdictEntry
+	add := func(component, test string, results ...string) {
+		for _, result := range results {
+			entries = append(entries, VerdictEntry{
+				Type:      "test",
+				Component: component,
+				Test:      test,
+				Result:    result,
+				Timestamp: start.Add(time.Duration(len(entries)) * time.Hour),
+			})
+		}
+	}
+
+	add("auth", "login", "pass", "fail", "pass", "fail", "pass") // flips every run
+	add("auth", "logout", "pass", "pass", "pass", "fail")        // one flip
+	add("auth", "signup", "pass", "pass", "pass")                // stable
+	add("auth", "reset", "pass", "fail")                         // too few runs
+	add("billing", "charge", "fail", "pass", "fail")             // other component
+	entries = append(entries, VerdictEntry{Type: "benchmark", Component: "auth", Metric: "p99", Value: 12})
+	return entries
+}
+
+func TestDetectFlakyTests(t *testing.T) {
+	flaky := detectFlakyTests(flakyEntries(), "auth", 3)
+	if len(flaky) != 2 {
+		t.Fatalf("Expected 2 flaky tests, got %+v", flaky)
+	}
+	login := flaky[0]
+	if login.Test != "login" || login.Runs != 5 || login.Passes != 3 || login.Fails != 2 ||
+		login.Flips != 4 || login.FlipRate != 1 || login.LastResult != "pass" {
+		t.Errorf("Unexpected login: %+v", login)
+	}
+	logout := flaky[1]
+	if logout.Test != "logout" || logout.Flips != 1 || logout.LastResult != "fail" {
+		t.Errorf("Unexpected logout: %+v", logout)
+	}
+
+	// Ties on flip rate go to the test with more runs
+	all := detectFlakyTests(flakyEntries(), "", 3)
+	var names []string
+	for _, f := range all {
+		names = append(names, f.Component+"/"+f.Test)
+	}
+	if strings.Join(names, " ") != "auth/login billing/charge auth/logout" {
+		t.Errorf("Unexpected order: %v", names)
+	}
+
+	if got := detectFlakyTests(flakyEntries(), "auth", 2); len(got) != 3 {
+		t.Errorf("Expected --min-runs 2 to include reset, got %+v", got)
+	}
+}
+
+func TestVerdictFlakyOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := saveVerdictData(&VerdictData{Entries: flakyEntries()}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := captureStdout(t, []string{"matrix", "verdict", "flaky", "--component", "billing", "--json"}, runVerdictFlaky)
+	if err != nil {
+		t.Fatalf("verdict flaky failed: %v", err)
+	}
+	var flaky []FlakyTest
+	if err := json.Unmarshal([]byte(out), &flaky); err != nil {
+		t.Fatalf("Output is not a list of flaky tests: %v\n%s", err, out)
+	}
+	if len(flaky) != 1 || flaky[0].Test != "charge" || flaky[0].Flips != 2 {
+		t.Errorf("Unexpected flaky tests: %+v", flaky)
+	}
+
+	out, err = captureStdout(t, []string{"matrix", "verdict", "flaky", "--component", "auth"}, runVerdictFlaky)
+	if err != nil {
+		t.Fatalf("verdict flaky failed: %v", err)
+	}
+	if !strings.Contains(out, "Flips: 4 (100% of transitions)") || !strings.Contains(out, "2 flaky tests found") {
+		t.Errorf("Unexpected report:\n%s", out)
+	}
+
+	// An empty result is still a JSON list
+	out, err = captureStdout(t, []string{"matrix", "verdict", "flaky", "--component", "search", "--json"}, runVerdictFlaky)
+	if err != nil || strings.TrimSpace(out) != "[]" {
+		t.Errorf("Expected [], got %q, %v", out, err)
+	}
+}
+
+// compareEntries gives neo and smith one shared component and one each
+func compareEntries() []VerdictEntry {
+	run := func(who, component, result string, duration float64) VerdictEntry {
+		return VerdictEntry{Type: "test", Identity: who, Component: component, Test: "suite", Result: result, Duration: duration}
+	}
+	return []VerdictEntry{
+		run("neo", "auth", "pass", 1),
+		run("neo", "auth", "pass", 3),
+		run("neo", "billing", "pass", 1),
+		run("smith", "auth", "pass", 2),
+		run("smith", "auth", "fail", 4),
+		run("smith", "search", "fail", 1),
+		run("trinity", "auth", "fail", 9),
+	}
+}
+
+func TestCompareIdentities(t *testing.T) {
+	c := compareIdentities(compareEntries(), "neo", "smith")
+
+	if len(c.Components) != 1 {
+		t.Fatalf("Expected one shared component, got %+v", c.Components)
+	}
+	auth := c.Components[0]
+	if auth.Component != "auth" || auth.SuccessRateA != 100 || auth.SuccessRateB != 50 || auth.SuccessDelta != -50 {
+		t.Errorf("Unexpected success rates: %+v", auth)
+	}
+	if auth.AvgDurationA != 2 || auth.AvgDurationB != 3 || auth.DurationDelta != 1 || auth.TestsA != 2 || auth.TestsB != 2 {
+		t.Errorf("Unexpected durations: %+v", auth)
+	}
+	if strings.Join(c.OnlyA, ",") != "billing" || strings.Join(c.OnlyB, ",") != "search" {
+		t.Errorf("OnlyA = %v, OnlyB = %v", c.OnlyA, c.OnlyB)
+	}
+}
+
+func TestVerdictCompareOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := saveVerdictData(&VerdictData{Entries: compareEntries()}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := captureStdout(t, []string{"matrix", "verdict", "compare", "--a", "neo", "--b", "smith", "--json"}, runVerdictCompare)
+	if err != nil {
+		t.Fatalf("verdict compare failed: %v", err)
+	}
+	var c IdentityComparison
+	if err := json.Unmarshal([]byte(out), &c); err != nil {
+		t.Fatalf("Output is not a comparison: %v\n%s", err, out)
+	}
+	if c.A != "neo" || c.B != "smith" || len(c.Components) != 1 || c.Components[0].SuccessDelta != -50 {
+		t.Errorf("Unexpected comparison: %+v", c)
+	}
+
+	out, err = captureStdout(t, []string{"matrix", "verdict", "compare", "--a", "neo", "--b", "smith"}, runVerdictCompare)
+	if err != nil {
+		t.Fatalf("verdict compare failed: %v", err)
+	}
+	for _, want := range []string{"100.0%", "50.0%", "-50.0%", "+1.00s", "Δ is smith minus neo", "Only neo: billing", "Only smith: search"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the table:\n%s", want, out)
+		}
+	}
+
+	// Identities with nothing in common say so
+	out, err = captureStdout(t, []string{"matrix", "verdict", "compare", "--a", "neo", "--b", "oracle"}, runVerdictCompare)
+	if err != nil || !strings.Contains(out, "No shared components with test results") {
+		t.Errorf("Expected no shared components, got %v:\n%s", err, out)
+	}
+
+	for _, args := range [][]string{{"--a", "neo"}, {"--a", "neo", "--b", "link"}} {
+		argv := append([]string{"matrix", "verdict", "compare"}, args...)
+		if _, err := captureStdout(t, argv, runVerdictCompare); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
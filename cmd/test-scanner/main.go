@@ -18,11 +18,12 @@ func main() {
 	fmt.Printf("Scanning RAM directory: %s\n\n", ramDir)
 
 	// Scan the directory
-	files, err := ram.ScanDir(ramDir)
+	result, err := ram.ScanDirDetailed(ramDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error scanning directory: %v\n", err)
 		os.Exit(1)
 	}
+	files := result.Files
 
 	fmt.Printf("Found %d markdown files:\n\n", len(files))
 
@@ -41,4 +42,12 @@ func main() {
 		}
 		fmt.Println()
 	}
+
+	// Report files that were too large or not valid UTF-8 text
+	if len(result.Skipped) > 0 {
+		fmt.Printf("Skipped %d files (oversized or not UTF-8 text):\n", len(result.Skipped))
+		for _, path := range result.Skipped {
+			fmt.Printf("  - %s\n", path)
+		}
+	}
 }
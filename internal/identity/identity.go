@@ -5,6 +5,8 @@
 // each with their own working directory under ~/.claude/ram/{identity}/.
 //
 // This package validates identity names and resolves their RAM directory paths.
+// The RAM root can be moved with SetRAMDir (the --ram-dir flag) or the
+// MATRIX_RAM_DIR environment variable.
 package identity
 
 import (
@@ -12,8 +14,16 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/coryzibell/matrix/internal/util"
 )
 
+// RAMDirEnv is the environment variable that overrides the RAM directory
+const RAMDirEnv = "MATRIX_RAM_DIR"
+
+// ramDirOverride is set by SetRAMDir and takes precedence over RAMDirEnv
+var ramDirOverride string
+
 // All known identities in the matrix system
 var identities = []string{
 	"neo",
@@ -64,18 +74,54 @@ func IsValid(name string) bool {
 	return false
 }
 
+// SetRAMDir overrides the RAM directory for the rest of the process. The
+// override is also exported as RAMDirEnv so child processes, such as the
+// command alt-routes plain re-runs, see the same directory. An empty dir
+// removes the override.
+func SetRAMDir(dir string) {
+	ramDirOverride = dir
+	if dir == "" {
+		os.Unsetenv(RAMDirEnv)
+		return
+	}
+	os.Setenv(RAMDirEnv, dir)
+}
+
+// RAMDir returns the absolute RAM directory: the SetRAMDir override if set,
+// then $MATRIX_RAM_DIR, then ~/.claude/ram
+func RAMDir() (string, error) {
+	dir := ramDirOverride
+	if dir == "" {
+		dir = os.Getenv(RAMDirEnv)
+	}
+	if dir != "" {
+		abs, err := filepath.Abs(util.ExpandPath(dir))
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve RAM directory %s: %w", dir, err)
+		}
+		return abs, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".claude", "ram"), nil
+}
+
 // RAMPath returns the expanded path to an identity's RAM directory
-// Returns ~/.claude/ram/{name} expanded to absolute path
+// Returns {RAMDir}/{name}, ~/.claude/ram/{name} by default
 func RAMPath(name string) (string, error) {
 	normalized := strings.ToLower(strings.TrimSpace(name))
 	if !IsValid(normalized) {
 		return "", fmt.Errorf("invalid identity: %s", name)
 	}
 
-	home, err := os.UserHomeDir()
+	ramDir, err := RAMDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
 
-	return filepath.Join(home, ".claude", "ram", normalized), nil
+	return filepath.Join(ramDir, normalized), nil
 }
@@ -3,6 +3,16 @@
 // Supports colored headers, labeled items, and success messages with automatic
 // color disabling via the NoColor flag. All output goes to stdout.
 //
+// PlainText goes further than NoColor: it also strips emoji and replaces
+// box-drawing and symbol glyphs with ASCII so output reads cleanly on screen
+// readers and braille displays.
+//
+// EmitJSON is the shared sink for machine-readable output, so commands don't
+// hand-build JSON.
+//
+// Progress draws an in-place counter on stderr during long scans, only when
+// stderr is a terminal and Quiet is unset.
+//
 // Example:
 //
 //	output.Header("Processing files")
@@ -10,7 +20,14 @@
 //	output.Success("All done!")
 package output
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
 
 // ANSI color codes
 const (
@@ -25,6 +42,22 @@ const (
 // NoColor disables color output when true
 var NoColor bool
 
+// ansiPattern matches ANSI escape sequences
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
+// emojiPattern matches pictographic emoji and their variation selectors
+var emojiPattern = regexp.MustCompile(`[\x{1F000}-\x{1FAFF}\x{2600}-\x{27BF}\x{2B00}-\x{2BFF}\x{FE0F}\x{200D}]`)
+
+// glyphReplacer maps decorative glyphs to readable ASCII
+var glyphReplacer = strings.NewReplacer(
+	"═", "=", "━", "=", "─", "-", "│", "|", "┃", "|",
+	"├", "|", "└", "`", "┌", "+", "┐", "+", "┘", "+", "┼", "+",
+	"→", "->", "←", "<-", "↔", "<->", "⇒", "=>",
+	"•", "-", "·", "-", "…", "...",
+	"✓", "[ok]", "✔", "[ok]", "✗", "[x]", "✘", "[x]", "⚠", "[!]",
+	"█", "#", "░", ".",
+)
+
 // color wraps text in an ANSI color code if NoColor is false
 func color(colorCode, text string) string {
 	if NoColor {
@@ -33,6 +66,20 @@ func color(colorCode, text string) string {
 	return colorCode + text + Reset
 }
 
+// PlainText strips ANSI codes and emoji from text and replaces symbol glyphs
+// with ASCII equivalents, collapsing any whitespace left behind
+func PlainText(text string) string {
+	text = ansiPattern.ReplaceAllString(text, "")
+
+	// Preserve indentation, but tidy gaps left by removed glyphs
+	trimmed := strings.TrimLeft(text, " \t")
+	indent := text[:len(text)-len(trimmed)]
+
+	trimmed = glyphReplacer.Replace(trimmed)
+	trimmed = emojiPattern.ReplaceAllString(trimmed, "")
+	return indent + strings.Join(strings.Fields(trimmed), " ")
+}
+
 // Header prints colored header text in cyan
 func Header(text string) {
 	fmt.Println(color(Cyan, text))
@@ -47,3 +94,19 @@ func Item(label string, value string) {
 func Success(text string) {
 	fmt.Println(color(Green, text))
 }
+
+// EmitJSON writes v to stdout as indented JSON followed by a newline.
+// Color is disabled afterwards so any later status output can't mix ANSI
+// codes into a JSON stream.
+func EmitJSON(v any) error {
+	NoColor = true
+	return writeJSON(os.Stdout, v)
+}
+
+// writeJSON encodes v as two-space indented JSON without HTML escaping
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"ansi", "\033[32mdone\033[0m", "done"},
+		{"emoji header", "📜 Contract Ledger", "Contract Ledger"},
+		{"box heading", "═══ HOT ARTIFACTS ═══", "=== HOT ARTIFACTS ==="},
+		{"arrow", "neo → smith", "neo -> smith"},
+		{"indent kept", "    ⚠ Breaking change", "    [!] Breaking change"},
+		{"list marker", "  • item", "  - item"},
+		{"plain unchanged", "Total: 3", "Total: 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(tt.input); got != tt.want {
+				t.Errorf("PlainText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	type finding struct {
+		File  string `json:"file"`
+		Match string `json:"match"`
+	}
+
+	var buf bytes.Buffer
+	input := []finding{{File: "a\\b.go", Match: "x := \"<tag>\"\n\ttab"}}
+	if err := writeJSON(&buf, input); err != nil {
+		t.Fatalf("writeJSON() failed: %v", err)
+	}
+
+	out := buf.String()
+	if out[len(out)-1] != '\n' {
+		t.Error("Expected trailing newline")
+	}
+
+	var decoded []finding
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("writeJSON() produced invalid JSON: %v\n%s", err, out)
+	}
+	if len(decoded) != 1 || decoded[0] != input[0] {
+		t.Errorf("Round trip mismatch: got %+v, want %+v", decoded, input)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("<tag>")) {
+		t.Errorf("Expected HTML characters left unescaped, got %s", out)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	var buf bytes.Buffer
+	p := &Progress{w: &buf, label: "Scanning"}
+	for i := 0; i < 3; i++ {
+		p.Tick()
+	}
+	if p.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", p.Count())
+	}
+	// Redraws are throttled, so only the first tick draws
+	if got := buf.String(); got != "\r\033[K/ Scanning... 1 files" {
+		t.Errorf("Unexpected progress output %q", got)
+	}
+	p.Done()
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\r\033[K")) {
+		t.Errorf("Expected Done to clear the line, got %q", buf.String())
+	}
+
+	// Disabled progress still counts but never writes
+	quiet := &Progress{label: "Scanning"}
+	quiet.Tick()
+	quiet.Done()
+	if quiet.Count() != 1 {
+		t.Errorf("Expected disabled progress to count, got %d", quiet.Count())
+	}
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Quiet disables progress indicators
+var Quiet bool
+
+// progressInterval throttles redraws so fast walks don't flood the terminal
+const progressInterval = 100 * time.Millisecond
+
+// spinnerFrames cycle once per redraw
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// Progress is a files-scanned counter that redraws in place on stderr.
+// It is a no-op unless stderr is a terminal and Quiet is unset, so piped
+// and machine-readable output never sees it. Not safe for concurrent use;
+// call it from the goroutine doing the walk.
+type Progress struct {
+	w     io.Writer // nil when disabled
+	label string
+	count int
+	frame int
+	last  time.Time
+	drawn bool
+}
+
+// NewProgress starts a progress counter labeled e.g. "Scanning"
+func NewProgress(label string) *Progress {
+	if Quiet || !IsTerminal(os.Stderr) {
+		return &Progress{label: label}
+	}
+	return &Progress{w: os.Stderr, label: label}
+}
+
+// Tick counts one more item, redrawing at most every progressInterval
+func (p *Progress) Tick() {
+	p.count++
+	if p.w == nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(p.last) < progressInterval {
+		return
+	}
+	p.last = now
+	p.frame = (p.frame + 1) % len(spinnerFrames)
+	fmt.Fprintf(p.w, "\r\033[K%s %s... %d files", spinnerFrames[p.frame], p.label, p.count)
+	p.drawn = true
+}
+
+// Count returns the number of items ticked so far
+func (p *Progress) Count() int {
+	return p.count
+}
+
+// Done clears the progress line so the report starts on a clean line
+func (p *Progress) Done() {
+	if p.w != nil && p.drawn {
+		fmt.Fprint(p.w, "\r\033[K")
+		p.drawn = false
+	}
+}
+
+// IsTerminal reports whether f is an interactive terminal. /dev/null is
+// also a character device, so it is ruled out explicitly.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(info, null) {
+		return false
+	}
+	return true
+}
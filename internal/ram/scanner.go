@@ -6,15 +6,24 @@
 //
 // The scanner walks identity subdirectories (one level deep) and reads all markdown
 // files, returning File structs that contain path, identity, name, and content.
+// Files that aren't valid UTF-8 text or exceed MaxFileSize are skipped so a
+// corrupted or binary .md file can't break downstream regex processing.
 package ram
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/coryzibell/matrix/internal/identity"
 )
 
+// MaxFileSize is the largest file (in bytes) ScanDir will read
+const MaxFileSize = 5 * 1024 * 1024
+
 // File represents a markdown file found in the RAM directory
 type File struct {
 	Path     string // Full absolute path to the file
@@ -23,33 +32,50 @@ type File struct {
 	Content  string // Raw file content
 }
 
-// DefaultRAMDir returns the default RAM directory path with ~ expanded
+// ScanResult holds the files read by ScanDirDetailed along with the paths
+// that were skipped because they were oversized or not valid UTF-8 text
+type ScanResult struct {
+	Files   []File
+	Skipped []string
+}
+
+// DefaultRAMDir returns the RAM directory path with ~ expanded. It is
+// ~/.claude/ram unless overridden by --ram-dir or MATRIX_RAM_DIR (see
+// identity.RAMDir).
 func DefaultRAMDir() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
-	}
-	return filepath.Join(homeDir, ".claude", "ram"), nil
+	return identity.RAMDir()
 }
 
 // ScanDir finds all .md files in the RAM directory subdirectories
 // and returns a slice of File structs populated with their data.
 // It scans one level deep (identity directories) and finds all .md files within.
+// Unreadable, oversized, and non-UTF-8 files are silently skipped; use
+// ScanDirDetailed to find out which.
 func ScanDir(ramDir string) ([]File, error) {
+	result, err := ScanDirDetailed(ramDir)
+	if err != nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+// ScanDirDetailed scans like ScanDir but also reports the paths of .md files
+// skipped for exceeding MaxFileSize or not being valid UTF-8 text.
+func ScanDirDetailed(ramDir string) (ScanResult, error) {
+	var result ScanResult
+
 	// Check if RAM directory exists
 	if _, err := os.Stat(ramDir); err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("RAM directory does not exist: %s", ramDir)
+			return result, fmt.Errorf("RAM directory does not exist: %s", ramDir)
 		}
-		return nil, fmt.Errorf("failed to access RAM directory: %w", err)
+		return result, fmt.Errorf("failed to access RAM directory: %w", err)
 	}
 
-	var files []File
-
 	// Read identity directories (first level)
 	entries, err := os.ReadDir(ramDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read RAM directory %s: %w", ramDir, err)
+		return result, fmt.Errorf("failed to read RAM directory %s: %w", ramDir, err)
 	}
 
 	// Iterate through identity directories
@@ -63,50 +89,95 @@ func ScanDir(ramDir string) ([]File, error) {
 		identityPath := filepath.Join(ramDir, identityName)
 
 		// Read all files in this identity directory
-		err := filepath.WalkDir(identityPath, func(path string, d os.DirEntry, err error) error {
-			if err != nil {
-				// Skip directories we can't read
-				return nil
-			}
-
-			// Skip directories
-			if d.IsDir() {
-				return nil
-			}
-
-			// Only process .md files
-			if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
-				return nil
-			}
-
-			// Read file content
-			content, err := os.ReadFile(path)
-			if err != nil {
-				// Skip files we can't read
-				return nil
-			}
-
-			// Extract name without extension
-			fileName := d.Name()
-			name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
-
-			// Create File struct
-			file := File{
-				Path:     path,
-				Identity: identityName,
-				Name:     name,
-				Content:  string(content),
-			}
-
-			files = append(files, file)
-			return nil
-		})
-
-		if err != nil {
+		if err := scanIdentityDir(identityPath, identityName, &result); err != nil {
 			// Log error but continue with other identities
 			continue
 		}
 	}
 
-	return files, nil
+	return result, nil
+}
+
+// ScanIdentity finds all .md files in a single identity's RAM directory.
+// It validates the identity and walks only ~/.claude/ram/{identity}/, so scoped
+// queries avoid scanning the whole garden. An identity with no RAM directory
+// yet returns no files rather than an error.
+func ScanIdentity(name string) ([]File, error) {
+	identityPath, err := identity.RAMPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(identityPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to access identity directory: %w", err)
+	}
+
+	var result ScanResult
+	if err := scanIdentityDir(identityPath, filepath.Base(identityPath), &result); err != nil {
+		return nil, fmt.Errorf("failed to scan identity directory %s: %w", identityPath, err)
+	}
+	return result.Files, nil
+}
+
+// scanIdentityDir walks one identity directory and appends its readable
+// markdown files to result, recording skipped paths along the way
+func scanIdentityDir(identityPath, identityName string, result *ScanResult) error {
+	return filepath.WalkDir(identityPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			// Skip directories we can't read
+			return nil
+		}
+
+		// Skip directories
+		if d.IsDir() {
+			return nil
+		}
+
+		// Only process .md files
+		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
+			return nil
+		}
+
+		// Skip oversized files before reading them into memory
+		if info, err := d.Info(); err == nil && info.Size() > MaxFileSize {
+			result.Skipped = append(result.Skipped, path)
+			return nil
+		}
+
+		// Read file content
+		content, err := os.ReadFile(path)
+		if err != nil {
+			// Skip files we can't read
+			return nil
+		}
+
+		// Skip binary or corrupted files that aren't UTF-8 text
+		if !isText(content) {
+			result.Skipped = append(result.Skipped, path)
+			return nil
+		}
+
+		// Extract name without extension
+		fileName := d.Name()
+		name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+
+		// Create File struct
+		file := File{
+			Path:     path,
+			Identity: identityName,
+			Name:     name,
+			Content:  string(content),
+		}
+
+		result.Files = append(result.Files, file)
+		return nil
+	})
+}
+
+// isText reports whether content is valid UTF-8 without NUL bytes
+func isText(content []byte) bool {
+	return utf8.Valid(content) && bytes.IndexByte(content, 0) == -1
 }
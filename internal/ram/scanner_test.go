@@ -5,9 +5,13 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/coryzibell/matrix/internal/identity"
 )
 
 func TestDefaultRAMDir(t *testing.T) {
+	t.Setenv(identity.RAMDirEnv, "")
+
 	ramDir, err := DefaultRAMDir()
 	if err != nil {
 		t.Fatalf("DefaultRAMDir() failed: %v", err)
@@ -29,6 +33,40 @@ func TestDefaultRAMDir(t *testing.T) {
 	}
 }
 
+func TestDefaultRAMDirOverride(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(identity.RAMDirEnv, "~/fixtures/ram")
+	defer identity.SetRAMDir("")
+
+	ramDir, err := DefaultRAMDir()
+	if err != nil {
+		t.Fatalf("DefaultRAMDir() failed: %v", err)
+	}
+	if want := filepath.Join(home, "fixtures", "ram"); ramDir != want {
+		t.Errorf("MATRIX_RAM_DIR: got %s, want %s", ramDir, want)
+	}
+
+	// --ram-dir wins over the environment, and relative paths become absolute
+	identity.SetRAMDir("testdata-ram")
+	ramDir, err = DefaultRAMDir()
+	if err != nil {
+		t.Fatalf("DefaultRAMDir() failed: %v", err)
+	}
+	cwd, _ := os.Getwd()
+	if want := filepath.Join(cwd, "testdata-ram"); ramDir != want {
+		t.Errorf("SetRAMDir: got %s, want %s", ramDir, want)
+	}
+
+	smith, err := identity.RAMPath("smith")
+	if err != nil {
+		t.Fatalf("RAMPath() failed: %v", err)
+	}
+	if smith != filepath.Join(ramDir, "smith") {
+		t.Errorf("RAMPath() should follow the override, got %s", smith)
+	}
+}
+
 func TestScanDir(t *testing.T) {
 	// Create temporary test directory structure
 	tmpDir := t.TempDir()
@@ -134,3 +172,101 @@ func TestScanDirEmpty(t *testing.T) {
 		t.Errorf("Expected 0 files in empty directory, got %d", len(files))
 	}
 }
+
+func TestScanDirDetailedSkipsBadFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	neoDir := filepath.Join(tmpDir, "neo")
+	if err := os.MkdirAll(neoDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+
+	goodPath := filepath.Join(neoDir, "good.md")
+	binaryPath := filepath.Join(neoDir, "binary.md")
+	invalidPath := filepath.Join(neoDir, "invalid.md")
+	largePath := filepath.Join(neoDir, "large.md")
+
+	fixtures := map[string][]byte{
+		goodPath:    []byte("# Good\n\nPlain text with unicode: ✓"),
+		binaryPath:  {0x89, 'P', 'N', 'G', 0x00, 0x00},
+		invalidPath: []byte("# Broken \xff\xfe text"),
+		largePath:   []byte(strings.Repeat("a", MaxFileSize+1)),
+	}
+	for path, content := range fixtures {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	result, err := ScanDirDetailed(tmpDir)
+	if err != nil {
+		t.Fatalf("ScanDirDetailed() failed: %v", err)
+	}
+
+	if len(result.Files) != 1 || result.Files[0].Path != goodPath {
+		t.Errorf("Expected only good.md to be scanned, got %+v", result.Files)
+	}
+
+	skipped := make(map[string]bool)
+	for _, path := range result.Skipped {
+		skipped[path] = true
+	}
+	for _, path := range []string{binaryPath, invalidPath, largePath} {
+		if !skipped[path] {
+			t.Errorf("Expected %s to be recorded as skipped", filepath.Base(path))
+		}
+	}
+	if skipped[goodPath] {
+		t.Error("good.md should not be recorded as skipped")
+	}
+
+	// ScanDir keeps its original contract and just drops the bad files
+	files, err := ScanDir(tmpDir)
+	if err != nil {
+		t.Fatalf("ScanDir() failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected ScanDir to return 1 file, got %d", len(files))
+	}
+}
+
+func TestScanIdentity(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(identity.RAMDirEnv, "")
+
+	ramDir := filepath.Join(home, ".claude", "ram")
+	for _, dir := range []string{"smith", "trinity"} {
+		if err := os.MkdirAll(filepath.Join(ramDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s directory: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(ramDir, "smith", "plan.md"), []byte("# Plan"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(ramDir, "trinity", "debug.md"), []byte("# Debug"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	files, err := ScanIdentity(" Smith ")
+	if err != nil {
+		t.Fatalf("ScanIdentity() failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %d: %+v", len(files), files)
+	}
+	if files[0].Identity != "smith" || files[0].Name != "plan" {
+		t.Errorf("Unexpected file: %+v", files[0])
+	}
+
+	files, err = ScanIdentity("neo")
+	if err != nil {
+		t.Errorf("ScanIdentity() on identity without a directory should not error, got: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected no files for neo, got %d", len(files))
+	}
+
+	if _, err := ScanIdentity("nobody"); err == nil {
+		t.Error("ScanIdentity() should reject an invalid identity")
+	}
+}
@@ -0,0 +1,56 @@
+// Package util holds small string and path helpers shared by the matrix
+// commands.
+//
+// Keeping them in one place means, for example, that crossroads and
+// incident-trace derive identical slugs from the same title.
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSlugLength is the longest slug Slugify returns
+const MaxSlugLength = 60
+
+var slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify lowercases text and collapses every run of non-alphanumeric
+// characters into a single hyphen, trimming hyphens from the ends and
+// limiting the result to MaxSlugLength bytes.
+func Slugify(text string) string {
+	slug := slugSeparator.ReplaceAllString(strings.ToLower(text), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > MaxSlugLength {
+		slug = slug[:MaxSlugLength]
+	}
+	return slug
+}
+
+// Truncate shortens s to at most maxLen characters, ending in "..." when
+// anything was cut. It never splits a multi-byte character.
+func Truncate(s string, maxLen int) string {
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	if maxLen <= 3 {
+		return string([]rune(s)[:max(maxLen, 0)])
+	}
+	return string([]rune(s)[:maxLen-3]) + "..."
+}
+
+// ExpandPath replaces a leading ~ with the user's home directory. Paths
+// without one, or when the home directory can't be found, are returned as is.
+func ExpandPath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Use Postgres over SQLite", "use-postgres-over-sqlite"},
+		{"  --Checkout outage!!  ", "checkout-outage"},
+		{"v2.3 rollback (prod)", "v2-3-rollback-prod"},
+		{"!!!", ""},
+		{strings.Repeat("a", 80), strings.Repeat("a", MaxSlugLength)},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.input); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input  string
+		maxLen int
+		want   string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"this is too long", 10, "this is..."},
+		{"héllo wörld", 8, "héllo..."},
+		{"abcdef", 2, "ab"},
+	}
+
+	for _, tt := range tests {
+		if got := Truncate(tt.input, tt.maxLen); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"~", home},
+		{"~/work/notes.md", filepath.Join(home, "work", "notes.md")},
+		{"/tmp/~/x", "/tmp/~/x"},
+		{"~other/x", "~other/x"},
+		{"relative/path", "relative/path"},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandPath(tt.input); got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}